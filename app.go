@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 type App struct {
 	ctx     context.Context
 	service *app.Service
+	initErr error
 }
 
 // NewApp creates a new App application struct
@@ -25,6 +27,7 @@ func NewApp() *App {
 	}
 	return &App{
 		service: svc,
+		initErr: err,
 	}
 }
 
@@ -33,58 +36,60 @@ func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 }
 
-// SearchParams exposed to frontend
-type SearchParams struct {
-	Query         string `json:"query"`
-	Days          int    `json:"days"` // 1, 7, 30, or -1 (Custom)
-	CustomFrom    string `json:"customFrom"` // YYYY-MM-DD
-	CustomTo      string `json:"customTo"`   // YYYY-MM-DD
-	Scope         int    `json:"scope"` // 0=Auto, 1=Chosen, 2=Global
-	ChosenCountry string `json:"chosenCountry"`
-	PivotLang     string `json:"pivotLang"`
+// InitError is why the backend service failed to start, "" if it started.
+// The frontend asks on mount: an event emitted at startup would fire before
+// it listens.
+func (a *App) InitError() string {
+	if _, err := a.backend(); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// backend returns the service, or an error carrying the reason it failed to
+// initialize.
+func (a *App) backend() (*app.Service, error) {
+	if a.service != nil {
+		return a.service, nil
+	}
+	if a.initErr != nil {
+		return nil, fmt.Errorf("backend service not initialized: %w", a.initErr)
+	}
+	return nil, fmt.Errorf("backend service not initialized")
 }
 
+// SearchParams exposed to frontend
+type SearchParams = app.SearchParams
+
 // Search calls the backend service
 func (a *App) Search(p SearchParams) (*app.SearchResult, error) {
-	if a.service == nil {
-		return nil, fmt.Errorf("backend service not initialized")
-	}
-
-	var from, to time.Time
-
-	if p.Days == -1 {
-		// Custom range
-		var err error
-		from, err = time.Parse("2006-01-02", p.CustomFrom)
-		if err != nil {
-			return nil, fmt.Errorf("invalid custom from date: %w", err)
-		}
-		to, err = time.Parse("2006-01-02", p.CustomTo)
-		if err != nil {
-			return nil, fmt.Errorf("invalid custom to date: %w", err)
-		}
-		// Adjust 'to' to end of day if it's just a date, or keep as is.
-		// Usually discovery works better if 'to' is inclusive of the day.
-		to = to.Add(23*time.Hour + 59*time.Minute)
-	} else {
-		// Standard ranges
-		to = time.Now()
-		from = to.AddDate(0, 0, -p.Days)
-		if p.Days == 1 {
-			from = to.Add(-24 * time.Hour)
-		}
-	}
-
-	req := app.SearchRequest{
-		Query:         p.Query,
-		From:          from,
-		To:            to,
-		Scope:         app.SearchScope(p.Scope),
-		ChosenCountry: p.ChosenCountry,
-		PivotLang:     p.PivotLang,
-	}
-
-	return a.service.Search(a.ctx, req)
+	svc, err := a.backend()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := p.Request(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.Search(a.ctx, req)
+	if errors.Is(err, app.ErrURLQuery) {
+		// A client error, not a failed search: say where links are handled
+		return nil, fmt.Errorf("%w (pass the links to ExtractAndSummarize, or paste them at the newscheck CLI prompt)", err)
+	}
+	return res, err
+}
+
+// AnalyzeIntent returns the topics, themes, countries and keywords found in
+// query, without searching.
+func (a *App) AnalyzeIntent(query string) app.Intent {
+	return app.ExtractIntent(query)
+}
+
+// PivotLanguages lists the translation targets the frontend may offer.
+func (a *App) PivotLanguages() []app.PivotLanguage {
+	return app.PivotLanguages()
 }
 
 // ExtractParams exposed to frontend
@@ -101,10 +106,11 @@ type ExtractResult struct {
 }
 
 func (a *App) ExtractAndSummarize(p ExtractParams) (*ExtractResult, error) {
-	if a.service == nil {
-		return nil, fmt.Errorf("backend service not initialized")
+	svc, err := a.backend()
+	if err != nil {
+		return nil, err
 	}
-	articles, summary, err := a.service.ExtractAndSummarize(a.ctx, p.URLs, p.PivotLang, p.Query, p.ApiKey)
+	articles, summary, err := svc.ExtractAndSummarize(a.ctx, p.URLs, p.PivotLang, p.Query, p.ApiKey)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +118,10 @@ func (a *App) ExtractAndSummarize(p ExtractParams) (*ExtractResult, error) {
 }
 
 func (a *App) SaveArticleReport(articles []extract.Article) (string, error) {
+	svc, err := a.backend()
+	if err != nil {
+		return "", err
+	}
 	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		DefaultFilename: "articles_report.docx",
 		Title:           "Save Article Report",
@@ -126,14 +136,18 @@ func (a *App) SaveArticleReport(articles []extract.Article) (string, error) {
 		return "", nil // User cancelled
 	}
 
-	err = a.service.GenerateArticleReport(path, articles)
+	err = svc.GenerateArticleReport(path, articles)
 	if err != nil {
 		return "", err
 	}
 	return path, nil
 }
 
-func (a *App) SaveScoresReport(candidates []discovery.Candidate) (string, error) {
+func (a *App) SaveScoresReport(candidates []discovery.Candidate, articles []extract.Article) (string, error) {
+	svc, err := a.backend()
+	if err != nil {
+		return "", err
+	}
 	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		DefaultFilename: "scores_report.docx",
 		Title:           "Save Scores Report",
@@ -148,7 +162,7 @@ func (a *App) SaveScoresReport(candidates []discovery.Candidate) (string, error)
 		return "", nil // User cancelled
 	}
 
-	err = a.service.GenerateScoresReport(path, candidates)
+	err = svc.GenerateScoresReport(path, candidates, articles)
 	if err != nil {
 		return "", err
 	}
@@ -156,6 +170,10 @@ func (a *App) SaveScoresReport(candidates []discovery.Candidate) (string, error)
 }
 
 func (a *App) SaveResumeReport(summary string, query string, articles []extract.Article) (string, error) {
+	svc, err := a.backend()
+	if err != nil {
+		return "", err
+	}
 	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
 		DefaultFilename: "resume_report.docx",
 		Title:           "Save Resume Report",
@@ -170,9 +188,29 @@ func (a *App) SaveResumeReport(summary string, query string, articles []extract.
 		return "", nil // User cancelled
 	}
 
-	err = a.service.GenerateResumeReport(path, summary, query, articles)
+	err = svc.GenerateResumeReport(path, summary, query, articles)
 	if err != nil {
 		return "", err
 	}
 	return path, nil
 }
+
+// SaveAllReports asks for a folder and writes the article, scores and resume
+// documents for the given results into it in one go.
+func (a *App) SaveAllReports(result app.SearchResult, articles []extract.Article, summary string, query string) ([]string, error) {
+	svc, err := a.backend()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                "Choose Report Folder",
+		CanCreateDirectories: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return nil, nil // User cancelled
+	}
+	return svc.GenerateAllReports(dir, &result, articles, summary, query)
+}
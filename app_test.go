@@ -0,0 +1,32 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"newscheck/internal/app"
+)
+
+func TestInitErrorPropagates(t *testing.T) {
+	cause := &fs.PathError{Op: "open", Path: "data/countries.json", Err: fs.ErrNotExist}
+	a := &App{initErr: cause}
+
+	if msg := a.InitError(); !strings.Contains(msg, "data/countries.json") {
+		t.Errorf("InitError() = %q, want the original error", msg)
+	}
+	if _, err := a.Search(SearchParams{Query: "port strike", Days: 7}); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Search error = %v, want it to wrap the init error", err)
+	}
+	if _, err := a.ExtractAndSummarize(ExtractParams{URLs: []string{"https://a.example/1"}}); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ExtractAndSummarize error = %v, want it to wrap the init error", err)
+	}
+	if _, err := a.SaveArticleReport(nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("SaveArticleReport error = %v, want it to wrap the init error", err)
+	}
+
+	if msg := (&App{service: &app.Service{}}).InitError(); msg != "" {
+		t.Errorf("InitError() = %q for a started service, want empty", msg)
+	}
+}
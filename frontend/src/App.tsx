@@ -1,4 +1,4 @@
-import { useState } from 'react';
+import { useEffect, useState } from 'react';
 import './fonts.css';
 import './App.css';
 
@@ -17,6 +17,7 @@ interface Candidate {
     url: string;
     title: string;
     source: string;
+    publisher?: string; // clean outlet name ("The Guardian")
     published_at: string; // ISO string
     relevance_score: number;
     consensus_score: number;
@@ -24,9 +25,16 @@ interface Candidate {
 
 interface SearchResult {
     Candidates: Candidate[];
+    ErrorSummary?: string[];
+    Hints?: { cause: string; fix: string }[];
     // ... other fields if needed
 }
 
+interface PivotLanguage {
+    code: string;
+    name: string;
+}
+
 interface ExtractParams {
     urls: string[];
     pivotLang: string;
@@ -62,10 +70,14 @@ function App() {
     const [scope, setScope] = useState(0);
     const [chosenCountry, setChosenCountry] = useState("");
     const [pivotLang, setPivotLang] = useState("en");
+    const [pivotTouched, setPivotTouched] = useState(false);
+    const [pivotLanguages, setPivotLanguages] = useState<PivotLanguage[]>([{ code: "en", name: "English" }, { code: "fr", name: "French" }]);
     const [apiKey, setApiKey] = useState("");
 
     // Data State
     const [candidates, setCandidates] = useState<Candidate[]>([]);
+    const [sourceWarnings, setSourceWarnings] = useState<string[]>([]);
+    const [hints, setHints] = useState<{ cause: string; fix: string }[]>([]);
     const [selectedUrls, setSelectedUrls] = useState<Set<string>>(new Set());
     const [extractResult, setExtractResult] = useState<ExtractResult | null>(null);
 
@@ -74,6 +86,28 @@ function App() {
     const [loading, setLoading] = useState(false);
     const [error, setError] = useState("");
 
+    useEffect(() => {
+        wails.PivotLanguages()
+            .then((langs: PivotLanguage[]) => { if (langs && langs.length > 0) setPivotLanguages(langs); })
+            .catch(() => { /* keep the built-in defaults */ });
+        wails.InitError()
+            .then((msg: string) => { if (msg) setError("Backend failed to start: " + msg); })
+            .catch(() => { /* older backend without InitError */ });
+    }, []);
+
+    // Pre-select the pivot from the query's language until the user picks one.
+    useEffect(() => {
+        if (pivotTouched || !query.trim()) return;
+        const timer = setTimeout(() => {
+            wails.AnalyzeIntent(query)
+                .then((intent: { lang?: string }) => {
+                    if (intent.lang && pivotLanguages.some(l => l.code === intent.lang)) setPivotLang(intent.lang);
+                })
+                .catch(() => { /* keep the current pivot */ });
+        }, 400);
+        return () => clearTimeout(timer);
+    }, [query, pivotTouched, pivotLanguages]);
+
     const handleSearch = async () => {
         if (!query) return;
         setLoading(true);
@@ -88,7 +122,9 @@ function App() {
                 chosenCountry,
                 pivotLang
             };
-            const res = await wails.Search(params);
+            const res: SearchResult = await wails.Search(params);
+            setSourceWarnings(res?.ErrorSummary ?? []);
+            setHints(res?.Hints ?? []);
             if (res && res.Candidates) {
                 setCandidates(res.Candidates);
                 setView("results");
@@ -161,6 +197,7 @@ function App() {
     const goHome = () => {
         setView("search");
         setCandidates([]);
+        setSourceWarnings([]);
         setSelectedUrls(new Set());
         setExtractResult(null);
         setError("");
@@ -210,9 +247,10 @@ function App() {
                         </div>
                         <div className="form-group">
                             <label>Pivot Language</label>
-                            <select value={pivotLang} onChange={e => setPivotLang(e.target.value)}>
-                                <option value="en">English</option>
-                                <option value="fr">French</option>
+                            <select value={pivotLang} onChange={e => { setPivotLang(e.target.value); setPivotTouched(true); }}>
+                                {pivotLanguages.map(l => (
+                                    <option key={l.code} value={l.code}>{l.name}</option>
+                                ))}
                             </select>
                         </div>
                     </div>
@@ -267,7 +305,7 @@ function App() {
                             </div>
                         </div>
                         <div className="actions" style={{display:'flex', gap:'0.5rem'}}>
-                            <button className="btn" onClick={() => wails.SaveScoresReport(candidates)}>
+                            <button className="btn" onClick={() => wails.SaveScoresReport(candidates, extractResult?.articles ?? [])}>
                                 <Icons.Download /> Save Scores
                             </button>
                             <button
@@ -280,6 +318,21 @@ function App() {
                         </div>
                     </div>
 
+                    {sourceWarnings.length > 0 && (
+                        <div className="error">
+                            {sourceWarnings.map((w, i) => <div key={i}>{w}</div>)}
+                        </div>
+                    )}
+
+                    {candidates.length === 0 && hints.length > 0 && (
+                        <div className="hints">
+                            <h3>Nothing found. Likely causes:</h3>
+                            <ul>
+                                {hints.map((h, i) => <li key={i}>{h.cause}; <strong>{h.fix}</strong></li>)}
+                            </ul>
+                        </div>
+                    )}
+
                     <div className="list">
                         {candidates.map((c, i) => (
                             <div key={i} className={`item ${selectedUrls.has(c.url) ? 'selected' : ''}`} onClick={() => toggleSelect(c.url)}>
@@ -293,7 +346,7 @@ function App() {
                                 <div className="content">
                                     <h3>{c.title}</h3>
                                     <div className="meta">
-                                        <span><Icons.News /> {c.source}</span>
+                                        <span><Icons.News /> {c.publisher || c.source}</span>
                                         <span>{new Date(c.published_at).toLocaleDateString()}</span>
                                         <span className="badge rel">Rel: {c.relevance_score}</span>
                                         {c.consensus_score > 1 && <span className="badge consensus">Consensus: {c.consensus_score}</span>}
@@ -0,0 +1,316 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gingfrederik/docx"
+	"newscheck/internal/extract"
+	"newscheck/internal/geo"
+	"newscheck/internal/text"
+)
+
+// maxKeyActors is how many actors a resume lists.
+const maxKeyActors = 10
+
+// Actor kinds; "" when the heuristics can't tell.
+const (
+	ActorPerson       = "person"
+	ActorOrganization = "organization"
+	ActorPlace        = "place"
+)
+
+// KeyActor is a name that recurs across the extracted articles.
+type KeyActor struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind,omitempty"`
+	Articles int    `json:"articles"` // articles mentioning it
+	Mentions int    `json:"mentions"` // mentions across all articles
+}
+
+// orgWords mark a capitalized span as an organization.
+var orgWords = map[string]struct{}{
+	"agency": {}, "association": {}, "authority": {}, "bank": {}, "commission": {},
+	"committee": {}, "company": {}, "corp": {}, "corporation": {}, "council": {},
+	"court": {}, "department": {}, "federation": {}, "fund": {}, "group": {},
+	"inc": {}, "institute": {}, "ltd": {}, "ministry": {}, "movement": {},
+	"office": {}, "organization": {}, "organisation": {}, "parliament": {},
+	"party": {}, "police": {}, "senate": {}, "union": {}, "university": {},
+}
+
+// titleWords open a name without being part of it ("Prime Minister Keir
+// Starmer").
+var titleWords = map[string]struct{}{
+	"chancellor": {}, "dr": {}, "general": {}, "gov": {}, "governor": {}, "judge": {},
+	"king": {}, "mayor": {}, "minister": {}, "mr": {}, "mrs": {}, "ms": {}, "pope": {},
+	"president": {}, "prime": {}, "prince": {}, "princess": {}, "queen": {},
+	"secretary": {}, "sen": {}, "senator": {},
+}
+
+// nameConnectors may join two capitalized words inside one name ("Bank of
+// England", "Charles de Gaulle").
+var nameConnectors = map[string]struct{}{"of": {}, "de": {}, "du": {}, "la": {}, "van": {}, "von": {}, "al": {}, "bin": {}}
+
+// reNameToken splits text into words (keeping inner apostrophes, hyphens
+// and dots) and sentence/clause punctuation.
+var reNameToken = regexp.MustCompile(`[\p{L}\p{M}\p{N}]+(?:['’.\-][\p{L}\p{M}\p{N}]+)*|[.!?;:,"“”()]`)
+
+// KeyActors is heuristic named-entity aggregation over articles' titles and
+// texts: runs of capitalized words (optionally joined by "of", "de"...) are
+// names, minus leading titles ("President"). A lone word opening a sentence
+// only counts if it is seen as a name elsewhere. Longer mentions fold into
+// the shorter names they end with, so "French President Emmanuel Macron"
+// and "Macron" count toward "Emmanuel Macron". Names
+// are ranked by the articles that mention them, then by mentions; names
+// mentioned once are dropped. Kinds come from matcher (places, may be nil),
+// organization keywords and acronyms; two- or three-word names left over are
+// people. Low-quality and duplicate articles are skipped.
+func KeyActors(articles []extract.Article, matcher *geo.CountryMatcher) []KeyActor {
+	type tally struct {
+		name      string
+		mentions  int
+		articles  map[int]struct{}
+		firstSeen int
+	}
+	names := map[string]*tally{}
+	order := 0
+	add := func(n string, article int) {
+		key := strings.ToLower(n)
+		t := names[key]
+		if t == nil {
+			t = &tally{name: n, articles: map[int]struct{}{}, firstSeen: order}
+			order++
+			names[key] = t
+		}
+		t.mentions++
+		t.articles[article] = struct{}{}
+	}
+	type opener struct {
+		word    string
+		article int
+	}
+	var openers []opener
+	for i, a := range articles {
+		if a.LowQuality || a.DuplicateOf != "" {
+			continue
+		}
+		found, opening := capitalizedNames(a.Title + ".\n" + a.Text)
+		for _, n := range found {
+			add(n, i)
+		}
+		for _, w := range opening {
+			openers = append(openers, opener{w, i})
+		}
+	}
+
+	// "Officials said..." is capitalized by position only; "Macron said..."
+	// counts once Macron is seen as a name (or a name's last word) elsewhere
+	lastWords := map[string]struct{}{}
+	for key := range names {
+		if i := strings.LastIndexByte(key, ' '); i >= 0 {
+			lastWords[key[i+1:]] = struct{}{}
+		}
+	}
+	for _, o := range openers {
+		key := strings.ToLower(o.word)
+		_, isLast := lastWords[key]
+		if _, ok := names[key]; ok || isLast {
+			add(o.word, o.article)
+		}
+	}
+
+	// Fold each name into the longest other name it ends with: "French
+	// President Emmanuel Macron" into "Emmanuel Macron", then "Macron" into
+	// it too, unless two full names end with "Macron"
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, key := range keys {
+		t := names[key]
+		var into *tally
+		if strings.Contains(key, " ") {
+			for k, u := range names {
+				if strings.HasSuffix(key, " "+k) && strings.Contains(k, " ") && (into == nil || len(k) > len(strings.ToLower(into.name))) {
+					into = u
+				}
+			}
+		} else {
+			for k, u := range names {
+				if strings.HasSuffix(k, " "+key) {
+					if into != nil {
+						into = nil
+						break
+					}
+					into = u
+				}
+			}
+		}
+		if into == nil {
+			continue
+		}
+		into.mentions += t.mentions
+		for i := range t.articles {
+			into.articles[i] = struct{}{}
+		}
+		if t.firstSeen < into.firstSeen {
+			into.firstSeen = t.firstSeen
+		}
+		delete(names, key)
+	}
+
+	tallies := make([]*tally, 0, len(names))
+	for _, t := range names {
+		if t.mentions >= 2 {
+			tallies = append(tallies, t)
+		}
+	}
+	sort.Slice(tallies, func(i, j int) bool {
+		a, b := tallies[i], tallies[j]
+		if len(a.articles) != len(b.articles) {
+			return len(a.articles) > len(b.articles)
+		}
+		if a.mentions != b.mentions {
+			return a.mentions > b.mentions
+		}
+		return a.firstSeen < b.firstSeen
+	})
+	if len(tallies) > maxKeyActors {
+		tallies = tallies[:maxKeyActors]
+	}
+
+	out := make([]KeyActor, len(tallies))
+	for i, t := range tallies {
+		out[i] = KeyActor{Name: t.name, Kind: actorKind(t.name, matcher), Articles: len(t.articles), Mentions: t.mentions}
+	}
+	return out
+}
+
+// capitalizedNames returns the capitalized runs in s, in order, and apart
+// the lone capitalized words that open a sentence.
+func capitalizedNames(s string) (names, openers []string) {
+	stop := text.StopwordSet("")
+	var run []string
+	sentenceStart := true
+	flush := func(startsSentence bool) {
+		// Leading connectors, stopwords and titles ("President") and
+		// trailing connectors and stopwords aren't part of the name
+		for len(run) > 0 && (isNameFiller(run[0], stop) || isTitleWord(run[0])) {
+			run = run[1:]
+			startsSentence = false
+		}
+		for len(run) > 0 && isNameFiller(run[len(run)-1], stop) {
+			run = run[:len(run)-1]
+		}
+		switch {
+		case len(run) > 1:
+			names = append(names, strings.Join(run, " "))
+		case len(run) == 1 && startsSentence:
+			openers = append(openers, run[0])
+		case len(run) == 1:
+			names = append(names, run[0])
+		}
+		run = run[:0]
+	}
+
+	runStartsSentence := false
+	for _, tok := range reNameToken.FindAllString(s, -1) {
+		switch {
+		case isCapitalized(tok) && !isAllDigits(tok):
+			if len(run) == 0 {
+				runStartsSentence = sentenceStart
+			}
+			run = append(run, tok)
+		case len(run) > 0 && isConnector(tok):
+			run = append(run, tok)
+		default:
+			flush(runStartsSentence)
+		}
+		sentenceStart = strings.ContainsAny(tok, ".!?:\"“”") && len([]rune(tok)) == 1
+	}
+	flush(runStartsSentence)
+	return names, openers
+}
+
+func isConnector(tok string) bool {
+	_, ok := nameConnectors[tok]
+	return ok
+}
+
+func isTitleWord(tok string) bool {
+	_, ok := titleWords[strings.ToLower(strings.TrimSuffix(tok, "."))]
+	return ok
+}
+
+func isNameFiller(tok string, stop map[string]struct{}) bool {
+	lower := strings.ToLower(tok)
+	if _, ok := nameConnectors[lower]; ok {
+		return true
+	}
+	_, ok := stop[lower]
+	return ok
+}
+
+func isAllDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// actorKind guesses what name refers to.
+func actorKind(name string, matcher *geo.CountryMatcher) string {
+	words := strings.Fields(name)
+	if matcher != nil {
+		if found := matcher.FindCountries(name); len(found) == 1 && strings.EqualFold(found[0], name) {
+			return ActorPlace
+		}
+	}
+	for _, w := range words {
+		if _, ok := orgWords[strings.ToLower(strings.TrimSuffix(w, "."))]; ok {
+			return ActorOrganization
+		}
+	}
+	if len(words) == 1 && len(name) >= 2 && strings.ToUpper(name) == name {
+		return ActorOrganization // acronym: NATO, UN, IMF
+	}
+	if len(words) == 2 || len(words) == 3 {
+		return ActorPerson
+	}
+	return ""
+}
+
+// keyActors is KeyActors for resumes: nil unless ReportActors is set.
+func (s *Service) keyActors(articles []extract.Article) []KeyActor {
+	if !s.ReportActors {
+		return nil
+	}
+	return KeyActors(articles, s.Matcher)
+}
+
+// actorLine renders one actor as "Emmanuel Macron (person): 4 articles, 9 mentions".
+func actorLine(a KeyActor) string {
+	name := a.Name
+	if a.Kind != "" {
+		name += " (" + a.Kind + ")"
+	}
+	return fmt.Sprintf("%s: %d article%s, %d mention%s", name, a.Articles, plural(a.Articles), a.Mentions, plural(a.Mentions))
+}
+
+// addActorsSection adds the "Key actors" lines of a resume.
+func addActorsSection(f *docx.File, actors []KeyActor) {
+	if len(actors) == 0 {
+		return
+	}
+	f.AddParagraph() // Spacer
+	f.AddParagraph().AddText("Key actors:")
+	for _, a := range actors {
+		f.AddParagraph().AddText("- " + actorLine(a))
+	}
+}
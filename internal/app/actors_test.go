@@ -0,0 +1,78 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"newscheck/internal/extract"
+)
+
+func actorArticles() []extract.Article {
+	return []extract.Article{
+		{
+			Title: "Dockers strike as French President Emmanuel Macron meets unions",
+			Text: "French President Emmanuel Macron met the Dockers Union on Monday. " +
+				"Macron said the Port Authority would reopen talks. Officials said NATO shipments were delayed.",
+		},
+		{
+			Title: "Strike spreads to Le Havre",
+			Text: "Emmanuel Macron urged calm. The Dockers Union rejected the offer, and the Port Authority closed two terminals. " +
+				"Officials expect the strike to last.",
+		},
+		{Title: "Stub", Text: "Emmanuel Macron Emmanuel Macron Emmanuel Macron", LowQuality: true},
+	}
+}
+
+func TestKeyActors(t *testing.T) {
+	got := KeyActors(actorArticles(), nil)
+	byName := map[string]KeyActor{}
+	for _, a := range got {
+		byName[a.Name] = a
+	}
+
+	macron, ok := byName["Emmanuel Macron"]
+	if !ok {
+		t.Fatalf("actors = %+v, want Emmanuel Macron", got)
+	}
+	// "French President Emmanuel Macron" and "Macron said" fold in; the
+	// low-quality stub doesn't count
+	if macron.Kind != ActorPerson || macron.Articles != 2 || macron.Mentions != 4 {
+		t.Errorf("Emmanuel Macron = %+v, want a person in 2 articles, 4 mentions", macron)
+	}
+	if got[0].Name != "Emmanuel Macron" {
+		t.Errorf("top actor = %+v, want Emmanuel Macron", got[0])
+	}
+	for _, name := range []string{"Dockers Union", "Port Authority"} {
+		if a := byName[name]; a.Kind != ActorOrganization || a.Articles != 2 {
+			t.Errorf("%s = %+v, want an organization in 2 articles", name, a)
+		}
+	}
+	for _, name := range []string{"Officials", "NATO", "Le Havre", "Macron"} {
+		if _, ok := byName[name]; ok {
+			t.Errorf("%q listed, want sentence openers, single mentions and folded names left out", name)
+		}
+	}
+}
+
+func TestResumeKeyActorsSection(t *testing.T) {
+	dir := t.TempDir()
+	svc := &Service{}
+	off := filepath.Join(dir, "off.docx")
+	if err := svc.GenerateResumeReport(off, "summary", "port strike", actorArticles()); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(docxText(t, off), "Key actors") {
+		t.Error("resume lists key actors without ReportActors")
+	}
+
+	svc.ReportActors = true
+	on := filepath.Join(dir, "on.docx")
+	if err := svc.GenerateResumeReport(on, "summary", "port strike", actorArticles()); err != nil {
+		t.Fatal(err)
+	}
+	doc := docxText(t, on)
+	if !strings.Contains(doc, "Key actors") || !strings.Contains(doc, "Emmanuel Macron (person): 2 articles, 4 mentions") {
+		t.Errorf("resume lacks the key actors section")
+	}
+}
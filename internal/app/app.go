@@ -4,10 +4,14 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -15,6 +19,7 @@ import (
 	"newscheck/internal/discovery"
 	"newscheck/internal/extract"
 	"newscheck/internal/geo"
+	"newscheck/internal/text"
 )
 
 type Input struct {
@@ -35,39 +40,95 @@ type TimeRange struct {
 }
 
 type Intent struct {
-	Topics    []string
-	Regions   []string
-	Countries []string
-	Themes    []string
-	Keywords  []string
+	Lang      string   `json:"lang,omitempty"` // query language (ISO-639-1), "" if unknown
+	Topics    []string `json:"topics"`
+	Regions   []string `json:"regions"`
+	Countries []string `json:"countries"`
+	Themes    []string `json:"themes"`
+	Keywords  []string `json:"keywords"`
 }
 
 type SearchPlan struct {
-	Query   string
-	Scope   string // "global" | "region:<name>" | "country:<name>"
-	Focus   string // "topic:<x>" | "theme:<x>" | "mixed"
-	Weight  int
-	Explain string
+	Query     string
+	Scope     string // "global" | "region:<name>" | "country:<ISO2|name>"
+	ScopeTerm string // display/query term for Scope, e.g. "Venezuela" for "country:VE"
+	// Scope term per target language ("fr" -> "Allemagne"); falls back to ScopeTerm.
+	LocalTerms map[string]string `json:",omitempty"`
+	// Outlet the plan is restricted to (Google News source: operator).
+	Publisher string `json:",omitempty"`
+	Focus     string // "topic:<x>" | "theme:<x>" | "mixed"
+	Weight    int
+	Explain   string
 }
 
-func Run() error {
+func Run(args []string) error {
+	if len(args) > 0 {
+		if cmd, ok := subcommands[args[0]]; ok {
+			return cmd(args[1:])
+		}
+	}
+
+	opts, err := parseCLIOptions(args)
+	if err != nil {
+		return err
+	}
+
+	// -format urls: stdout carries the URL list alone, everything else
+	// (prompts included) goes to stderr
+	var out io.Writer = os.Stdout
+	var urls *urlList
+	if opts.Format == FormatURLs {
+		urls = newURLList(os.Stdout)
+		out = os.Stderr
+		opts.Batch.urls = urls
+	}
+
+	if opts.QueriesFile != "" {
+		svc, err := newCLIService(opts)
+		if err != nil {
+			return err
+		}
+		_, err = runBatch(context.Background(), out, svc, opts.QueriesFile, opts.SinceFile, opts.Batch, SearchRequest{
+			Filter:           opts.Filter,
+			GlobalTargets:    opts.GlobalTargets,
+			DiscoveryTimeout: opts.DiscoveryTimeout,
+			Dedupe:           opts.Dedupe,
+			NoCache:          opts.NoCache,
+			CollapseLocales:  opts.CollapseLocales,
+
+			MinCountryConfidence: opts.MinCountryConfidence,
+			QueryLang:            opts.QueryLang,
+			Publisher:            opts.Publisher,
+		})
+		return err
+	}
+
+	if opts.URLsFile != "" {
+		svc, err := newCLIService(opts)
+		if err != nil {
+			return err
+		}
+		_, err = runURLs(context.Background(), out, svc, opts.URLsFile, opts.Label, opts.Batch.Pivot, opts.Batch.OutDir)
+		return err
+	}
+
 	in := bufio.NewReader(os.Stdin)
 
 	// 1) Query input + validation
 	var query string
 	for {
-		fmt.Println("Enter your topic (keywords/sentence/paragraph).")
-		fmt.Println("Submit with a blank line.")
-		fmt.Print("> ")
+		fmt.Fprintln(out, "Enter your topic (keywords/sentence/paragraph).")
+		fmt.Fprintln(out, "Submit with a blank line.")
+		fmt.Fprint(out, "> ")
 
-		q, err := readMultiline(in)
+		q, err := readMultiline(out, in)
 		if err != nil {
 			return err
 		}
 		q = strings.TrimSpace(q)
 
 		if ok, reason := validateQuery(q); !ok {
-			fmt.Printf("Invalid input (%s). Please try again.\n\n", reason)
+			fmt.Fprintf(out, "Invalid input (%s). Please try again.\n\n", reason)
 			continue
 		}
 
@@ -75,159 +136,164 @@ func Run() error {
 		break
 	}
 
+	// A pasted link is an article to read, not keywords: extract it
+	// directly instead of running discovery on "https www ...".
+	if urls := QueryURLs(query); urls != nil {
+		fmt.Fprintf(out, "The query is %d URL(s); extracting directly instead of searching.\n", len(urls))
+		pivot, err := selectPivotLanguage(out, in, DefaultPivotFor(opts.QueryLang))
+		if err != nil {
+			return err
+		}
+		svc, err := newCLIService(opts)
+		if err != nil {
+			return err
+		}
+		_, err = extractURLs(context.Background(), out, svc, urls, opts.Label, pivot, opts.Batch.OutDir)
+		return err
+	}
+
 	// 2) Time window selection
-	tr, err := selectTimeRange(in)
+	tr, err := selectTimeRange(out, in, opts.Location)
 	if err != nil {
 		return err
 	}
 
 	// 3) Search scope selection
-	scopeMode, chosenCountry, err := selectSearchScope(in)
+	scopeMode, chosenCountry, err := selectSearchScope(out, in)
 	if err != nil {
 		return err
 	}
 
-	// 4) Intent extraction
-	intent := ExtractIntent(query)
+	// 4) Intent extraction happens in Service.Search (after scope is known)
 
-	// 5) Pivot language selection (translation later)
-	pivot, err := selectPivotLanguage(in)
+	// 5) Pivot language selection (translation later), defaulting to the
+	// query's language when it is a pivot option
+	queryLang := opts.QueryLang
+	if queryLang == "" {
+		queryLang = DetectQueryLang(query)
+	}
+	pivot, err := selectPivotLanguage(out, in, DefaultPivotFor(queryLang))
 	if err != nil {
 		return err
 	}
 
 	ctx := context.Background()
 
-	// 6) Country detection + resolver chain:
-	// - Manual overrides dataset (country_languages.json)
-	// - Auto cache dataset (country_auto_cache.json) written automatically
-	// - API fallback (RestCountries)
-	// - In-memory cache layer (your geo.NewCache)
-	cache := geo.NewCache("newscheck")
-
-	ds, err := geo.NewDatasetResolver("data/country_languages.json")
-	if err != nil {
-		return err
-	}
-
-	autoStore, err := geo.NewAutoCacheStore("data/country_auto_cache.json")
+	if opts.Explain {
+		resolver, matcher, err := newCountryResolution()
+		if err != nil {
+			return err
+		}
+		regions, err := geo.LoadRegions(geo.DefaultRegionsPath)
+		if err != nil {
+			return err
+		}
+		synonyms, err := LoadSynonyms(DefaultSynonymsPath)
+		if err != nil {
+			return err
+		}
+		return printExplanation(out, explainSearch(ctx, SearchRequest{
+			Query:         query,
+			From:          tr.From,
+			To:            tr.To,
+			Scope:         scopeMode,
+			ChosenCountry: chosenCountry,
+			PivotLang:     pivot,
+			GlobalTargets: opts.GlobalTargets,
+
+			CollapseLocales:      opts.CollapseLocales,
+			MinCountryConfidence: opts.MinCountryConfidence,
+			QueryLang:            opts.QueryLang,
+			Publisher:            opts.Publisher,
+			Synonyms:             synonyms,
+		}, matcher, resolver, regions, opts.concurrency().Resolution))
+	}
+
+	// 6) Country detection, targets, plans, discovery, filtering and scoring.
+	// The resolver chain is described in newCountryResolution.
+	svc, err := newCLIService(opts)
 	if err != nil {
 		return err
 	}
 
-	api := geo.NewRestCountriesResolver()
-	apiWithAuto := geo.NewAutoCacheResolver(autoStore, api)
+	req := SearchRequest{
+		Query:         query,
+		From:          tr.From,
+		To:            tr.To,
+		Scope:         scopeMode,
+		ChosenCountry: chosenCountry,
+		PivotLang:     pivot,
+		Filter:        opts.Filter,
+		GlobalTargets: opts.GlobalTargets,
 
-	resolver := geo.NewHybridResolver(cache, ds, apiWithAuto)
+		DiscoveryTimeout: opts.DiscoveryTimeout,
+		Dedupe:           opts.Dedupe,
+		NoCache:          opts.NoCache,
+		CollapseLocales:  opts.CollapseLocales,
 
-	matcher, err := geo.NewCountryMatcher("data/country_languages.json")
+		MinCountryConfidence: opts.MinCountryConfidence,
+		QueryLang:            opts.QueryLang,
+		Trace:                opts.Trace != "",
+		Publisher:            opts.Publisher,
+	}
+	if opts.Stream {
+		req.OnPartial = printPartial(out)
+		fmt.Fprintln(out, "\nDiscovering (streaming results as sources answer)...")
+	}
+	res, err := svc.Search(ctx, req)
 	if err != nil {
 		return err
 	}
-
-	var countryNames []string
-
-	switch scopeMode {
-	case ScopeAuto:
-		// Auto (current behavior)
-		// Find possibly multiple countries from the raw query (manual overrides only)
-		countryNames = matcher.FindCountries(query)
-
-		// If matcher found none, fall back to rule-based intent country hits (if any)
-		if len(countryNames) == 0 && len(intent.Countries) > 0 {
-			countryNames = append(countryNames, intent.Countries...)
+	if opts.Trace != "" {
+		if err := writeTrace(opts.Trace, res.Trace); err != nil {
+			return fmt.Errorf("write trace: %w", err)
 		}
-
-		// If still none: attempt automatic country resolution from query hints
-		// This is what enables "any country -> local languages" without editing JSON.
-		if len(countryNames) == 0 {
-			hints := geo.ExtractCountryHints(query)
-			for _, h := range hints {
-				info, err := resolver.ResolveCountry(ctx, h)
-				if err == nil && info.ISO2 != "" && len(info.Languages) > 0 {
-					countryNames = append(countryNames, info.Name)
-					break
-				}
-			}
-		}
-
-	case ScopeChosen:
-		// User chose a specific country
-		countryNames = []string{chosenCountry}
-		// Clear intent countries/regions to prevent mixing if user explicitly chose one
-		intent.Countries = nil
-		intent.Regions = nil
-
-	case ScopeGlobal:
-		// Explicit global - force empty country list
-		countryNames = []string{}
-		// Also clear intent locations
-		intent.Countries = nil
-		intent.Regions = nil
+		fmt.Fprintln(out, "Trace written to", opts.Trace)
 	}
-
-	// Resolve all countries (some may fail; we skip failed ones)
-	resolved := make([]geo.CountryInfo, 0, len(countryNames))
-	for _, name := range countryNames {
-		info, err := resolver.ResolveCountry(ctx, name)
-		if err == nil && info.ISO2 != "" {
-			resolved = append(resolved, info)
+	if opts.SinceFile != "" {
+		if err := applySinceFile(out, opts.SinceFile, query, res); err != nil {
+			return err
 		}
 	}
+	if urls != nil {
+		fmt.Fprintf(out, "%d candidate URL(s) written to stdout\n", len(res.Candidates))
+		return urls.write(res.Candidates)
+	}
+	stats := res.Stats
 
-	// Build discovery targets:
-	// - For each resolved country: local langs + English
-	// - If none: a safe fallback (US/en)
-	targets := buildTargets(resolved)
-	printTargets(countryNames, resolved, targets)
-
-	// Generate search plans AFTER scope/targets are finalized
-	plans := BuildSearchPlans(query, intent, resolved)
+	printTargets(out, res.DetectedCountries, res.Countries, res.Targets)
+	if res.Note != "" {
+		fmt.Fprintln(out, "Note:", res.Note)
+	}
+	if len(res.CollapsedTargets) > 0 {
+		fmt.Fprintf(out, "Collapsed %d shared-locale target(s): %s\n", len(res.CollapsedTargets), formatTargets(res.CollapsedTargets))
+	}
 
 	input := Input{
 		Query:       query,
 		TimeRange:   tr,
-		Intent:      intent,
-		SearchPlans: plans,
-		Targets:     targets,
+		Intent:      res.Intent,
+		SearchPlans: res.Plans,
+		Targets:     res.Targets,
 		PivotLang:   pivot,
 	}
 
-	fmt.Println("\nRequest accepted:")
-	fmt.Println("Time window:", input.TimeRange.Label)
-	fmt.Println("Pivot lang :", input.PivotLang)
+	fmt.Fprintln(out, "\nRequest accepted:")
+	fmt.Fprintln(out, "Time window:", input.TimeRange.Label)
+	fmt.Fprintln(out, "Pivot lang :", input.PivotLang)
 
-	fmt.Println("\nExtracted intent:")
-	printIntent(input.Intent)
+	fmt.Fprintln(out, "\nExtracted intent:")
+	printIntent(out, input.Intent)
 
-	fmt.Println("\nGenerated search plans:")
-	printPlans(input.SearchPlans)
+	fmt.Fprintln(out, "\nGenerated search plans:")
+	printPlans(out, input.SearchPlans)
 
-	// 7) Discovery (Google News RSS per (ISO2,lang) + curated RSS)
-	gn := discovery.NewGoogleNews()
-
-	rss := discovery.NewRSSFeeds([]string{
-		"https://rss.nytimes.com/services/xml/rss/nyt/World.xml",
-		"https://www.theguardian.com/world/rss",
-		"https://feeds.bbci.co.uk/news/world/rss.xml",
-		"https://www.aljazeera.com/xml/rss/all.xml",
-	})
+	printSourceErrors(out, res.SourceErrors)
+	printFeedFailures(out, res.FeedFailures)
+	candidates := res.Candidates
 
-	candidates, err := runDiscoveryWithTargets(ctx, input.SearchPlans, input.TimeRange, input.Targets, gn, rss)
-	if err != nil {
-		return err
-	}
-
-	// Relevance filtering
-	candidates = filterCandidates(candidates, query, intent, resolved)
-
-	// Cross-source consensus scoring
-	consensusScores := calculateConsensus(candidates)
-	for i := range candidates {
-		candidates[i].ConsensusScore = consensusScores[candidates[i].URL]
-	}
-
-	fmt.Printf("\nDiscovered %d candidate articles (after filtering)\n", len(candidates))
+	fmt.Fprintf(out, "\nDiscovered %d candidate articles (after filtering)\n", len(candidates))
+	printEmptyResultHints(out, res.Hints)
 	for i := 0; i < mini(20, len(candidates)); i++ {
 		c := candidates[i]
 		consensusLabel := ""
@@ -235,102 +301,176 @@ func Run() error {
 			consensusLabel = fmt.Sprintf(" [Consensus: %d]", c.ConsensusScore)
 		}
 
-		fmt.Printf("%2d) %s%s [Rel: %d]\n    %s\n    %s\n    %s\n",
-			i+1, c.Title, consensusLabel, c.RelevanceScore, c.URL, c.PublishedAt.Format(time.RFC3339), c.Source)
+		source := c.Publisher
+		if source == "" {
+			source = c.Source
+		}
+		if c.TargetISO2 != "" {
+			source += " via " + c.TargetISO2 + "/" + c.TargetLang
+		}
+
+		fmt.Fprintf(out, "%2d) %s%s [Rel: %d]\n    %s\n    %s\n    %s\n",
+			i+1, c.Title, consensusLabel, c.RelevanceScore, c.URL, candidateDate(c), source)
 	}
 
 	// 8) Step 7: Fetch + Extract (Python worker) for top N
-	fmt.Print("\nExtract how many articles now? (0 to skip, default 5): ")
-	line, _ := in.ReadString('\n')
-	line = strings.TrimSpace(line)
-
-	n := 5
-	if line != "" {
-		var tmp int
-		_, _ = fmt.Sscanf(line, "%d", &tmp)
-		if tmp < 0 {
-			tmp = 0
+	// Either a count (top N) or a list of candidate numbers, e.g. "1,3,7-9".
+	var selected []int
+	for {
+		fmt.Fprint(out, "\nExtract which articles? (N for top N, list like 1,3,7-9, 0 to skip, default 5): ")
+		line, _ := in.ReadString('\n')
+		sel, err := parseSelection(line, len(candidates), 5, func(n int) []int {
+			return pickTop(candidates, opts.ExtractBy, svc.Consensus, n)
+		})
+		if err != nil {
+			fmt.Fprintf(out, "Invalid selection (%s). Please try again.\n", err)
+			continue
 		}
-		n = tmp
-	}
-	if n > len(candidates) {
-		n = len(candidates)
+		selected = sel
+		break
 	}
+	n := len(selected)
 
 	var extractedArticles []extract.Article
 
+	worker := svc.Worker
+	extractStart := time.Now()
 	if n > 0 {
-		worker := extract.NewWorker()
-		for i := 0; i < n; i++ {
-			u := candidates[i].URL
-			fmt.Printf("\n[%d/%d] Extracting: %s\n", i+1, n, u)
-
-			art, err := worker.Extract(ctx, u, input.PivotLang)
-			if err != nil {
-				fmt.Println("  - error:", err)
-				continue
+		urls := make([]string, n)
+		for k, i := range selected {
+			urls[k] = candidates[i].URL
+		}
+		svc.ExtractAll(ctx, urls, input.PivotLang, func(k int, o ExtractOutcome) {
+			resumed := ""
+			if o.Resumed {
+				resumed = " (resumed)"
+			}
+			fmt.Fprintf(out, "\n[%d/%d] Extracted #%d: %s%s\n", k+1, n, selected[k]+1, o.URL, resumed)
+			if !o.OK {
+				stats.ExtractFailed++
+				fmt.Fprintln(out, "  - error:", o.Error)
+				return
 			}
+			stats.Extracted++
 
+			art := *o.Article
 			extractedArticles = append(extractedArticles, art)
 
-			fmt.Println("  - title:", art.Title)
-			fmt.Println("  - site :", art.Site)
+			fmt.Fprintln(out, "  - title:", art.Title)
+			fmt.Fprintln(out, "  - site :", art.Site)
 			if art.Lang != nil {
-				fmt.Println("  - lang :", *art.Lang)
+				fmt.Fprintln(out, "  - lang :", *art.Lang)
 			}
-			fmt.Printf("  - text : %d chars\n", len(art.Text))
-
-			preview := strings.TrimSpace(art.Text)
-			if len(preview) > 250 {
-				preview = preview[:250] + "..."
+			if art.MetadataOnly {
+				return
 			}
+			fmt.Fprintf(out, "  - text : %d chars\n", len(art.Text))
+
+			preview := articlePreview(art.Text, cliPreviewChars)
 			if preview != "" {
-				fmt.Println("  - preview:", preview)
+				fmt.Fprintln(out, "  - preview:", preview)
 			}
+		})
+	}
+
+	if n > 0 {
+		stats.stage("extract", extractStart)
+		if k := backfillPublishedAt(candidates, extractedArticles); k > 0 {
+			fmt.Fprintf(out, "Dated %d undated candidate(s) from their extracted articles\n", k)
 		}
 	}
 
+	// Keep stubs (paywalls, JS-only pages) out of the reports and resume
+	extractedArticles = markLowQuality(extractedArticles, opts.MinArticleChars)
+	// One wire story syndicated by several outlets counts once
+	extractedArticles = collapseDuplicates(extractedArticles, svc.SourceWeights, normalizeDomains(svc.PreferredDomains))
+
 	if len(extractedArticles) > 0 || len(candidates) > 0 {
-		fmt.Println("\nGenerating reports...")
-		if err := generateReports(extractedArticles, candidates); err != nil {
-			fmt.Println("Error generating reports:", err)
+		fmt.Fprintln(out, "\nGenerating reports...")
+		if paths, err := generateReports(out, svc, extractedArticles, candidates); err != nil {
+			fmt.Fprintln(out, "Error generating reports:", err)
 		} else {
-			fmt.Println("Reports generated: articles.docx, scores.docx")
+			fmt.Fprintln(out, "Reports generated:", strings.Join(paths, ", "))
 		}
 
-		if len(extractedArticles) > 0 {
-			fmt.Println("\nGenerating coherent resume (Summary)...")
-			worker := extract.NewWorker()
-			if err := generateResume(ctx, worker, extractedArticles, query); err != nil {
-				fmt.Printf("Error generating resume: %v\n", err)
+		if len(extractedArticles) > 0 && !worker.MetadataOnly {
+			fmt.Fprintln(out, "\nGenerating coherent resume (Summary)...")
+			actors := svc.keyActors(extractedArticles)
+			if path, summary, err := generateResume(ctx, worker, extractedArticles, query, svc.Summary, actors); err != nil {
+				fmt.Fprintf(out, "Error generating resume: %v\n", err)
 			} else {
-				fmt.Println("Resume generated: summaries/resume_....docx")
+				fmt.Fprintln(out, "Resume generated:", path)
+				if svc.ResumeTemplate != nil {
+					if p, err := writeTemplatedResume(svc.ResumeTemplate, "summaries", summary, query, extractedArticles, actors, time.Now()); err != nil {
+						fmt.Fprintln(out, "Error rendering resume template:", err)
+					} else {
+						fmt.Fprintln(out, "Resume generated:", p)
+					}
+				}
 			}
 		}
 	}
 
+	printRunStats(out, stats)
 	return nil
 }
 
-func generateResume(ctx context.Context, w *extract.Worker, articles []extract.Article, query string) error {
-	if err := os.MkdirAll("summaries", 0755); err != nil {
-		return fmt.Errorf("creating summaries dir: %w", err)
+// newCLIService is NewService with the CLI's extraction and report flags applied.
+func newCLIService(opts cliOptions) (*Service, error) {
+	svc, err := NewService()
+	if err != nil {
+		return nil, err
+	}
+	svc.MinArticleChars = opts.MinArticleChars
+	svc.IncludeOriginal = opts.IncludeOriginal
+	svc.Worker.KeepOriginal = opts.IncludeOriginal
+	svc.Worker.TimeoutEscalation = opts.TimeoutEscalation
+	svc.Worker.MetadataOnly = opts.ExtractMode == extract.ModeMetadataOnly
+	svc.Consensus.Method = opts.ConsensusMethod
+	svc.Summary = opts.Summary
+	svc.PreferredDomains = opts.PreferredDomains
+	svc.Concurrency = opts.concurrency()
+	svc.ReportActors = opts.KeyActors
+	svc.Journal = NewExtractJournal()
+	svc.ResumeExtraction = opts.ResumeExtraction
+	if svc.Sources, err = selectSources(svc.Sources, opts.Sources); err != nil {
+		return nil, fmt.Errorf("-sources: %w", err)
+	}
+	for _, ds := range svc.Sources {
+		switch src := ds.Source.(type) {
+		case *discovery.CuratedFeeds:
+			src.SetBrowserHeaders(opts.FeedBrowserHeaders)
+			src.SetMatchWeights(opts.FeedMatch)
+		case *discovery.GoogleNews:
+			src.SaveRawDir = opts.SaveRaw
+			src.FromRawDir = opts.FromRaw
+		}
 	}
-
-	// Aggregate texts
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("User Query: %s\n\n", query))
-	sb.WriteString("Source Articles:\n")
-	for _, art := range articles {
-		sb.WriteString(fmt.Sprintf("Title: %s\nSource: %s\nText:\n%s\n\n", art.Title, art.Site, art.Text))
+	if opts.SaveRaw != "" || opts.FromRaw != "" {
+		svc.Cache = nil // cached candidates would skip the saving or the replay
 	}
+	if opts.ResumeTemplate != "" {
+		rt, err := LoadResumeTemplate(opts.ResumeTemplate)
+		if err != nil {
+			return nil, err
+		}
+		svc.ResumeTemplate = rt
+	}
+	return svc, nil
+}
 
-	fullText := sb.String()
+// generateResume summarizes articles and saves the resume DOCX under
+// summaries/, with a "Key actors" section when actors is set, returning its
+// path and the summary.
+func generateResume(ctx context.Context, w *extract.Worker, articles []extract.Article, query string, limits SummaryLimits, actors []KeyActor) (string, string, error) {
+	if err := os.MkdirAll("summaries", 0755); err != nil {
+		return "", "", fmt.Errorf("creating summaries dir: %w", err)
+	}
 
-	// Call summarizer
-	summary, err := w.Summarize(ctx, fullText, "")
+	// Call summarizer on the (per-article truncated) texts
+	summary, err := w.Summarize(ctx, summaryInput(query, articles, limits), "")
 	if err != nil {
-		return err
+		return "", "", err
 	}
 
 	// Save to DOCX
@@ -350,6 +490,7 @@ func generateResume(ctx context.Context, w *extract.Worker, articles []extract.A
 	// Summary Content
 	p = f.AddParagraph()
 	p.AddText(summary)
+	addActorsSection(f, actors)
 
 	f.AddParagraph() // Spacer
 	f.AddParagraph().AddText("--------------------------------------------------")
@@ -361,142 +502,170 @@ func generateResume(ctx context.Context, w *extract.Worker, articles []extract.A
 		f.AddParagraph().AddText(fmt.Sprintf("- %s (%s)", art.Title, art.Site))
 	}
 
-	timestamp := time.Now().Format("2006-01-02_15-04")
-	filename := fmt.Sprintf("summaries/resume_%s.docx", timestamp)
+	filename := uniqueReportPath("summaries", "resume", time.Now())
 	if err := f.Save(filename); err != nil {
-		return err
+		return "", "", err
 	}
 
-	return nil
+	return filename, summary, nil
+}
+
+// uniqueReportPath returns dir/<prefix>_<timestamp>.docx, adding a numeric
+// suffix if that file already exists, so two reports generated in the same
+// second don't overwrite each other.
+func uniqueReportPath(dir, prefix string, now time.Time) string {
+	return uniqueReportPathExt(dir, prefix, ".docx", now)
 }
 
-func generateReports(articles []extract.Article, candidates []discovery.Candidate) error {
-	// Create output directories
+// uniqueReportPathExt is uniqueReportPath for any extension (".md", ".html").
+func uniqueReportPathExt(dir, prefix, ext string, now time.Time) string {
+	base := filepath.Join(dir, fmt.Sprintf("%s_%s", prefix, now.Format("2006-01-02_15-04-05")))
+	path := base + ext
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = fmt.Sprintf("%s_%d%s", base, i, ext)
+	}
+}
+
+// generateReports writes the article and scores reports of a CLI run under
+// reports/ and scores/ through the Service's report writers.
+func generateReports(w io.Writer, svc *Service, articles []extract.Article, candidates []discovery.Candidate) ([]string, error) {
 	if err := os.MkdirAll("reports", 0755); err != nil {
-		return fmt.Errorf("creating reports dir: %w", err)
+		return nil, fmt.Errorf("creating reports dir: %w", err)
 	}
 	if err := os.MkdirAll("scores", 0755); err != nil {
-		return fmt.Errorf("creating scores dir: %w", err)
+		return nil, fmt.Errorf("creating scores dir: %w", err)
 	}
 
-	// 1. Articles DOCX
+	var written []string
 	if len(articles) > 0 {
-		f := docx.NewFile()
-
-		titleP := f.AddParagraph()
-		titleRun := titleP.AddText("Extracted Articles Report")
-		titleRun.Size(20)
-		f.AddParagraph() // Spacer
-
-		for _, art := range articles {
-			// Title
-			p := f.AddParagraph()
-			run := p.AddText(art.Title)
-			// run.Bold() // Not supported in this lib version apparently
-			run.Size(16)
-
-			// Metadata
-			p = f.AddParagraph()
-			pub := ""
-			if art.PublishedAt != nil {
-				pub = *art.PublishedAt
-			}
-			run = p.AddText(fmt.Sprintf("Source: %s | Date: %s", art.Site, pub))
-			run.Size(10)
-			run.Color("808080")
-
-			// URL
-			p = f.AddParagraph()
-			run = p.AddText(art.FinalURL)
-			run.Size(10)
-			run.Color("0000FF")
-
-			// Simple text splitting by double newlines for paragraphs
-			paragraphs := strings.Split(art.Text, "\n\n")
-			for _, txt := range paragraphs {
-				txt = strings.TrimSpace(txt)
-				if txt != "" {
-					f.AddParagraph().AddText(txt)
-				}
-			}
-			f.AddParagraph().AddText("--------------------------------------------------")
+		filename := uniqueReportPath("reports", "articles", time.Now())
+		if err := svc.GenerateArticleReport(filename, articles); err != nil {
+			return written, err
 		}
-
-		timestamp := time.Now().Format("2006-01-02_15-04")
-		filename := fmt.Sprintf("reports/articles_%s.docx", timestamp)
-		if err := f.Save(filename); err != nil {
-			return err
-		}
-		fmt.Printf("Saved article report to: %s\n", filename)
+		written = append(written, filename)
+		fmt.Fprintf(w, "Saved article report to: %s\n", filename)
 	}
-
-	// 2. Scores DOCX
 	if len(candidates) > 0 {
-		f := docx.NewFile()
-
-		// Header
-		p := f.AddParagraph()
-		run := p.AddText("Relevance & Consensus Scores Report")
-		run.Size(18)
-
-		// Explanations
-		p = f.AddParagraph()
-		p.AddText("Understanding the Scores:")
-
-		p = f.AddParagraph()
-		p.AddText("- Relevance Score (0-100): Indicates how closely the article matches your specific query keywords and country intent. Higher is better.")
-
-		p = f.AddParagraph()
-		p.AddText("- Consensus Score: Represents cross-source validation. It counts how many *other* independent sources are covering essentially the same story (based on keyword overlap). A higher score suggests a major, verified event.")
-
-		f.AddParagraph() // Spacer
-		f.AddParagraph().AddText("--------------------------------------------------")
-		f.AddParagraph() // Spacer
-
-		for _, c := range candidates {
-			p = f.AddParagraph()
-			run = p.AddText(c.Title)
-			// run.Bold()
-
-			p = f.AddParagraph()
-			run = p.AddText(c.URL)
-			run.Size(10)
+		filename := uniqueReportPath("scores", "scores", time.Now())
+		if err := svc.GenerateScoresReport(filename, candidates, articles); err != nil {
+			return written, err
+		}
+		written = append(written, filename)
+		fmt.Fprintf(w, "Saved scores report to: %s\n", filename)
+	}
+	return written, nil
+}
 
-			// Scale relevance to look more standard (it was raw points before)
-			// Assuming raw score rarely exceeds ~20-30 in current logic, let's just present it clearly or normalize if we knew max.
-			// Current logic: +10 per keyword match, +5 country, +2 recency.
-			// Let's cap visual display at 100 or just show "Score: X".
-			// A "perfect" match might be ~2 keywords + country + recent = 27.
-			// Let's show it as "Relevance Score: X (Raw)".
+// ===== Auto country detection =====
 
-			consensusDesc := "Low"
-			if c.ConsensusScore >= 2 { consensusDesc = "Medium" }
-			if c.ConsensusScore >= 4 { consensusDesc = "High" }
-			if c.ConsensusScore >= 6 { consensusDesc = "Very High" }
+// maxAutoCountries caps how many countries auto-detection may contribute,
+// so a long paragraph mentioning many places doesn't explode the target set.
+const maxAutoCountries = 5
 
-			p = f.AddParagraph()
-			run = p.AddText(fmt.Sprintf("Relevance: %d | Consensus: %d (%s)", c.RelevanceScore, c.ConsensusScore, consensusDesc))
-			run.Color("008000")
+// autoDetectCountries unions the three detection mechanisms, in confidence order:
+// 1) dataset matcher (exact phrase/alias hits)
+// 2) rule-based intent lexicon
+// 3) capitalized query hints accepted by the resolver (any country -> local languages)
+// Names are deduped by resolved ISO2 when possible, otherwise by name. Each
+// guess carries its mechanism's base confidence; see scoreCountryGuesses.
+func autoDetectCountries(ctx context.Context, query string, intent Intent, matcher *geo.CountryMatcher, resolver geo.Resolver) []CountryGuess {
+	var out []CountryGuess
+	seen := map[string]struct{}{}
 
-			f.AddParagraph() // Spacer
+	add := func(name string, requireLangs bool, base float64) {
+		if len(out) >= maxAutoCountries {
+			return
 		}
-
-		timestamp := time.Now().Format("2006-01-02_15-04")
-		filename := fmt.Sprintf("scores/scores_%s.docx", timestamp)
-		if err := f.Save(filename); err != nil {
-			return err
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return
+		}
+		key := "name:" + strings.ToLower(name)
+		info, err := resolver.ResolveCountry(ctx, name)
+		if err == nil && info.ISO2 != "" {
+			key = "iso2:" + info.ISO2
+			if requireLangs && len(info.Languages) == 0 {
+				return
+			}
+		} else if requireLangs {
+			return
+		}
+		if _, ok := seen[key]; ok {
+			return
 		}
-		fmt.Printf("Saved scores report to: %s\n", filename)
+		seen[key] = struct{}{}
+		if requireLangs {
+			// Hints use the resolver's canonical name, not the raw query token
+			name = info.Name
+		}
+		out = append(out, CountryGuess{Name: name, Confidence: base})
 	}
 
-	return nil
+	if matcher != nil {
+		for _, n := range matcher.FindCountries(query) {
+			add(n, false, matcherConfidence)
+		}
+	}
+	for _, n := range intent.Countries {
+		add(n, false, matcherConfidence)
+	}
+	for _, h := range geo.ExtractCountryHints(query) {
+		add(h, true, hintConfidence)
+	}
+	scoreCountryGuesses(query, out)
+	return out
 }
 
 // ===== Targets =====
 
-func buildTargets(resolved []geo.CountryInfo) []geo.DiscoveryTarget {
+// DefaultGlobalTargets are the anchor locales used when no country resolves
+// (including Global scope), spread across regions and languages so a
+// worldwide search isn't just US-English outlets.
+var DefaultGlobalTargets = []geo.DiscoveryTarget{
+	{ISO2: "US", Lang: "en"},
+	{ISO2: "GB", Lang: "en"},
+	{ISO2: "FR", Lang: "fr"},
+	{ISO2: "DE", Lang: "de"},
+	{ISO2: "IN", Lang: "en"},
+	{ISO2: "BR", Lang: "pt"},
+}
+
+// withLocale makes loc the worldwide anchor for its language: it goes first
+// and replaces the other anchors in that language, so "en-AU" stands in for
+// US, GB and IN English. global nil = DefaultGlobalTargets.
+func withLocale(global []geo.DiscoveryTarget, loc discovery.LanguageProfile) []geo.DiscoveryTarget {
+	if global == nil {
+		global = DefaultGlobalTargets
+	}
+	out := []geo.DiscoveryTarget{{ISO2: loc.GL, Lang: loc.Code}}
+	for _, t := range global {
+		if !strings.EqualFold(t.Lang, loc.Code) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// buildTargets turns resolved countries into discovery targets. With no
+// countries it uses the global anchors (DefaultGlobalTargets when nil), and
+// US/en as the last resort.
+func buildTargets(resolved []geo.CountryInfo, global []geo.DiscoveryTarget) []geo.DiscoveryTarget {
 	if len(resolved) == 0 {
-		return []geo.DiscoveryTarget{{ISO2: "US", Lang: "en"}}
+		if global == nil {
+			global = DefaultGlobalTargets
+		}
+		out := make([]geo.DiscoveryTarget, 0, len(global))
+		for _, t := range global {
+			t.Global = true
+			out = append(out, t)
+		}
+		if len(out) == 0 {
+			out = append(out, geo.DiscoveryTarget{ISO2: "US", Lang: "en", Global: true})
+		}
+		return out
 	}
 
 	seen := map[string]struct{}{}
@@ -522,162 +691,507 @@ func buildTargets(resolved []geo.CountryInfo) []geo.DiscoveryTarget {
 	return out
 }
 
-func printTargets(countryNames []string, resolved []geo.CountryInfo, targets []geo.DiscoveryTarget) {
-	fmt.Println("\nDetected countries:", strings.Join(countryNames, ", "))
+// collapseSharedLocales keeps one target per language in langs among those
+// where the language isn't local to the country (the English baseline added
+// to every country, or the pivot language): DE/en and FR/en query much the
+// same international edition. Local-language targets are never touched.
+// Returns the kept targets and the dropped ones.
+func collapseSharedLocales(targets []geo.DiscoveryTarget, resolved []geo.CountryInfo, langs []string) (kept, dropped []geo.DiscoveryTarget) {
+	collapse := map[string]bool{}
+	for _, l := range langs {
+		if l = strings.ToLower(strings.TrimSpace(l)); l != "" {
+			collapse[l] = true
+		}
+	}
+	local := map[string]bool{}
 	for _, c := range resolved {
-		fmt.Printf("Resolved: %s (%s) langs=%v\n", c.Name, c.ISO2, c.Languages)
+		for _, t := range geo.BuildDiscoveryTargets(c, false) { // false => local languages only
+			local[t.ISO2+"|"+t.Lang] = true
+		}
+	}
+
+	seenLang := map[string]bool{}
+	for _, t := range targets {
+		if t.Global || !collapse[t.Lang] || local[t.ISO2+"|"+t.Lang] {
+			kept = append(kept, t)
+			continue
+		}
+		if seenLang[t.Lang] {
+			dropped = append(dropped, t)
+			continue
+		}
+		seenLang[t.Lang] = true
+		kept = append(kept, t)
+	}
+	return kept, dropped
+}
+
+func printTargets(w io.Writer, countryNames []string, resolved []geo.CountryInfo, targets []geo.DiscoveryTarget) {
+	fmt.Fprintln(w, "\nDetected countries:", strings.Join(countryNames, ", "))
+	for _, c := range resolved {
+		labels := make([]string, len(c.Languages))
+		for i, l := range c.Languages {
+			labels[i] = languageLabel(resolved, c.ISO2, l)
+		}
+		fmt.Fprintf(w, "Resolved: %s (%s) langs=%s\n", c.Name, c.ISO2, strings.Join(labels, ", "))
 	}
 	if len(resolved) == 0 {
-		fmt.Println("Resolved: (none) -> fallback discovery target: US/en")
+		fmt.Fprintln(w, "Resolved: (none) -> global anchor targets")
 	}
 
-	fmt.Println("\nDiscovery targets (ISO2/lang):")
+	fmt.Fprintln(w, "\nDiscovery targets (ISO2/lang):")
 	for _, t := range targets {
-		fmt.Printf("- %s/%s\n", t.ISO2, t.Lang)
+		fmt.Fprintf(w, "- %s/%s\n", t.ISO2, languageLabel(resolved, t.ISO2, t.Lang))
+	}
+}
+
+// languageLabel is "de (German)" when the country iso2 among resolved names
+// the language code, else the code.
+func languageLabel(resolved []geo.CountryInfo, iso2, code string) string {
+	for _, c := range resolved {
+		if !strings.EqualFold(c.ISO2, iso2) {
+			continue
+		}
+		if name := c.LanguageNames[code]; name != "" {
+			return code + " (" + name + ")"
+		}
 	}
+	return code
 }
 
 // ===== Discovery =====
 
+// SourceError records one failed discovery call (per source/target/plan).
+type SourceError struct {
+	Source string `json:"source"` // "Google News", "Bing News", "Curated RSS"
+	Target string `json:"target"` // "ISO2/lang"; for curated RSS the feed language, empty for world feeds
+	Plan   string `json:"plan"`
+	Err    string `json:"error"`
+}
+
+// DiscoverySource registers a discovery backend with its share of the
+// candidate budget: PerPlan on average per plan and locale (weighted by plan
+// Weight), never below MinPerPlan.
+type DiscoverySource struct {
+	Source     discovery.Source
+	PerPlan    int
+	MinPerPlan int
+}
+
 func runDiscoveryWithTargets(
 	ctx context.Context,
 	plans []SearchPlan,
 	tr TimeRange,
 	targets []geo.DiscoveryTarget,
-	gn *discovery.GoogleNews,
-	rss *discovery.RSSFeeds,
-) ([]discovery.Candidate, error) {
-
-	toPlan := func(p SearchPlan) discovery.Plan {
-		return discovery.Plan{Query: p.Query, Scope: p.Scope}
+	sources []DiscoverySource,
+	dedupe DedupeStrategy,
+	workers int, // concurrent source calls; results keep the sequential order
+	onFound PartialFunc, // optional
+	stats *RunStats, // optional
+) ([]discovery.Candidate, []SourceError, error) {
+
+	toPlan := func(p SearchPlan, lang string) discovery.Plan {
+		term := p.ScopeTerm
+		if t, ok := p.LocalTerms[lang]; ok {
+			term = t
+		}
+		return discovery.Plan{Query: p.Query, Scope: p.Scope, ScopeTerm: term, Publisher: p.Publisher}
 	}
 
 	maxPlans := 10
 	if len(plans) < maxPlans {
 		maxPlans = len(plans)
 	}
+	plans = plans[:maxPlans]
 
-	all := make([]discovery.Candidate, 0, 400)
-
+	// Per-target locales, shared by every source that doesn't map targets itself
+	type locale struct {
+		profile discovery.LanguageProfile
+		label   string
+		global  bool
+	}
+	var locales []locale
+	profiles := make([]discovery.LanguageProfile, 0, len(targets))
 	for _, t := range targets {
 		hl, gl, ceid := geo.BuildGoogleNewsParams(t.ISO2, t.Lang)
 		if hl == "" || gl == "" || ceid == "" {
 			continue
 		}
-		profile := discovery.LanguageProfile{
+		p := discovery.LanguageProfile{
 			Code: t.Lang,
 			HL:   hl,
 			GL:   gl,
 			CEID: ceid,
 		}
+		profiles = append(profiles, p)
+		locales = append(locales, locale{profile: p, label: t.ISO2 + "/" + t.Lang, global: t.Global})
+	}
+
+	// Collect every call first, then run them concurrently
+	type call struct {
+		src   discovery.Source
+		plan  SearchPlan
+		loc   locale
+		limit int
+		found []discovery.Candidate
+		err   error
+	}
+	var calls []call
+
+	for _, ds := range sources {
+		src := ds.Source
+		limits := weightedPlanLimits(plans, ds.PerPlan, ds.MinPerPlan)
+
+		srcLocales := locales
+		perTarget := true
+		if ls, ok := src.(discovery.LocaleSource); ok {
+			perTarget = false
+			srcLocales = nil
+			for _, p := range ls.Locales(profiles) {
+				srcLocales = append(srcLocales, locale{profile: p, label: p.Code})
+			}
+		}
+
+		// Global plans aren't tied to a country, so re-running the same query
+		// for every country target only burns requests. Run each once, except
+		// across global anchor targets, which exist precisely to fan them out.
+		ranGlobal := map[string]struct{}{}
+
+		for _, loc := range srcLocales {
+			for i := range plans {
+				if perTarget && isGlobalScope(plans[i].Scope) {
+					key := plans[i].Query
+					if loc.global {
+						key += "|" + loc.label
+					}
+					if _, done := ranGlobal[key]; done {
+						continue
+					}
+					ranGlobal[key] = struct{}{}
+				}
+
+				calls = append(calls, call{src: src, plan: plans[i], loc: loc, limit: limits[i]})
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	streamed := map[string]struct{}{}
+	forEachLimit(len(calls), workers, func(i int) {
+		c := &calls[i]
+		c.found, c.err = c.src.Discover(ctx, toPlan(c.plan, c.loc.profile.Code), c.loc.profile, tr.From, tr.To, c.limit)
+		if onFound == nil || c.err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		onFound(c.src.Name(), c.loc.label, streamNew(c.found, dedupe, streamed))
+	})
+
+	all := make([]discovery.Candidate, 0, 400)
+	var errs []SourceError
+	for _, c := range calls {
+		if c.err != nil {
+			errs = append(errs, SourceError{Source: c.src.Name(), Target: c.loc.label, Plan: c.plan.Query, Err: c.err.Error()})
+			continue
+		}
+		stats.addSource(c.src.Name(), len(c.found))
+		all = append(all, c.found...)
+	}
+
+	out, merged, unusable := dedupeCandidates(all, dedupe)
+	if stats != nil {
+		stats.Deduped, stats.Unusable = merged, unusable
+	}
+	return out, errs, nil
+}
 
-		for i := 0; i < maxPlans; i++ {
-			found, err := gn.Discover(ctx, toPlan(plans[i]), profile, tr.From, tr.To, 25)
-			if err == nil {
-				all = append(all, found...)
+// weightedPlanLimits splits an overall budget of perPlan*len(plans) items
+// across plans in proportion to their Weight, so the original query brings
+// more candidates than a weak expansion. Every plan gets at least minPerPlan.
+func weightedPlanLimits(plans []SearchPlan, perPlan, minPerPlan int) []int {
+	limits := make([]int, len(plans))
+	total := 0
+	for _, p := range plans {
+		if p.Weight > 0 {
+			total += p.Weight
+		}
+	}
+	budget := perPlan * len(plans)
+	for i, p := range plans {
+		n := perPlan
+		if total > 0 {
+			w := p.Weight
+			if w < 0 {
+				w = 0
 			}
+			n = budget * w / total
 		}
+		if n < minPerPlan {
+			n = minPerPlan
+		}
+		limits[i] = n
 	}
+	return limits
+}
+
+func isGlobalScope(scope string) bool {
+	return discovery.ParseScope(scope).Kind == "global"
+}
 
-	for i := 0; i < maxPlans; i++ {
-		found, err := rss.Discover(ctx, toPlan(plans[i]), tr.From, tr.To, 10)
-		if err == nil {
-			all = append(all, found...)
+var reHTTPStatus = regexp.MustCompile(`(?i)\bhttp (\d{3})\b`)
+
+// summarizeSourceErrors groups errors by source and short reason, e.g.
+// "Google News returned HTTP 429 for 5 targets".
+func summarizeSourceErrors(errs []SourceError) []string {
+	type group struct {
+		source, reason string
+		targets        map[string]struct{}
+		calls          int
+	}
+	groups := map[string]*group{}
+	var order []string
+
+	for _, e := range errs {
+		reason := shortErrorReason(e.Err)
+		key := e.Source + "|" + reason
+		g, ok := groups[key]
+		if !ok {
+			g = &group{source: e.Source, reason: reason, targets: map[string]struct{}{}}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.calls++
+		if e.Target != "" {
+			g.targets[e.Target] = struct{}{}
 		}
 	}
 
-	return dedupeCandidates(all), nil
+	out := make([]string, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		verb := "failed with"
+		if strings.HasPrefix(g.reason, "HTTP ") {
+			verb = "returned"
+		}
+		if n := len(g.targets); n > 0 {
+			out = append(out, fmt.Sprintf("%s %s %s for %d target%s", g.source, verb, g.reason, n, plural(n)))
+		} else {
+			out = append(out, fmt.Sprintf("%s %s %s (%d call%s)", g.source, verb, g.reason, g.calls, plural(g.calls)))
+		}
+	}
+	return out
 }
 
-func dedupeCandidates(in []discovery.Candidate) []discovery.Candidate {
+func shortErrorReason(msg string) string {
+	if strings.Contains(msg, discovery.ErrInterstitial.Error()) {
+		return "a consent/interstitial page"
+	}
+	if strings.Contains(msg, discovery.ErrChallenge.Error()) {
+		return "a bot challenge page"
+	}
+	if m := reHTTPStatus.FindStringSubmatch(msg); m != nil {
+		return "HTTP " + m[1]
+	}
+	msg = strings.TrimSpace(msg)
+	if len(msg) > 80 {
+		msg = msg[:80] + "..."
+	}
+	return msg
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+func printSourceErrors(w io.Writer, errs []SourceError) {
+	if len(errs) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nDiscovery errors:")
+	for _, line := range summarizeSourceErrors(errs) {
+		fmt.Fprintln(w, "-", line)
+	}
+}
+
+// feedReporter is a source made of feeds that tracks each feed's latest
+// fetch (discovery.CuratedFeeds).
+type feedReporter interface {
+	FeedOutcomes(since time.Time) []discovery.FeedOutcome
+}
+
+// failedFeeds returns the feeds of sources whose fetch at or after since
+// failed.
+func failedFeeds(sources []DiscoverySource, since time.Time) []discovery.FeedOutcome {
+	var out []discovery.FeedOutcome
+	for _, ds := range sources {
+		fr, ok := ds.Source.(feedReporter)
+		if !ok {
+			continue
+		}
+		for _, o := range fr.FeedOutcomes(since) {
+			if !o.OK {
+				out = append(out, o)
+			}
+		}
+	}
+	return out
+}
+
+// printFeedFailures warns about each feed that failed, e.g. "Al Jazeera
+// feed returned HTTP 403".
+func printFeedFailures(w io.Writer, feeds []discovery.FeedOutcome) {
+	if len(feeds) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nFeed warnings:")
+	challenged := false
+	for _, o := range feeds {
+		verb := "failed with"
+		reason := shortErrorReason(o.Err)
+		if strings.HasPrefix(reason, "HTTP ") {
+			verb = "returned"
+		}
+		challenged = challenged || strings.Contains(o.Err, discovery.ErrChallenge.Error())
+		fmt.Fprintf(w, "- %s feed %s %s (%s)\n", o.Name(), verb, reason, o.URL)
+	}
+	if challenged {
+		fmt.Fprintln(w, "  Bot-protected feeds sometimes accept -feed-browser-headers; JS challenges can't be passed.")
+	}
+}
+
+// dedupeCandidates merges candidates that are the same article under the
+// strategy, keeping the freshest copy and every plan that found it. It
+// also returns how many candidates were merged into another and how many
+// were dropped for a blank URL or an unusable title.
+func dedupeCandidates(in []discovery.Candidate, strategy DedupeStrategy) (out []discovery.Candidate, merged, unusable int) {
 	seen := map[string]discovery.Candidate{}
+	var order []string // keys in first-seen order, for a stable output
 	for _, c := range in {
-		u := strings.TrimSpace(c.URL)
-		if u == "" {
+		if strings.TrimSpace(c.URL) == "" || !discovery.HasUsableTitle(c.Title) {
+			unusable++
 			continue
 		}
+		u := strategy.key(c)
 		if prev, ok := seen[u]; ok {
+			// Keep the freshest copy but remember every plan that found it
+			keep := prev
 			if c.PublishedAt.After(prev.PublishedAt) {
-				seen[u] = c
+				keep = c
+				keep.Provenance = prev.Provenance
 			}
+			keep.AddProvenance(c.FoundBy)
+			seen[u] = keep
+			merged++
 			continue
 		}
+		c.Provenance = nil
+		c.AddProvenance(c.FoundBy)
 		seen[u] = c
+		order = append(order, u)
 	}
-
-	out := make([]discovery.Candidate, 0, len(seen))
-	for _, v := range seen {
-		out = append(out, v)
+	out = make([]discovery.Candidate, 0, len(seen))
+	for _, u := range order {
+		out = append(out, seen[u])
 	}
 
-	sort.Slice(out, func(i, j int) bool {
-		return out[i].PublishedAt.After(out[j].PublishedAt)
+	// Newest first; equal times by URL, so reruns list them the same way
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].PublishedAt.Equal(out[j].PublishedAt) {
+			return out[i].PublishedAt.After(out[j].PublishedAt)
+		}
+		return out[i].URL < out[j].URL
 	})
-	return out
+	return out, merged, unusable
 }
 
 // ===== Pivot selection =====
 
-func selectPivotLanguage(r *bufio.Reader) (string, error) {
+// selectPivotLanguage asks for the pivot language; Enter picks def.
+func selectPivotLanguage(w io.Writer, r *bufio.Reader, def string) (string, error) {
+	langs := PivotLanguages()
 	for {
-		fmt.Println("\nTranslate everything to (pivot language):")
-		fmt.Println("1) English (en)")
-		fmt.Println("2) French  (fr)")
-		fmt.Print("> ")
+		fmt.Fprintln(w, "\nTranslate everything to (pivot language):")
+		for i, l := range langs {
+			mark := ""
+			if l.Code == def {
+				mark = " (default)"
+			}
+			fmt.Fprintf(w, "%d) %s (%s)%s\n", i+1, l.Name, l.Code, mark)
+		}
+		fmt.Fprint(w, "> ")
 
 		choice, _ := r.ReadString('\n')
 		choice = strings.TrimSpace(choice)
+		if choice == "" {
+			return def, nil
+		}
 
-		switch choice {
-		case "1":
-			return "en", nil
-		case "2":
-			return "fr", nil
-		default:
-			fmt.Println("Invalid choice. Please select 1–2.")
+		// Accept the menu number or the code itself
+		if n, err := strconv.Atoi(choice); err == nil {
+			if n >= 1 && n <= len(langs) {
+				return langs[n-1].Code, nil
+			}
+		} else if code, err := ValidatePivotLang(choice); err == nil && choice != "" {
+			return code, nil
 		}
+		fmt.Fprintf(w, "Invalid choice. Please select 1–%d or enter a language code.\n", len(langs))
 	}
 }
 
 // ===== Printing helpers =====
 
-func printIntent(i Intent) {
+func printIntent(w io.Writer, i Intent) {
 	if len(i.Topics) > 0 {
-		fmt.Println("Topics   :", strings.Join(i.Topics, ", "))
+		fmt.Fprintln(w, "Topics   :", strings.Join(i.Topics, ", "))
 	}
 	if len(i.Regions) > 0 {
-		fmt.Println("Regions  :", strings.Join(i.Regions, ", "))
+		fmt.Fprintln(w, "Regions  :", strings.Join(i.Regions, ", "))
 	}
 	if len(i.Countries) > 0 {
-		fmt.Println("Countries:", strings.Join(i.Countries, ", "))
+		fmt.Fprintln(w, "Countries:", strings.Join(i.Countries, ", "))
 	}
 	if len(i.Themes) > 0 {
-		fmt.Println("Themes   :", strings.Join(i.Themes, ", "))
+		fmt.Fprintln(w, "Themes   :", strings.Join(i.Themes, ", "))
 	}
 	if len(i.Keywords) > 0 {
-		fmt.Println("Keywords :", strings.Join(i.Keywords, ", "))
+		fmt.Fprintln(w, "Keywords :", strings.Join(i.Keywords, ", "))
 	}
 }
 
-func printPlans(plans []SearchPlan) {
+func printPlans(w io.Writer, plans []SearchPlan) {
 	for idx, p := range plans {
-		fmt.Printf("%2d) [%s] (%s, w=%d) %s\n", idx+1, p.Scope, p.Focus, p.Weight, p.Query)
+		scope := p.Scope
+		if p.ScopeTerm != "" {
+			scope += " \"" + p.ScopeTerm + "\""
+		}
+		fmt.Fprintf(w, "%2d) [%s] (%s, w=%d) %s\n", idx+1, scope, p.Focus, p.Weight, p.Query)
 		if p.Explain != "" {
-			fmt.Printf("    - %s\n", p.Explain)
+			fmt.Fprintf(w, "    - %s\n", p.Explain)
 		}
 	}
 }
 
 // ===== Step 5: Search plan generation =====
 
-func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.CountryInfo) []SearchPlan {
+// BuildSearchPlans turns the query and its intent into search plans. A
+// region without a country expands into member countries from regions (nil =
+// geo.DefaultRegions).
+func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.CountryInfo, regions geo.Regions, synonyms Synonyms) []SearchPlan {
 	base := normalizeQuery(original)
 
-	// If forced countries exist (from Choose Country mode), override intent scopes
+	// If forced countries exist (from Choose Country mode), override intent scopes.
+	// Forced scopes are keyed by ISO2 but searched by country name.
 	var scopes []string
+	scopeTerms := map[string]string{}
 	if len(forcedCountries) > 0 {
 		for _, c := range forcedCountries {
-			scopes = append(scopes, "country:"+c.ISO2)
+			scope := "country:" + c.ISO2
+			scopes = append(scopes, scope)
+			scopeTerms[scope] = c.Name
 		}
 	} else {
 		scopes = buildScopes(intent)
@@ -687,11 +1201,12 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 
 	for _, scope := range scopes {
 		plans = append(plans, SearchPlan{
-			Query:   base,
-			Scope:   scope,
-			Focus:   "mixed",
-			Weight:  100,
-			Explain: "original user query",
+			Query:     base,
+			Scope:     scope,
+			ScopeTerm: scopeTerms[scope],
+			Focus:     "mixed",
+			Weight:    100,
+			Explain:   "original user query",
 		})
 	}
 
@@ -699,11 +1214,12 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 		kw := strings.Join(intent.Keywords, " ")
 		for _, scope := range scopes {
 			plans = append(plans, SearchPlan{
-				Query:   kw,
-				Scope:   scope,
-				Focus:   "mixed",
-				Weight:  85,
-				Explain: "top extracted keywords",
+				Query:     kw,
+				Scope:     scope,
+				ScopeTerm: scopeTerms[scope],
+				Focus:     "mixed",
+				Weight:    85,
+				Explain:   "top extracted keywords",
 			})
 		}
 	}
@@ -711,11 +1227,12 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 	for _, topic := range intent.Topics {
 		for _, scope := range scopes {
 			plans = append(plans, SearchPlan{
-				Query:   fmt.Sprintf("%s %s", base, strings.ToLower(topic)),
-				Scope:   scope,
-				Focus:   "topic:" + topic,
-				Weight:  80,
-				Explain: "topic expansion",
+				Query:     fmt.Sprintf("%s %s", base, strings.ToLower(topic)),
+				Scope:     scope,
+				ScopeTerm: scopeTerms[scope],
+				Focus:     "topic:" + topic,
+				Weight:    80,
+				Explain:   "topic expansion",
 			})
 		}
 	}
@@ -723,17 +1240,32 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 	for _, theme := range intent.Themes {
 		for _, scope := range scopes {
 			plans = append(plans, SearchPlan{
-				Query:   fmt.Sprintf("%s %s", base, strings.ToLower(theme)),
-				Scope:   scope,
-				Focus:   "theme:" + theme,
-				Weight:  75,
-				Explain: "theme expansion",
+				Query:     fmt.Sprintf("%s %s", base, strings.ToLower(theme)),
+				Scope:     scope,
+				ScopeTerm: scopeTerms[scope],
+				Focus:     "theme:" + theme,
+				Weight:    75,
+				Explain:   "theme expansion",
+			})
+		}
+	}
+
+	queries, notes := synonyms.expandedQueries(original)
+	for i, q := range queries {
+		for _, scope := range scopes {
+			plans = append(plans, SearchPlan{
+				Query:     q,
+				Scope:     scope,
+				ScopeTerm: scopeTerms[scope],
+				Focus:     "mixed",
+				Weight:    78,
+				Explain:   "synonym expansion (" + notes[i] + ")",
 			})
 		}
 	}
 
-	if len(intent.Countries) == 0 && len(intent.Regions) > 0 {
-		countries := countriesForRegions(intent.Regions)
+	if len(forcedCountries) == 0 && len(intent.Countries) == 0 && len(intent.Regions) > 0 {
+		countries := countriesForRegions(regions, intent.Regions)
 		for _, c := range countries {
 			plans = append(plans, SearchPlan{
 				Query:   fmt.Sprintf("%s %s", base, strings.ToLower(c)),
@@ -746,9 +1278,14 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 	}
 
 	plans = dedupePlans(plans)
+	// dedupePlans returns map order; Scope, Focus and Query are unique after
+	// it, so this is a total order and the plans come out the same every run
 	sort.Slice(plans, func(i, j int) bool {
 		if plans[i].Weight == plans[j].Weight {
 			if plans[i].Scope == plans[j].Scope {
+				if plans[i].Query == plans[j].Query {
+					return plans[i].Focus < plans[j].Focus
+				}
 				return plans[i].Query < plans[j].Query
 			}
 			return plans[i].Scope < plans[j].Scope
@@ -762,6 +1299,31 @@ func BuildSearchPlans(original string, intent Intent, forcedCountries []geo.Coun
 	return plans
 }
 
+// localizePlans gives country-scoped plans the country's name in each
+// language it is known in, so a French-language search appends "Allemagne"
+// rather than "Germany". Scopes may name the country by ISO2 or by name.
+func localizePlans(plans []SearchPlan, countries []geo.CountryInfo) {
+	for i := range plans {
+		sc := discovery.ParseScope(plans[i].Scope)
+		if sc.Kind != "country" {
+			continue
+		}
+		for _, c := range countries {
+			if len(c.LocalNames) == 0 {
+				continue
+			}
+			if !strings.EqualFold(sc.Value, c.ISO2) && !strings.EqualFold(sc.Value, c.Name) {
+				continue
+			}
+			plans[i].LocalTerms = c.LocalNames
+			if plans[i].ScopeTerm == "" {
+				plans[i].ScopeTerm = c.Name
+			}
+			break
+		}
+	}
+}
+
 func buildScopes(intent Intent) []string {
 	var scopes []string
 	for _, r := range intent.Regions {
@@ -776,58 +1338,198 @@ func buildScopes(intent Intent) []string {
 	return uniqueSorted(scopes)
 }
 
-func calculateConsensus(candidates []discovery.Candidate) map[string]int {
-	scores := make(map[string]int)
-	if len(candidates) < 2 {
-		return scores
+// FilterOptions tunes filterCandidates. The zero value keeps the default behavior.
+type FilterOptions struct {
+	// FreshnessFloor drops anything published before now-FreshnessFloor,
+	// on top of the search window ("what's new since I last looked"). 0 = off.
+	FreshnessFloor time.Duration
+
+	// Stemming also matches inflected forms ("vote" ~ "voting").
+	Stemming bool
+
+	// MinRelevance drops candidates scoring below it (one title keyword
+	// match is worth 10). 0 keeps anything scoring above zero.
+	MinRelevance int
+
+	// MinResults backfills from the best candidates under MinRelevance
+	// (still scoring above zero) when fewer than this many clear it. 0 = off.
+	MinResults int
+
+	// Order breaks ties between equally relevant candidates; zero weights
+	// mean DefaultOrderWeights.
+	Order OrderWeights
+
+	// MinSources drops candidates whose story (same-story cluster) is
+	// covered by fewer distinct publishers, after consensus scoring. <= 1 =
+	// off.
+	MinSources int
+
+	// PreferredDomains get preferredBoost on a match (normalized, see
+	// normalizeDomains). Search fills it from SearchRequest.PreferredDomains.
+	PreferredDomains []string
+
+	// MaxAgePerSource is a freshness floor per source kind (SourceKindFeed,
+	// SourceKindCountry), applied like FreshnessFloor. Missing kinds = off.
+	MaxAgePerSource map[string]time.Duration
+
+	// Noise drops user-flagged URLs and domains and blocklisted hosts.
+	// Search fills it from Service.Noise.
+	Noise *NoiseList
+
+	// PivotBoost is added to a matching candidate already in PivotLang (no
+	// translation needed). 0 = off. Search fills PivotLang from the request.
+	PivotBoost int
+	PivotLang  string
+
+	// Publisher keeps only candidates from that outlet (see
+	// discovery.MatchesPublisher). Search fills it from the request.
+	Publisher string
+
+	// Synonyms let a title match a query term through one of its
+	// synonyms (synonymHitScore each). Search fills it from the request.
+	Synonyms Synonyms
+}
+
+func stemSet(tokens []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		set[text.Stem(t)] = struct{}{}
 	}
+	return set
+}
 
-	// Pre-process titles into sets of tokens
-	type doc struct {
-		url    string
-		tokens map[string]struct{}
+// isLocalLanguageTarget reports whether c came from a resolved country's
+// edition in one of that country's own languages (not the English baseline,
+// unless English is a local language there).
+func isLocalLanguageTarget(c discovery.Candidate, countries []geo.CountryInfo) bool {
+	if c.TargetISO2 == "" || c.TargetLang == "" {
+		return false
 	}
+	for _, country := range countries {
+		if !strings.EqualFold(country.ISO2, c.TargetISO2) {
+			continue
+		}
+		for _, t := range geo.BuildDiscoveryTargets(country, false) { // false => local languages only
+			if strings.EqualFold(t.Lang, c.TargetLang) {
+				return true
+			}
+		}
+	}
+	return false
+}
 
-	docs := make([]doc, len(candidates))
-	for i, c := range candidates {
-		// Use extractKeywords to get significant tokens
-		tokens := extractKeywords(strings.ToLower(c.Title))
-		set := make(map[string]struct{})
-		for _, t := range tokens {
-			set[t] = struct{}{}
+// phraseBoost is added per exact query phrase found in a title.
+const phraseBoost = 20
+
+// maxPhraseWords: longer unquoted queries are sentences, not phrases.
+const maxPhraseWords = 6
+
+var reQuoted = regexp.MustCompile(`"([^"]+)"|“([^”]+)”`)
+
+// queryPhrases returns the phrases worth an exact-match bonus, as
+// space-joined tokens: every quoted phrase, or else the whole query when it
+// is 2..maxPhraseWords words long.
+func queryPhrases(query string) []string {
+	var out []string
+	for _, m := range reQuoted.FindAllStringSubmatch(query, -1) {
+		ph := m[1]
+		if ph == "" {
+			ph = m[2]
+		}
+		if toks := text.Tokenize(ph); len(toks) > 0 {
+			out = append(out, strings.Join(toks, " "))
 		}
-		docs[i] = doc{c.URL, set}
 	}
+	if len(out) > 0 {
+		return out
+	}
+	if toks := text.Tokenize(query); len(toks) >= 2 && len(toks) <= maxPhraseWords {
+		out = append(out, strings.Join(toks, " "))
+	}
+	return out
+}
 
-	// Compare every pair
-	for i := 0; i < len(docs); i++ {
-		for j := 0; j < len(docs); j++ {
-			if i == j {
-				continue
-			}
+// cooccurrenceBoost is added once to a title where a query keyword and a
+// searched country's name are at most cooccurrenceWindow words apart
+// ("floods hit Kenya"), on top of the separate keyword and country matches: the
+// article is about the topic in that country, not merely mentioning both.
+const (
+	cooccurrenceBoost  = 6
+	cooccurrenceWindow = 4
+)
 
-			// Calculate overlap (Jaccard-ish)
-			common := 0
-			for t := range docs[i].tokens {
-				if _, ok := docs[j].tokens[t]; ok {
-					common++
+// termsNearCountry reports whether one of terms and one of countries occur
+// in tokens with at most window tokens between them. Terms and names may span several
+// tokens ("united states"); with stems, words also match by stem.
+func termsNearCountry(tokens, terms, countries []string, window int, stems bool) bool {
+	norm := func(w string) string {
+		if stems {
+			return text.Stem(w)
+		}
+		return w
+	}
+	toks := make([]string, len(tokens))
+	for i, t := range tokens {
+		toks[i] = norm(t)
+	}
+	// spans returns the [start, end) token ranges where phrase occurs
+	spans := func(phrase string) [][2]int {
+		words := text.Tokenize(phrase)
+		for i := range words {
+			words[i] = norm(words[i])
+		}
+		var out [][2]int
+		if len(words) == 0 {
+			return out
+		}
+	next:
+		for i := 0; i+len(words) <= len(toks); i++ {
+			for j, w := range words {
+				if toks[i+j] != w {
+					continue next
 				}
 			}
+			out = append(out, [2]int{i, i + len(words)})
+		}
+		return out
+	}
 
-			// Threshold: if they share significant keywords, assume they cover the same topic
-			if common >= 2 {
-				scores[docs[i].url]++
+	var countrySpans [][2]int
+	for _, c := range countries {
+		countrySpans = append(countrySpans, spans(c)...)
+	}
+	if len(countrySpans) == 0 {
+		return false
+	}
+	for _, t := range terms {
+		for _, ts := range spans(t) {
+			for _, cs := range countrySpans {
+				overlap := ts[0] < cs[1] && cs[0] < ts[1]
+				if !overlap && max(cs[0]-ts[1], ts[0]-cs[1]) <= window {
+					return true
+				}
 			}
 		}
 	}
-	return scores
+	return false
 }
 
-func filterCandidates(candidates []discovery.Candidate, query string, intent Intent, countries []geo.CountryInfo) []discovery.Candidate {
+// Relevance points per additional plan that found a candidate, and the cap.
+const (
+	provenanceBoost    = 2
+	maxProvenanceBoost = 6
+)
+
+func filterCandidates(candidates []discovery.Candidate, query string, intent Intent, countries []geo.CountryInfo, opts FilterOptions) []discovery.Candidate {
 	if len(candidates) == 0 {
 		return candidates
 	}
 
+	var floor time.Time
+	if opts.FreshnessFloor > 0 {
+		floor = time.Now().Add(-opts.FreshnessFloor)
+	}
+
 	// Normalize query terms for simple matching
 	qTerms := extractKeywords(strings.ToLower(query))
 
@@ -836,6 +1538,9 @@ func filterCandidates(candidates []discovery.Candidate, query string, intent Int
 		qTerms = append(qTerms, strings.ToLower(k))
 	}
 
+	phrases := queryPhrases(query)
+	synonymTerms := opts.Synonyms.matches(query)
+
 	// If explicit countries, add them to boost match
 	countryTerms := []string{}
 	for _, c := range countries {
@@ -843,50 +1548,139 @@ func filterCandidates(candidates []discovery.Candidate, query string, intent Int
 	}
 
 	type scored struct {
-		c     discovery.Candidate
-		score int
+		c        discovery.Candidate
+		score    int
+		tiebreak float64
 	}
 
+	now := time.Now()
+	order := opts.Order.withDefaults()
+	kindFloors := sourceFloors(opts.MaxAgePerSource, now)
+
 	var scoredCandidates []scored
 
 	for _, c := range candidates {
+		// Undated candidates can't be judged against the floor; keep them
+		if !floor.IsZero() && !c.Undated && c.PublishedAt.Before(floor) {
+			continue
+		}
+		if kf, ok := kindFloors[sourceKind(c)]; ok && !c.Undated && c.PublishedAt.Before(kf) {
+			continue
+		}
+		if opts.Noise.Drops(c) {
+			continue
+		}
+		if opts.Publisher != "" && !discovery.MatchesPublisher(c, opts.Publisher) {
+			continue
+		}
+
 		score := 0
-		title := strings.ToLower(c.Title)
+		title := strings.ToLower(text.NormalizeTitle(c.Title, c.Publisher, c.Source))
+
+		var titleStems map[string]struct{} // nil (never matches) unless stemming
+		if opts.Stemming {
+			titleStems = stemSet(text.Tokenize(title))
+		}
 
 		// 1. Title keyword match (high weight)
 		for _, term := range qTerms {
 			if strings.Contains(title, term) {
 				score += 10
+			} else if _, ok := titleStems[text.Stem(term)]; ok {
+				score += 10
 			}
 		}
 
+		// 1b. Exact phrase (quoted, or the whole short query) beats scattered words
+		if len(phrases) > 0 {
+			joined := " " + strings.Join(text.Tokenize(title), " ") + " "
+			for _, ph := range phrases {
+				if strings.Contains(joined, " "+ph+" ") {
+					score += phraseBoost
+				}
+			}
+		}
+
+		// 1b'. A query term named differently ("BoE" for "central bank")
+		if len(synonymTerms) > 0 {
+			score += synonymHitScore * synonymHits(synonymTerms, text.Tokenize(title))
+		}
+
+		// 1c. RSS sources' own weighted match, title hits above
+		// description-only ones
+		score += c.FeedMatch
+
 		// 2. Country match (medium weight)
+		nameMatch := false
 		for _, cName := range countryTerms {
 			if strings.Contains(title, cName) {
 				score += 5
+				nameMatch = true
 			}
 		}
+		// Local-language coverage rarely uses the English country name, so
+		// being found through that country's own language counts the same,
+		// for a title that already matched the query.
+		if !nameMatch && score > 0 && isLocalLanguageTarget(c, countries) {
+			score += 5
+		}
+
+		// 2b. Keyword next to the country name: about the topic there
+		if nameMatch && termsNearCountry(text.Tokenize(title), qTerms, countryTerms, cooccurrenceWindow, opts.Stemming) {
+			score += cooccurrenceBoost
+		}
 
-		// 3. Recency boost (simple)
-		if time.Since(c.PublishedAt) < 24*time.Hour {
+		// 3. Recency boost (simple); undated candidates get none
+		if !c.Undated && time.Since(c.PublishedAt) < 24*time.Hour {
 			score += 2
 		}
 
+		// 4. Found by several plans: independent queries agree it's relevant.
+		// Only strengthens a match, never creates one.
+		if score > 0 && len(c.Provenance) > 1 {
+			score += mini(provenanceBoost*(len(c.Provenance)-1), maxProvenanceBoost)
+		}
+
+		// 5. Preferred outlet: soft prioritization of an existing match
+		if score > 0 && isPreferred(c, opts.PreferredDomains) {
+			score += preferredBoost
+		}
+
+		// 6. Already in the pivot language: readable without translation
+		if score > 0 && opts.PivotBoost > 0 && sameLang(candidateLang(c), opts.PivotLang) {
+			score += opts.PivotBoost
+		}
+
 		// Threshold: at least one keyword match or very strong other signals
 		if score > 0 {
 			// Update the candidate's score
 			c.RelevanceScore = score
-			scoredCandidates = append(scoredCandidates, scored{c, score})
+			scoredCandidates = append(scoredCandidates, scored{c, score, order.tiebreak(c, now)})
 		}
 	}
 
-	// Sort by score descending
-	sort.Slice(scoredCandidates, func(i, j int) bool {
-		return scoredCandidates[i].score > scoredCandidates[j].score
+	// Sort by score descending; equal scores by recency, then source weight
+	sort.SliceStable(scoredCandidates, func(i, j int) bool {
+		if scoredCandidates[i].score != scoredCandidates[j].score {
+			return scoredCandidates[i].score > scoredCandidates[j].score
+		}
+		return scoredCandidates[i].tiebreak > scoredCandidates[j].tiebreak
 	})
 
-	out := make([]discovery.Candidate, len(scoredCandidates))
-	for i, sc := range scoredCandidates {
+	// Relevance cutoff first, then backfill the best of the rest if too few
+	// cleared it. Sorted input means the backfill is just a longer prefix.
+	keep := len(scoredCandidates)
+	if opts.MinRelevance > 0 {
+		keep = sort.Search(len(scoredCandidates), func(i int) bool {
+			return scoredCandidates[i].score < opts.MinRelevance
+		})
+		if keep < opts.MinResults {
+			keep = mini(opts.MinResults, len(scoredCandidates))
+		}
+	}
+
+	out := make([]discovery.Candidate, keep)
+	for i, sc := range scoredCandidates[:keep] {
 		out[i] = sc.c
 	}
 
@@ -921,41 +1715,44 @@ func dedupePlans(plans []SearchPlan) []SearchPlan {
 	return out
 }
 
-func countriesForRegions(regions []string) []string {
-	set := map[string]struct{}{}
-	for _, r := range regions {
-		switch r {
-		case "South America":
-			for _, c := range []string{"Argentina", "Bolivia", "Brazil", "Chile", "Colombia", "Ecuador", "Guyana", "Paraguay", "Peru", "Suriname", "Uruguay", "Venezuela"} {
-				set[c] = struct{}{}
-			}
-		case "Caribbean":
-			for _, c := range []string{"Haiti", "Jamaica", "Dominican Rep.", "Cuba", "Trinidad", "Barbados", "Bahamas"} {
-				set[c] = struct{}{}
-			}
-		}
-	}
-	out := make([]string, 0, len(set))
-	for c := range set {
-		out = append(out, c)
+// maxCountriesPerRegion caps the fan-out of a region scope ("Caribbean") into
+// country targets; the regions table lists members most newsworthy first.
+const maxCountriesPerRegion = 4
+
+func countriesForRegions(table geo.Regions, regions []string) []string {
+	if table == nil {
+		table = geo.DefaultRegions
 	}
-	sort.Strings(out)
-	return out
+	return table.Countries(regions, maxCountriesPerRegion)
 }
 
 // ===== Step 4: Intent extraction (rule-based) =====
 
+// ExtractIntent is ExtractIntentIn with the query language detected.
 func ExtractIntent(text string) Intent {
-	t := strings.ToLower(text)
+	return ExtractIntentIn(text, "")
+}
+
+// ExtractIntentIn extracts the intent of query written in lang (ISO-639-1;
+// "" = detect). The language adds its localized lexicon patterns to the
+// English ones and picks the stopwords for keywords. The result is a pure
+// function of its inputs: label lists are sorted and keywords are in a
+// total order (see text.Keywords), so plans built from it are stable.
+func ExtractIntentIn(query, lang string) Intent {
+	t := strings.ToLower(query)
+	if lang == "" {
+		lang = DetectQueryLang(query)
+	}
 
-	regionsFound := matchAny(t, regionLexicon)
+	regionsFound := matchAny(t, lexiconFor(regionLexicon, localRegionLexicon, lang))
 	countriesFound := matchAny(t, countryLexicon)
-	topicsFound := matchAny(t, topicLexicon)
-	themesFound := matchAny(t, themeLexicon)
+	topicsFound := matchAny(t, lexiconFor(topicLexicon, localTopicLexicon, lang))
+	themesFound := matchAny(t, lexiconFor(themeLexicon, localThemeLexicon, lang))
 
-	keywords := extractKeywords(t)
+	keywords := text.Keywords(t, lang, maxKeywords)
 
 	return Intent{
+		Lang:      lang,
 		Topics:    uniqueSorted(topicsFound),
 		Regions:   uniqueSorted(regionsFound),
 		Countries: uniqueSorted(countriesFound),
@@ -1016,10 +1813,18 @@ var themeLexicon = map[string][]string{
 	"Foreign policy": {"diplomacy", "treaty", "summit", "un", "oas"},
 }
 
+// matchAny returns the labels of lex with a pattern in text, sorted, so the
+// result never depends on map iteration order.
 func matchAny(text string, lex map[string][]string) []string {
+	labels := make([]string, 0, len(lex))
+	for label := range lex {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
 	var hits []string
-	for label, patterns := range lex {
-		for _, p := range patterns {
+	for _, label := range labels {
+		for _, p := range lex[label] {
 			if strings.Contains(text, p) {
 				hits = append(hits, label)
 				break
@@ -1029,56 +1834,11 @@ func matchAny(text string, lex map[string][]string) []string {
 	return hits
 }
 
-var stopwords = map[string]struct{}{
-	"the": {}, "a": {}, "an": {}, "and": {}, "or": {}, "to": {}, "of": {}, "in": {}, "on": {}, "for": {}, "with": {},
-	"is": {}, "are": {}, "was": {}, "were": {}, "be": {}, "been": {}, "being": {}, "this": {}, "that": {}, "these": {}, "those": {},
-	"what": {}, "who": {}, "where": {}, "when": {}, "why": {}, "how": {}, "latest": {}, "major": {}, "developments": {}, "development": {},
-}
-
-func extractKeywords(text string) []string {
-	re := regexp.MustCompile(`[^\pL\pN]+`)
-	raw := re.Split(text, -1)
+// maxKeywords caps how many keywords a query or title contributes.
+const maxKeywords = 12
 
-	counts := map[string]int{}
-	for _, tok := range raw {
-		tok = strings.TrimSpace(tok)
-		if tok == "" {
-			continue
-		}
-		if len([]rune(tok)) < 3 {
-			continue
-		}
-		if _, ok := stopwords[tok]; ok {
-			continue
-		}
-		counts[tok]++
-	}
-
-	type kv struct {
-		k string
-		v int
-	}
-	var all []kv
-	for k, v := range counts {
-		all = append(all, kv{k: k, v: v})
-	}
-
-	sort.Slice(all, func(i, j int) bool {
-		if all[i].v == all[j].v {
-			return all[i].k < all[j].k
-		}
-		return all[i].v > all[j].v
-	})
-
-	N := 12
-	if len(all) < N {
-		N = len(all)
-	}
-	out := make([]string, 0, N)
-	for i := 0; i < N; i++ {
-		out = append(out, all[i].k)
-	}
-	return out
+func extractKeywords(t string) []string {
+	return text.Keywords(t, "", maxKeywords)
 }
 
 func uniqueSorted(in []string) []string {
@@ -1104,13 +1864,23 @@ const (
 	ScopeGlobal
 )
 
-func selectSearchScope(r *bufio.Reader) (SearchScope, string, error) {
+func (s SearchScope) String() string {
+	switch s {
+	case ScopeChosen:
+		return "chosen"
+	case ScopeGlobal:
+		return "global"
+	}
+	return "auto"
+}
+
+func selectSearchScope(w io.Writer, r *bufio.Reader) (SearchScope, string, error) {
 	for {
-		fmt.Println("\nSearch scope:")
-		fmt.Println("1) Auto-detect from text (default)")
-		fmt.Println("2) Choose country")
-		fmt.Println("3) Global (worldwide)")
-		fmt.Print("> ")
+		fmt.Fprintln(w, "\nSearch scope:")
+		fmt.Fprintln(w, "1) Auto-detect from text (default)")
+		fmt.Fprintln(w, "2) Choose country")
+		fmt.Fprintln(w, "3) Global (worldwide)")
+		fmt.Fprint(w, "> ")
 
 		choice, _ := r.ReadString('\n')
 		choice = strings.TrimSpace(choice)
@@ -1123,34 +1893,36 @@ func selectSearchScope(r *bufio.Reader) (SearchScope, string, error) {
 		case "1":
 			return ScopeAuto, "", nil
 		case "2":
-			fmt.Println("Enter country name (e.g. 'Bulgaria'):")
-			fmt.Print("> ")
+			fmt.Fprintln(w, "Enter country name (e.g. 'Bulgaria'):")
+			fmt.Fprint(w, "> ")
 			c, _ := r.ReadString('\n')
 			c = strings.TrimSpace(c)
 			if c == "" {
-				fmt.Println("Empty country, falling back to Auto.")
+				fmt.Fprintln(w, "Empty country, falling back to Auto.")
 				return ScopeAuto, "", nil
 			}
 			return ScopeChosen, c, nil
 		case "3":
 			return ScopeGlobal, "", nil
 		default:
-			fmt.Println("Invalid choice. Please select 1-3.")
+			fmt.Fprintln(w, "Invalid choice. Please select 1-3.")
 		}
 	}
 }
 
 // ===== Time window selection =====
 
-func selectTimeRange(r *bufio.Reader) (TimeRange, error) {
+// selectTimeRange asks for the search window; custom dates are days in loc
+// (nil = UTC).
+func selectTimeRange(w io.Writer, r *bufio.Reader, loc *time.Location) (TimeRange, error) {
 	now := time.Now()
 	for {
-		fmt.Println("\nSelect time window:")
-		fmt.Println("1) Last 24 hours")
-		fmt.Println("2) Last 7 days")
-		fmt.Println("3) Last 30 days")
-		fmt.Println("4) Custom (YYYY-MM-DD to YYYY-MM-DD)")
-		fmt.Print("> ")
+		fmt.Fprintln(w, "\nSelect time window:")
+		fmt.Fprintln(w, "1) Last 24 hours")
+		fmt.Fprintln(w, "2) Last 7 days")
+		fmt.Fprintln(w, "3) Last 30 days")
+		fmt.Fprintln(w, "4) Custom (YYYY-MM-DD to YYYY-MM-DD)")
+		fmt.Fprint(w, "> ")
 
 		choice, _ := r.ReadString('\n')
 		choice = strings.TrimSpace(choice)
@@ -1163,41 +1935,39 @@ func selectTimeRange(r *bufio.Reader) (TimeRange, error) {
 		case "3":
 			return TimeRange{From: now.AddDate(0, 0, -30), To: now, Label: "Last 30 days"}, nil
 		case "4":
-			return readCustomRange(r)
+			return readCustomRange(w, r, loc)
 		default:
-			fmt.Println("Invalid choice. Please select 1–4.")
+			fmt.Fprintln(w, "Invalid choice. Please select 1–4.")
 		}
 	}
 }
 
-func readCustomRange(r *bufio.Reader) (TimeRange, error) {
+func readCustomRange(w io.Writer, r *bufio.Reader, loc *time.Location) (TimeRange, error) {
+	zone := "UTC"
+	if loc != nil {
+		zone = loc.String()
+	}
 	for {
-		fmt.Print("From date (YYYY-MM-DD): ")
+		fmt.Fprint(w, "From date (YYYY-MM-DD): ")
 		fromStr, _ := r.ReadString('\n')
-		fmt.Print("To date (YYYY-MM-DD): ")
+		fmt.Fprint(w, "To date (YYYY-MM-DD): ")
 		toStr, _ := r.ReadString('\n')
 
 		fromStr = strings.TrimSpace(fromStr)
 		toStr = strings.TrimSpace(toStr)
 
-		from, err1 := time.Parse("2006-01-02", fromStr)
-		to, err2 := time.Parse("2006-01-02", toStr)
-
-		if err1 != nil || err2 != nil {
-			fmt.Println("Invalid date format. Try again.")
-			continue
-		}
-		if from.After(to) {
-			fmt.Println("From date must be before To date.")
+		from, to, err := DayRange(fromStr, toStr, loc)
+		if err != nil {
+			fmt.Fprintf(w, "Invalid range (%s). Try again.\n", err)
 			continue
 		}
-		return TimeRange{From: from, To: to, Label: fmt.Sprintf("Custom (%s → %s)", fromStr, toStr)}, nil
+		return TimeRange{From: from, To: to, Label: fmt.Sprintf("Custom (%s → %s, whole days %s)", fromStr, toStr, zone)}, nil
 	}
 }
 
 // ===== Input helpers =====
 
-func readMultiline(r *bufio.Reader) (string, error) {
+func readMultiline(w io.Writer, r *bufio.Reader) (string, error) {
 	var lines []string
 	for {
 		line, err := r.ReadString('\n')
@@ -1216,7 +1986,7 @@ func readMultiline(r *bufio.Reader) (string, error) {
 			if len(lines) > 0 {
 				break
 			}
-			fmt.Print("> ")
+			fmt.Fprint(w, "> ")
 			continue
 		}
 		lines = append(lines, line)
@@ -1261,6 +2031,12 @@ func validateQuery(q string) (bool, string) {
 
 	words := strings.Fields(q)
 	if len(words) < 2 {
+		if QueryURLs(q) != nil {
+			return true, "" // routed to extraction, see QueryURLs
+		}
+		if isRunOnWord(q) {
+			return false, "single word too long"
+		}
 		if m := reWordToken.FindString(q); len([]rune(m)) >= 4 {
 			return true, ""
 		}
@@ -1269,6 +2045,109 @@ func validateQuery(q string) (bool, string) {
 	return true, ""
 }
 
+// maxWordRunes: a Latin-script "word" longer than this is a paste accident
+// (hash, token, run-together text), not a search term.
+const maxWordRunes = 45
+
+// isRunOnWord reports whether w is a single over-long Latin-script word.
+// Scripts written without spaces are exempt.
+func isRunOnWord(w string) bool {
+	if len([]rune(w)) <= maxWordRunes {
+		return false
+	}
+	for _, r := range w {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSelection turns the extraction prompt answer into 0-based candidate
+// indices. A bare number means "top N" (clamped to total); otherwise the input
+// is a comma-separated list of 1-based numbers and ranges ("1,3,7-9"), which
+// must all be within 1..total. Blank input selects the top def. "Top N" picks
+// come from top (see pickTop).
+func parseSelection(line string, total, def int, top func(n int) []int) ([]int, error) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return top(mini(def, total)), nil
+	}
+	if n, err := strconv.Atoi(line); err == nil {
+		if n < 0 {
+			n = 0
+		}
+		return top(mini(n, total)), nil
+	}
+
+	seen := make(map[int]bool)
+	var out []int
+	for _, part := range strings.Split(line, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi := part, part
+		if a, b, ok := strings.Cut(part, "-"); ok {
+			lo, hi = strings.TrimSpace(a), strings.TrimSpace(b)
+		}
+		from, err1 := strconv.Atoi(lo)
+		to, err2 := strconv.Atoi(hi)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("%q is not a number or range", part)
+		}
+		if from > to {
+			return nil, fmt.Errorf("range %q is reversed", part)
+		}
+		if from < 1 || to > total {
+			return nil, fmt.Errorf("%q is outside 1-%d", part, total)
+		}
+		for i := from; i <= to; i++ {
+			if !seen[i] {
+				seen[i] = true
+				out = append(out, i-1)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("nothing selected")
+	}
+	return out, nil
+}
+
+// Ways to choose the "top N" candidates to extract.
+const (
+	ExtractByRelevance = "relevance"
+	ExtractByCluster   = "cluster" // one representative per same-story cluster
+	// ExtractByClusterDirect is ExtractByCluster, but each cluster is
+	// represented by its best member with a direct publisher URL (curated
+	// RSS, Bing) when it has one, rather than a Google News link the worker
+	// must unwrap first.
+	ExtractByClusterDirect = "cluster-direct"
+)
+
+// pickTop returns the indices of the n candidates to extract: the n most
+// relevant, or with ExtractByCluster(Direct) a member of each of the n
+// largest same-story clusters (fewer if there are fewer clusters).
+func pickTop(candidates []discovery.Candidate, by string, cfg ConsensusConfig, n int) []int {
+	n = mini(n, len(candidates))
+	switch by {
+	case ExtractByCluster:
+		return clusterRepresentatives(candidates, cfg, n, false)
+	case ExtractByClusterDirect:
+		return clusterRepresentatives(candidates, cfg, n, true)
+	}
+	return topN(n)
+}
+
+func topN(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
 func mini(a, b int) int {
 	if a < b {
 		return a
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"newscheck/internal/extract"
+)
+
+// BatchOptions are the shared settings for a -queries-file run; each query
+// may override the window and scope.
+type BatchOptions struct {
+	Days      int    // search window (SearchWindow semantics)
+	Scope     string // "auto", "global" or a country name
+	OutDir    string // one sub-folder per query is created here
+	Extract   int    // top N candidates to extract and summarize; 0 = none
+	ExtractBy string // ExtractByRelevance, ExtractByCluster or ExtractByClusterDirect
+	Pivot     string
+
+	urls *urlList // -format urls: where each query's candidate URLs go
+}
+
+// batchQuery is one entry of a queries file.
+type batchQuery struct {
+	Query string `json:"query"`
+	Days  *int   `json:"days,omitempty"` // nil = BatchOptions.Days
+	Scope string `json:"scope,omitempty"`
+}
+
+// readQueriesFile accepts either a JSON array of {"query","days","scope"}
+// objects or plain text with one query per line. Text lines may add
+// overrides after " | ", e.g. "port strike | days=1 | scope=France".
+// Blank lines and lines starting with # are skipped.
+func readQueriesFile(path string) ([]batchQuery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var qs []batchQuery
+		if err := json.Unmarshal(trimmed, &qs); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return qs, nil
+	}
+
+	var qs []batchQuery
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		parts := strings.Split(text, " | ")
+		q := batchQuery{Query: strings.TrimSpace(parts[0])}
+		for _, p := range parts[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if !ok {
+				return nil, fmt.Errorf("%s:%d: override %q is not key=value", path, line, p)
+			}
+			switch strings.TrimSpace(k) {
+			case "days":
+				n, err := strconv.Atoi(strings.TrimSpace(v))
+				if err != nil {
+					return nil, fmt.Errorf("%s:%d: days: %w", path, line, err)
+				}
+				q.Days = &n
+			case "scope":
+				q.Scope = strings.TrimSpace(v)
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown override %q", path, line, k)
+			}
+		}
+		qs = append(qs, q)
+	}
+	return qs, sc.Err()
+}
+
+// window is the search window of q: its own days, or def when it has
+// none. An invalid one is an error (see SearchWindow).
+func (q batchQuery) window(def int, now time.Time) (time.Time, time.Time, error) {
+	days := def
+	if q.Days != nil {
+		days = *q.Days
+	}
+	from, to, err := SearchWindow(days, "", "", now)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("days: %w", err)
+	}
+	return from, to, nil
+}
+
+// scopeFromString maps "auto"/"global"/<country> to a scope and chosen country.
+func scopeFromString(s string) (SearchScope, string) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "auto":
+		return ScopeAuto, ""
+	case "global":
+		return ScopeGlobal, ""
+	}
+	return ScopeChosen, strings.TrimSpace(s)
+}
+
+// runBatch runs every query in path through search (and optionally
+// extraction) and writes each query's reports plus result.json into its own
+// folder under opts.OutDir. A failing query is reported and skipped. With a
+// sincePath, each query only keeps candidates newer than its last run.
+func runBatch(ctx context.Context, w io.Writer, svc *Service, path, sincePath string, opts BatchOptions, base SearchRequest) ([]string, error) {
+	pivot, err := ValidatePivotLang(opts.Pivot)
+	if err != nil {
+		return nil, err
+	}
+	queries, err := readQueriesFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("%s: no queries", path)
+	}
+
+	runDir := filepath.Join(opts.OutDir, time.Now().Format("20060102_150405"))
+	var dirs []string
+	for i, q := range queries {
+		fmt.Fprintf(w, "\n=== [%d/%d] %s\n", i+1, len(queries), q.Query)
+		if ok, reason := validateQuery(q.Query); !ok {
+			fmt.Fprintf(w, "Skipped: invalid query (%s)\n", reason)
+			continue
+		}
+
+		scope := opts.Scope
+		if q.Scope != "" {
+			scope = q.Scope
+		}
+
+		req := base
+		req.Query = q.Query
+		if req.From, req.To, err = q.window(opts.Days, time.Now()); err != nil {
+			fmt.Fprintln(w, "Skipped:", err)
+			continue
+		}
+		req.Scope, req.ChosenCountry = scopeFromString(scope)
+		req.PivotLang = pivot
+
+		res, err := svc.Search(ctx, req)
+		if err != nil {
+			fmt.Fprintln(w, "Search failed:", err)
+			continue
+		}
+		if sincePath != "" {
+			if err := applySinceFile(w, sincePath, q.Query, res); err != nil {
+				return dirs, err
+			}
+		}
+		printSourceErrors(w, res.SourceErrors)
+		printFeedFailures(w, res.FeedFailures)
+		fmt.Fprintf(w, "%d candidates\n", len(res.Candidates))
+		if err := opts.urls.write(res.Candidates); err != nil {
+			return dirs, err
+		}
+
+		var articles []extract.Article
+		var summary string
+		if picks := pickTop(res.Candidates, opts.ExtractBy, svc.Consensus, opts.Extract); len(picks) > 0 {
+			urls := make([]string, 0, len(picks))
+			for _, i := range picks {
+				urls = append(urls, res.Candidates[i].URL)
+			}
+			articles, summary, err = svc.ExtractAndSummarize(ctx, urls, pivot, q.Query, "")
+			if err != nil {
+				fmt.Fprintln(w, "Extraction failed:", err)
+			}
+			backfillPublishedAt(res.Candidates, articles)
+		}
+
+		dir := filepath.Join(runDir, fmt.Sprintf("%02d_%s", i+1, slugify(q.Query)))
+		written, err := svc.GenerateAllReports(dir, res, articles, summary, q.Query)
+		if err != nil {
+			fmt.Fprintln(w, "Reports failed:", err)
+			continue
+		}
+		if err := writeResultJSON(filepath.Join(dir, "result.json"), res); err != nil {
+			fmt.Fprintln(w, "result.json failed:", err)
+			continue
+		}
+		for _, file := range written {
+			fmt.Fprintln(w, "Saved:", file)
+		}
+		dirs = append(dirs, dir)
+	}
+	fmt.Fprintf(w, "\nBatch done: %d of %d queries written to %s\n", len(dirs), len(queries), runDir)
+	return dirs, nil
+}
+
+func writeResultJSON(path string, res *SearchResult) error {
+	b, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// slugify makes a short folder-safe name from a query.
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	out := strings.Trim(b.String(), "-")
+	if out == "" {
+		return "query"
+	}
+	return out
+}
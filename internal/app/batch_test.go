@@ -0,0 +1,70 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatchQueryWindow(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		file     string
+		def      int
+		wantDays []int // per query; 0 = want an error
+	}{
+		{name: "default days", file: "port strike\n", def: 7, wantDays: []int{7}},
+		{name: "line override", file: "port strike | days=1\nfloods\n", def: 7, wantDays: []int{1, 7}},
+		{name: "zero override fails only its line", file: "port strike | days=0\nfloods\n", def: 7, wantDays: []int{0, 7}},
+		{name: "negative override", file: "port strike | days=-3\n", def: 7, wantDays: []int{0}},
+		{name: "custom range needs dates", file: "port strike | days=-1\n", def: 7, wantDays: []int{0}},
+		{name: "bad default fails lines without override", file: "port strike\nfloods | days=2\n", def: 0, wantDays: []int{0, 2}},
+		{name: "json", file: `[{"query": "port strike", "days": 3}, {"query": "floods"}, {"query": "fires", "days": 0}]`, def: 7, wantDays: []int{3, 7, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "queries")
+			if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			qs, err := readQueriesFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(qs) != len(tt.wantDays) {
+				t.Fatalf("got %d queries, want %d", len(qs), len(tt.wantDays))
+			}
+			for i, q := range qs {
+				from, to, err := q.window(tt.def, now)
+				if tt.wantDays[i] == 0 {
+					if err == nil || !strings.Contains(err.Error(), "days") {
+						t.Errorf("%s: err = %v, want a days error", q.Query, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("%s: %v", q.Query, err)
+					continue
+				}
+				want, _, _ := SearchWindow(tt.wantDays[i], "", "", now)
+				if !from.Equal(want) || !to.Equal(now) {
+					t.Errorf("%s: window = %v..%v, want %v..%v", q.Query, from, to, want, now)
+				}
+			}
+		})
+	}
+}
+
+func TestReadQueriesFileRejectsBadDays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queries")
+	if err := os.WriteFile(path, []byte("port strike | days=week\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readQueriesFile(path); err == nil || !strings.Contains(err.Error(), ":1: days") {
+		t.Errorf("err = %v, want a line 1 days error", err)
+	}
+}
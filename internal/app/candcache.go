@@ -0,0 +1,107 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+// DefaultCandidateCacheTTL is how long discovered candidates are reused by
+// an identical search.
+const DefaultCandidateCacheTTL = 30 * time.Minute
+
+// CandidateCache stores discovery results on disk so re-running the same
+// search (to try another extraction or filter setting) skips the sources.
+// One JSON file per search key.
+type CandidateCache struct {
+	Dir string
+	TTL time.Duration
+}
+
+type candidateCacheEntry struct {
+	SavedAt    time.Time             `json:"saved_at"`
+	Candidates []discovery.Candidate `json:"candidates"`
+}
+
+// NewCandidateCache uses <user cache dir>/newscheck/candidates. Returns nil
+// (caching off) when there is no user cache dir.
+func NewCandidateCache() *CandidateCache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return &CandidateCache{Dir: filepath.Join(dir, "newscheck", "candidates"), TTL: DefaultCandidateCacheTTL}
+}
+
+// candidateCacheKey identifies a discovery run: the normalized query, scope,
+// window, targets, sources and dedupe strategy. Rolling windows ("last 7 days") are
+// keyed by length, so a run a few minutes later still matches; custom
+// windows ending in the past are keyed by their dates.
+func candidateCacheKey(req SearchRequest, targets []geo.DiscoveryTarget, sources []DiscoverySource, now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%s|%s|", normalizeQuery(req.Query), req.QueryLang, req.Scope, strings.ToLower(req.ChosenCountry))
+	if now.Sub(req.To) > time.Hour {
+		fmt.Fprintf(&b, "%s..%s|", req.From.Format("2006-01-02"), req.To.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(&b, "%s|", req.To.Sub(req.From).Round(time.Hour))
+	}
+	for _, t := range targets {
+		fmt.Fprintf(&b, "%s/%s,", t.ISO2, t.Lang)
+	}
+	fmt.Fprintf(&b, "|%s|%s|%s|%s|%s", sourceNames(sources), req.Dedupe, strings.Join(req.PreferredDomains, ","), strings.ToLower(req.Publisher), req.Synonyms.cacheKey(req.Query))
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:12])
+}
+
+func (c *CandidateCache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// get returns the candidates cached under key if they are younger than TTL.
+func (c *CandidateCache) get(key string, now time.Time) ([]discovery.Candidate, bool) {
+	if c == nil {
+		return nil, false
+	}
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var e candidateCacheEntry
+	if err := json.Unmarshal(b, &e); err != nil || now.Sub(e.SavedAt) > c.TTL {
+		return nil, false
+	}
+	return e.Candidates, true
+}
+
+// put stores candidates under key and removes expired entries.
+func (c *CandidateCache) put(key string, cands []discovery.Candidate, now time.Time) error {
+	if c == nil {
+		return nil
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	b, err := json.Marshal(candidateCacheEntry{SavedAt: now, Candidates: cands})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path(key), b, 0o644); err != nil {
+		return err
+	}
+
+	entries, _ := os.ReadDir(c.Dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && now.Sub(info.ModTime()) > c.TTL {
+			os.Remove(filepath.Join(c.Dir, e.Name()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"newscheck/internal/geo"
+)
+
+// subcommands are non-interactive maintenance commands: `newscheck <name> [flags]`.
+var subcommands = map[string]func(args []string) error{
+	"download-countries": runDownloadCountries,
+	"serve":              runServe,
+	"validate-data":      runValidateData,
+	"rebuild-cache":      runRebuildCache,
+	"monitor":            runMonitor,
+	"compare":            runCompare,
+	"noise":              runNoise,
+}
+
+// runDownloadCountries saves the full RestCountries dataset for offline resolution.
+func runDownloadCountries(args []string) error {
+	fs := flag.NewFlagSet("download-countries", flag.ContinueOnError)
+	out := fs.String("out", geo.DefaultRestCountriesDumpPath, "where to save the dump")
+	base := fs.String("base-url", os.Getenv(geo.RestCountriesBaseURLEnv), "RestCountries base URL (default: public API)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	n, err := geo.DownloadRestCountriesDump(context.Background(), *base, *out)
+	if err != nil {
+		return fmt.Errorf("downloading countries: %w", err)
+	}
+	fmt.Printf("Saved %d countries to %s\n", n, *out)
+	return nil
+}
+
+// runValidateData checks the country datasets and fails if any entry is invalid.
+func runValidateData(args []string) error {
+	fs := flag.NewFlagSet("validate-data", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"data/country_languages.json", "data/country_auto_cache.json"}
+	}
+
+	bad := 0
+	for _, p := range paths {
+		if _, err := os.Stat(p); os.IsNotExist(err) && fs.NArg() == 0 {
+			continue // the auto cache only exists after a first API lookup
+		}
+		problems := geo.ValidateDataset(p)
+		for _, pr := range problems {
+			fmt.Printf("%s: %s\n", p, pr)
+		}
+		if len(problems) == 0 {
+			fmt.Printf("%s: ok\n", p)
+		}
+		bad += len(problems)
+	}
+	if bad > 0 {
+		return fmt.Errorf("%d dataset problem%s found", bad, plural(bad))
+	}
+	return nil
+}
+
+// runRebuildCache re-resolves the auto-cache from the RestCountries API,
+// dropping entries the API no longer knows.
+func runRebuildCache(args []string) error {
+	fs := flag.NewFlagSet("rebuild-cache", flag.ContinueOnError)
+	path := fs.String("cache", "data/country_auto_cache.json", "auto-cache file to rebuild")
+	base := fs.String("base-url", os.Getenv(geo.RestCountriesBaseURLEnv), "RestCountries base URL (default: public API)")
+	delay := fs.Duration("delay", 500*time.Millisecond, "pause between API calls")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := geo.NewAutoCacheStore(*path)
+	if err != nil {
+		return err
+	}
+	api := geo.NewRestCountriesResolver(geo.WithBaseURL(*base))
+
+	// Names given on the command line are re-resolved; other entries stay
+	rep, err := geo.RebuildAutoCache(context.Background(), store, api, fs.Args(), *delay)
+	if err != nil {
+		return fmt.Errorf("rebuilding cache: %w", err)
+	}
+
+	for _, c := range rep.Changed {
+		if c.Old.ISO2 == "" {
+			fmt.Printf("added   %s: %s %v\n", c.Name, c.New.ISO2, c.New.Languages)
+			continue
+		}
+		fmt.Printf("changed %s: %s %v -> %s %v\n", c.Name, c.Old.ISO2, c.Old.Languages, c.New.ISO2, c.New.Languages)
+	}
+	for _, p := range rep.Dropped {
+		fmt.Printf("dropped %s\n", p)
+	}
+	for _, p := range rep.Invalid {
+		fmt.Printf("kept    %s (new data invalid)\n", p)
+	}
+	fmt.Printf("Rebuilt %s: %d unchanged, %d changed, %d dropped, %d invalid\n", *path, rep.Kept, len(rep.Changed), len(rep.Dropped), len(rep.Invalid))
+	return nil
+}
@@ -0,0 +1,200 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+// How a story's coverage changed between two windows.
+const (
+	StoryNew        = "new"        // only in the later window
+	StoryContinuing = "continuing" // in both
+	StoryDropped    = "dropped"    // only in the earlier window
+)
+
+// ComparedStory is one same-story cluster across both windows.
+type ComparedStory struct {
+	Status string `json:"status"`
+	// Most relevant member: from window B when the story is there, else A.
+	Title string `json:"title"`
+	URL   string `json:"url"`
+
+	// Candidates and distinct publishers per window.
+	ArticlesA   int `json:"articles_a"`
+	ArticlesB   int `json:"articles_b"`
+	PublishersA int `json:"publishers_a"`
+	PublishersB int `json:"publishers_b"`
+}
+
+// Comparison is coverage of one query in window A (earlier) versus window
+// B (later), aligned by same-story clusters.
+type Comparison struct {
+	Query   string    `json:"query"`
+	WindowA TimeRange `json:"window_a"`
+	WindowB TimeRange `json:"window_b"`
+
+	New        []ComparedStory `json:"new"`
+	Continuing []ComparedStory `json:"continuing"`
+	Dropped    []ComparedStory `json:"dropped"`
+
+	// Failed discovery calls of both runs.
+	SourceErrors []SourceError `json:"source_errors,omitempty"`
+}
+
+// CompareWindows runs req's search over windows a and b (a usually the
+// earlier one) and lines up their stories with the consensus clustering:
+// a cluster with candidates from both windows is continuing, one with only
+// b's is new, one with only a's dropped.
+func (s *Service) CompareWindows(ctx context.Context, req SearchRequest, a, b TimeRange) (*Comparison, error) {
+	req.From, req.To = a.From, a.To
+	resA, err := s.Search(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("window A: %w", err)
+	}
+	req.From, req.To = b.From, b.To
+	resB, err := s.Search(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("window B: %w", err)
+	}
+
+	cmp := compareCandidates(resA.Candidates, resB.Candidates, s.Consensus)
+	cmp.Query, cmp.WindowA, cmp.WindowB = req.Query, a, b
+	cmp.SourceErrors = append(resA.SourceErrors, resB.SourceErrors...)
+	return cmp, nil
+}
+
+// compareCandidates clusters a's and b's candidates together (b first, so
+// a story present in both is represented by its later coverage) and sorts
+// the clusters into new, continuing and dropped, each largest first.
+func compareCandidates(a, b []discovery.Candidate, cfg ConsensusConfig) *Comparison {
+	all := append(append([]discovery.Candidate(nil), b...), a...)
+	cmp := &Comparison{}
+	for _, cluster := range consensusClusters(all, cfg) {
+		st := ComparedStory{Title: all[cluster[0]].Title, URL: all[cluster[0]].URL}
+		pubsA, pubsB := map[string]struct{}{}, map[string]struct{}{}
+		for _, i := range cluster {
+			if i < len(b) {
+				st.ArticlesB++
+				pubsB[candidatePublisher(all[i])] = struct{}{}
+			} else {
+				st.ArticlesA++
+				pubsA[candidatePublisher(all[i])] = struct{}{}
+			}
+		}
+		st.PublishersA, st.PublishersB = len(pubsA), len(pubsB)
+
+		switch {
+		case st.ArticlesA > 0 && st.ArticlesB > 0:
+			st.Status = StoryContinuing
+			cmp.Continuing = append(cmp.Continuing, st)
+		case st.ArticlesB > 0:
+			st.Status = StoryNew
+			cmp.New = append(cmp.New, st)
+		default:
+			st.Status = StoryDropped
+			cmp.Dropped = append(cmp.Dropped, st)
+		}
+	}
+	return cmp
+}
+
+// Markdown renders the comparison report.
+func (c *Comparison) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Coverage comparison: %s\n\n", c.Query)
+	fmt.Fprintf(&b, "- Window A: %s\n", windowLabel(c.WindowA))
+	fmt.Fprintf(&b, "- Window B: %s\n\n", windowLabel(c.WindowB))
+	fmt.Fprintf(&b, "%d new, %d continuing, %d dropped stories.\n", len(c.New), len(c.Continuing), len(c.Dropped))
+
+	section := func(title string, stories []ComparedStory) {
+		fmt.Fprintf(&b, "\n## %s (%d)\n\n", title, len(stories))
+		if len(stories) == 0 {
+			b.WriteString("None.\n")
+			return
+		}
+		for _, st := range stories {
+			fmt.Fprintf(&b, "- [%s](%s) (articles/publishers A: %d/%d, B: %d/%d)\n",
+				st.Title, st.URL, st.ArticlesA, st.PublishersA, st.ArticlesB, st.PublishersB)
+		}
+	}
+	section("New in B", c.New)
+	section("Continuing", c.Continuing)
+	section("Dropped since A", c.Dropped)
+
+	if len(c.SourceErrors) > 0 {
+		fmt.Fprintf(&b, "\n%d discovery call(s) failed; the comparison may be incomplete.\n", len(c.SourceErrors))
+	}
+	return b.String()
+}
+
+func windowLabel(tr TimeRange) string {
+	s := tr.From.Format("2006-01-02 15:04") + " → " + tr.To.Format("2006-01-02 15:04")
+	if tr.Label != "" {
+		s = tr.Label + " (" + s + ")"
+	}
+	return s
+}
+
+// runCompare is `newscheck compare`: the query's coverage over the last
+// -days versus the -days before, written as a Markdown report (plus JSON).
+func runCompare(args []string) error {
+	fset := flag.NewFlagSet("compare", flag.ContinueOnError)
+	query := fset.String("query", "", "query to compare")
+	days := fset.Int("days", 7, "length of each window in days: B = the last N days, A = the N days before")
+	scope := fset.String("scope", "auto", "auto, global or a country name")
+	outDir := fset.String("out-dir", "reports", "where the comparison report is written")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if ok, reason := validateQuery(*query); !ok {
+		return fmt.Errorf("invalid -query (%s)", reason)
+	}
+	if *days < 1 {
+		return fmt.Errorf("-days must be at least 1")
+	}
+
+	svc, err := NewService()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	span := time.Duration(*days) * 24 * time.Hour
+	b := TimeRange{From: now.Add(-span), To: now, Label: fmt.Sprintf("last %d days", *days)}
+	a := TimeRange{From: b.From.Add(-span), To: b.From, Label: fmt.Sprintf("previous %d days", *days)}
+
+	req := SearchRequest{Query: *query, PivotLang: "en"}
+	req.Scope, req.ChosenCountry = scopeFromString(*scope)
+	cmp, err := svc.CompareWindows(context.Background(), req, a, b)
+	if err != nil {
+		return err
+	}
+	printSourceErrors(os.Stdout, cmp.SourceErrors)
+	fmt.Printf("%d new, %d continuing, %d dropped stories\n", len(cmp.New), len(cmp.Continuing), len(cmp.Dropped))
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		return err
+	}
+	path := uniqueReportPathExt(*outDir, "compare_"+slugify(*query), ".md", now)
+	if err := os.WriteFile(path, []byte(cmp.Markdown()), 0o644); err != nil {
+		return err
+	}
+	js, err := json.MarshalIndent(cmp, "", "  ")
+	if err != nil {
+		return err
+	}
+	jsonPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+	if err := os.WriteFile(jsonPath, js, 0o644); err != nil {
+		return err
+	}
+	fmt.Println("Saved:", path)
+	fmt.Println("Saved:", jsonPath)
+	return nil
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"newscheck/internal/discovery"
+)
+
+func TestCompareCandidates(t *testing.T) {
+	a := []discovery.Candidate{
+		direct("https://www.reuters.com/antwerp", "Port strike paralyses Antwerp harbour"),
+		direct("https://www.bbc.co.uk/antwerp", "Antwerp harbour port strike enters second day"),
+		direct("https://example.com/floods", "Floods hit southern Brazil"),
+	}
+	b := []discovery.Candidate{
+		direct("https://www.lemonde.fr/antwerp", "Port strike in Antwerp harbour ends"),
+		direct("https://www.reuters.com/election", "Chile votes in runoff election"),
+		direct("https://www.bbc.co.uk/election", "Chile runoff election votes counted"),
+	}
+	cmp := compareCandidates(a, b, DefaultConsensusConfig())
+
+	check := func(status string, got []ComparedStory, want []ComparedStory) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d stories %+v, want %d", status, len(got), got, len(want))
+		}
+		for i, w := range want {
+			if got[i] != w {
+				t.Errorf("%s story %d = %+v, want %+v", status, i, got[i], w)
+			}
+		}
+	}
+	// A story in both windows is represented by its later coverage
+	check(StoryContinuing, cmp.Continuing, []ComparedStory{{
+		Status: StoryContinuing, Title: b[0].Title, URL: b[0].URL,
+		ArticlesA: 2, ArticlesB: 1, PublishersA: 2, PublishersB: 1,
+	}})
+	check(StoryNew, cmp.New, []ComparedStory{{
+		Status: StoryNew, Title: b[1].Title, URL: b[1].URL,
+		ArticlesB: 2, PublishersB: 2,
+	}})
+	check(StoryDropped, cmp.Dropped, []ComparedStory{{
+		Status: StoryDropped, Title: a[2].Title, URL: a[2].URL,
+		ArticlesA: 1, PublishersA: 1,
+	}})
+
+	md := cmp.Markdown()
+	if !strings.Contains(md, "1 new, 1 continuing, 1 dropped stories.") {
+		t.Errorf("Markdown summary missing:\n%s", md)
+	}
+}
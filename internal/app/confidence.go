@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultMinCountryConfidence is the confidence an auto-detected country
+// needs to drive the search targets.
+const DefaultMinCountryConfidence = 0.5
+
+// Base confidence per detection mechanism (see autoDetectCountries), before
+// context adjustments.
+const (
+	matcherConfidence = 0.7 // dataset phrase/alias or intent lexicon hit
+	hintConfidence    = 0.5 // capitalized token the resolver accepted
+)
+
+// Context adjustments: "in Jordan" reads as a place, "Michael Jordan" as
+// part of a longer proper name.
+const (
+	locationCueBonus  = 0.3
+	properNamePenalty = 0.4
+)
+
+// CountryGuess is an auto-detected country with how sure detection is of it
+// (0..1).
+type CountryGuess struct {
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+}
+
+// locationCues are words that, right before a name, mark it as a place.
+var locationCues = map[string]bool{
+	"in": true, "from": true, "to": true, "into": true, "across": true,
+	"inside": true, "within": true, "near": true, "at": true, "of": true,
+	"against": true, "between": true, "throughout": true,
+	// French/Spanish/German/Portuguese/Italian
+	"en": true, "au": true, "aux": true, "dans": true, "de": true,
+	"del": true, "desde": true, "im": true, "aus": true, "nach": true,
+	"em": true, "no": true, "na": true, "do": true, "da": true, "nel": true,
+}
+
+var reQueryWord = regexp.MustCompile(`[\p{L}\p{M}][\p{L}\p{M}'’-]*`)
+
+// scoreCountryGuesses adjusts each guess's confidence from where its name
+// sits in query: up after a location cue, down when it is glued to other
+// capitalized words that aren't themselves detected countries. In a title-
+// cased or all-caps query ("France Pension Strikes") capitals say little, so
+// only a capitalized word before the name counts ("Michael Jordan"), short
+// function words like "The" aside. Guesses
+// whose name doesn't appear verbatim (alias or demonym hits) keep their
+// base.
+func scoreCountryGuesses(query string, guesses []CountryGuess) {
+	words := reQueryWord.FindAllString(query, -1)
+	headline := isHeadlineCase(words)
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+
+	// Word positions covered by any detected country name
+	country := make([]bool, len(words))
+	spans := make([][2]int, len(guesses))
+	for g := range guesses {
+		spans[g] = [2]int{-1, -1}
+		name := strings.Fields(strings.ToLower(guesses[g].Name))
+		if i := indexWords(lower, name); i >= 0 {
+			spans[g] = [2]int{i, i + len(name)}
+			for k := i; k < i+len(name); k++ {
+				country[k] = true
+			}
+		}
+	}
+
+	for g := range guesses {
+		start, end := spans[g][0], spans[g][1]
+		if start < 0 {
+			continue
+		}
+		c := guesses[g].Confidence
+		if start > 0 && locationCues[lower[start-1]] {
+			c += locationCueBonus
+		} else if (start > 0 && isCapitalized(words[start-1]) && !country[start-1] && !(headline && isShortWord(words[start-1]))) ||
+			(!headline && end < len(words) && isCapitalized(words[end]) && !country[end]) {
+			c -= properNamePenalty
+		}
+		guesses[g].Confidence = math.Round(clamp01(c)*100) / 100
+	}
+}
+
+// indexWords returns the position of needle as consecutive words in
+// haystack, or -1.
+func indexWords(haystack, needle []string) int {
+	if len(needle) == 0 {
+		return -1
+	}
+outer:
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		for k, w := range needle {
+			if haystack[i+k] != w {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// isHeadlineCase reports whether every word of several, short function
+// words ("of", "in") aside, is capitalized: title case or all caps.
+func isHeadlineCase(words []string) bool {
+	if len(words) < 2 {
+		return false
+	}
+	for _, w := range words {
+		if !isShortWord(w) && !isCapitalized(w) {
+			return false
+		}
+	}
+	return true
+}
+
+// isShortWord: three letters or fewer, mostly articles and prepositions.
+func isShortWord(w string) bool {
+	return len([]rune(w)) <= 3
+}
+
+func isCapitalized(w string) bool {
+	for _, r := range w {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func clamp01(f float64) float64 {
+	if f < 0 {
+		return 0
+	}
+	if f > 1 {
+		return 1
+	}
+	return f
+}
+
+// confidentCountries splits guesses at min (<= 0: DefaultMinCountryConfidence)
+// and explains any that were set aside.
+func confidentCountries(guesses []CountryGuess, min float64) (names []string, note string) {
+	if min <= 0 {
+		min = DefaultMinCountryConfidence
+	}
+	var dropped []string
+	for _, g := range guesses {
+		if g.Confidence >= min {
+			names = append(names, g.Name)
+			continue
+		}
+		dropped = append(dropped, fmt.Sprintf("%s (%.2f)", g.Name, g.Confidence))
+	}
+	if len(dropped) == 0 {
+		return names, ""
+	}
+	note = fmt.Sprintf("ignored country guesses below confidence %.2f: %s", min, strings.Join(dropped, ", "))
+	if len(names) == 0 {
+		note += "; searching globally"
+	}
+	return names, note
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"newscheck/internal/geo"
+)
+
+func TestScoreCountryGuesses(t *testing.T) {
+	tests := []struct {
+		query string
+		names []string
+		base  float64
+		want  []float64
+	}{
+		{"protests in Jordan", []string{"Jordan"}, matcherConfidence, []float64{1}},
+		{"Michael Jordan stats", []string{"Jordan"}, matcherConfidence, []float64{0.3}},
+		{"Michael Jordan", []string{"Jordan"}, matcherConfidence, []float64{0.3}},
+		{"Jordan Peterson lecture", []string{"Jordan"}, matcherConfidence, []float64{0.3}},
+		{"Ukraine Russia War", []string{"Ukraine", "Russia"}, matcherConfidence, []float64{0.7, 0.7}},
+		{"France Pension Strikes", []string{"France"}, matcherConfidence, []float64{0.7}},
+		{"FRANCE PENSION STRIKES", []string{"France"}, matcherConfidence, []float64{0.7}},
+		{"Elections In The United States", []string{"United States"}, hintConfidence, []float64{0.5}},
+		{"wildfires Greece", []string{"Greece"}, hintConfidence, []float64{0.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			guesses := make([]CountryGuess, len(tt.names))
+			for i, n := range tt.names {
+				guesses[i] = CountryGuess{Name: n, Confidence: tt.base}
+			}
+			scoreCountryGuesses(tt.query, guesses)
+			for i, g := range guesses {
+				if g.Confidence != tt.want[i] {
+					t.Errorf("%s confidence = %.2f, want %.2f", g.Name, g.Confidence, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAutoDetectCountriesUnion(t *testing.T) {
+	resolver := mapResolver{
+		"guyana":    {Name: "Guyana", ISO2: "GY", Languages: []string{"en"}},
+		"venezuela": {Name: "Venezuela", ISO2: "VE", Languages: []string{"es"}},
+	}
+	query := "tensions between Guyana and Venezuela"
+
+	// Venezuela only from the intent lexicon, Guyana only as a resolver hint
+	got := autoDetectCountries(context.Background(), query, Intent{Countries: []string{"Venezuela"}}, nil, resolver)
+	var names []string
+	for _, g := range got {
+		names = append(names, g.Name)
+	}
+	if strings.Join(names, ",") != "Venezuela,Guyana" {
+		t.Errorf("countries = %v, want Venezuela then Guyana", names)
+	}
+}
+
+func TestAutoDetectCountriesCap(t *testing.T) {
+	resolver := mapResolver{}
+	var names []string
+	for i := range maxAutoCountries + 3 {
+		name := fmt.Sprintf("Land%c", 'A'+i)
+		resolver[strings.ToLower(name)] = geo.CountryInfo{Name: name, ISO2: fmt.Sprintf("X%c", 'A'+i), Languages: []string{"en"}}
+		names = append(names, name)
+	}
+	got := autoDetectCountries(context.Background(), "summit", Intent{Countries: names}, nil, resolver)
+	if len(got) != maxAutoCountries {
+		t.Errorf("got %d countries, want the cap of %d", len(got), maxAutoCountries)
+	}
+}
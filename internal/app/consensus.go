@@ -0,0 +1,303 @@
+package app
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/text"
+)
+
+// ConsensusThreshold labels consensus scores >= Min whose story is covered
+// by at least MinDomains distinct publisher domains (0 = no requirement), so
+// a handful of aggregators re-posting one wire story doesn't read as "High".
+type ConsensusThreshold struct {
+	Min        int
+	MinDomains int
+	Label      string
+}
+
+// Ways to count consensus.
+const (
+	// ConsensusCluster scores a candidate by the distinct publishers in its
+	// same-story cluster (other than its own), so reposts of one story on the
+	// same site don't inflate each other.
+	ConsensusCluster = "cluster"
+	// ConsensusPairwise counts every other candidate sharing enough keywords,
+	// duplicates included (the original method, kept for comparison).
+	ConsensusPairwise = "pairwise"
+)
+
+// ConsensusConfig controls how candidates are grouped into "same story"
+// coverage and how the resulting score is described in reports.
+type ConsensusConfig struct {
+	// ConsensusCluster (default) or ConsensusPairwise.
+	Method string
+	// Two titles cover the same story when they share at least this many keywords.
+	MinSharedTokens int
+	// Ascending by Min; the highest threshold reached wins.
+	Thresholds []ConsensusThreshold
+}
+
+func DefaultConsensusConfig() ConsensusConfig {
+	return ConsensusConfig{
+		Method:          ConsensusCluster,
+		MinSharedTokens: 2,
+		Thresholds: []ConsensusThreshold{
+			{Min: 0, Label: "Low"},
+			{Min: 2, Label: "Medium"},
+			{Min: 4, MinDomains: 3, Label: "High"},
+			{Min: 6, MinDomains: 4, Label: "Very High"},
+		},
+	}
+}
+
+// withDefaults fills unset fields so a zero ConsensusConfig behaves like the default.
+func (c ConsensusConfig) withDefaults() ConsensusConfig {
+	def := DefaultConsensusConfig()
+	if c.Method == "" {
+		c.Method = def.Method
+	}
+	if c.MinSharedTokens <= 0 {
+		c.MinSharedTokens = def.MinSharedTokens
+	}
+	if len(c.Thresholds) == 0 {
+		c.Thresholds = def.Thresholds
+	}
+	return c
+}
+
+// Label describes a consensus score using the configured thresholds.
+// domains is the number of distinct publisher domains covering the story;
+// 0 means unknown and skips the diversity requirement.
+func (c ConsensusConfig) Label(score, domains int) string {
+	c = c.withDefaults()
+	label := c.Thresholds[0].Label
+	for _, t := range c.Thresholds {
+		if score < t.Min {
+			continue
+		}
+		if domains > 0 && domains < t.MinDomains {
+			continue
+		}
+		label = t.Label
+	}
+	return label
+}
+
+// CandidateLabel is Label for a scored candidate.
+func (c ConsensusConfig) CandidateLabel(cand discovery.Candidate) string {
+	return c.Label(cand.ConsensusScore, cand.ConsensusDomains)
+}
+
+// publisherDomain returns the registrable domain of rawURL ("bbc.co.uk",
+// "reuters.com"), approximated without a public-suffix list.
+func publisherDomain(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	labels := strings.Split(strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."), ".")
+	n := 2
+	// "co.uk", "com.au", "gov.br": short second-level label under a ccTLD
+	if len(labels) >= 3 && len(labels[len(labels)-1]) == 2 && len(labels[len(labels)-2]) <= 3 {
+		n = 3
+	}
+	if len(labels) <= n {
+		return strings.Join(labels, ".")
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// calculateConsensus returns, per candidate URL, its consensus score (see
+// ConsensusConfig.Method) and how many distinct publisher domains (including
+// its own) cover its story.
+func calculateConsensus(candidates []discovery.Candidate, cfg ConsensusConfig) (scores, domains map[string]int) {
+	cfg = cfg.withDefaults()
+	if cfg.Method == ConsensusPairwise {
+		return pairwiseConsensus(candidates, cfg)
+	}
+	return clusterConsensus(candidates, cfg)
+}
+
+// clusterConsensus scores each candidate by the distinct publishers in its
+// same-story cluster, not counting its own.
+func clusterConsensus(candidates []discovery.Candidate, cfg ConsensusConfig) (scores, domains map[string]int) {
+	scores = make(map[string]int)
+	domains = make(map[string]int)
+	for _, cluster := range consensusClusters(candidates, cfg) {
+		pubs := map[string]struct{}{}
+		for _, i := range cluster {
+			pubs[candidatePublisher(candidates[i])] = struct{}{}
+		}
+		for _, i := range cluster {
+			scores[candidates[i].URL] = len(pubs) - 1
+			domains[candidates[i].URL] = len(pubs)
+		}
+	}
+	return scores, domains
+}
+
+// applyConsensus sets each candidate's ConsensusScore and ConsensusDomains
+// and returns the size of the largest same-story cluster (score + 1).
+func applyConsensus(candidates []discovery.Candidate, cfg ConsensusConfig) int {
+	scores, domains := calculateConsensus(candidates, cfg)
+	largest := 0
+	for i := range candidates {
+		candidates[i].ConsensusScore = scores[candidates[i].URL]
+		candidates[i].ConsensusDomains = domains[candidates[i].URL]
+		largest = max(largest, candidates[i].ConsensusScore+1)
+	}
+	return largest
+}
+
+// dropThinStories keeps the candidates whose ConsensusDomains (distinct
+// publishers on the story, see candidatePublisher, set by applyConsensus)
+// reaches minSources.
+func dropThinStories(candidates []discovery.Candidate, minSources int) []discovery.Candidate {
+	if minSources <= 1 {
+		return candidates
+	}
+	out := candidates[:0:0]
+	for _, c := range candidates {
+		if max(c.ConsensusDomains, 1) >= minSources {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// candidatePublisher identifies the outlet behind c: its domain, or the
+// source name when the URL has none. A Google News wrapper URL says nothing
+// about the outlet, so wrapped candidates are keyed by their publisher name
+// (discovery.NormalizeSourceName), as the outlet's domain when it is a
+// known one, so they match the outlet's direct links.
+func candidatePublisher(c discovery.Candidate) string {
+	if discovery.IsWrapperURL(c.URL) {
+		name := discovery.NormalizeSourceName(c)
+		if d, ok := discovery.KnownPublisherDomain(name); ok {
+			return d
+		}
+		if name != "" {
+			return strings.ToLower(name)
+		}
+	}
+	if d := publisherDomain(c.URL); d != "" {
+		return d
+	}
+	return strings.ToLower(strings.TrimSpace(c.Source))
+}
+
+// pairwiseConsensus counts, per candidate, every other candidate covering the
+// same story.
+func pairwiseConsensus(candidates []discovery.Candidate, cfg ConsensusConfig) (scores, domains map[string]int) {
+	scores = make(map[string]int)
+	domains = make(map[string]int)
+	if len(candidates) < 2 {
+		return scores, domains
+	}
+
+	docs := titleTokenSets(candidates)
+
+	// Compare every pair
+	for i := 0; i < len(docs); i++ {
+		seenDomains := map[string]struct{}{}
+		if d := candidatePublisher(candidates[i]); d != "" {
+			seenDomains[d] = struct{}{}
+		}
+		for j := 0; j < len(docs); j++ {
+			if i == j {
+				continue
+			}
+
+			// Threshold: if they share significant keywords, assume they cover the same topic
+			if sharedTokens(docs[i], docs[j]) >= cfg.MinSharedTokens {
+				scores[candidates[i].URL]++
+				if d := candidatePublisher(candidates[j]); d != "" {
+					seenDomains[d] = struct{}{}
+				}
+			}
+		}
+		domains[candidates[i].URL] = len(seenDomains)
+	}
+	return scores, domains
+}
+
+// titleTokenSets returns the significant title keywords of each candidate.
+func titleTokenSets(candidates []discovery.Candidate) []map[string]struct{} {
+	sets := make([]map[string]struct{}, len(candidates))
+	for i, c := range candidates {
+		set := make(map[string]struct{})
+		for _, t := range extractKeywords(text.NormalizeTitle(c.Title, c.Publisher, c.Source)) {
+			set[t] = struct{}{}
+		}
+		sets[i] = set
+	}
+	return sets
+}
+
+func sharedTokens(a, b map[string]struct{}) int {
+	n := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// consensusClusters groups candidates into same-story clusters (same rule
+// as calculateConsensus). Candidates are taken in order, so with ranked input
+// each cluster's first index is its most relevant member. Clusters are
+// returned largest first; ties keep the order of their best member.
+func consensusClusters(candidates []discovery.Candidate, cfg ConsensusConfig) [][]int {
+	cfg = cfg.withDefaults()
+	docs := titleTokenSets(candidates)
+	assigned := make([]bool, len(candidates))
+
+	var clusters [][]int
+	for i := range candidates {
+		if assigned[i] {
+			continue
+		}
+		assigned[i] = true
+		cluster := []int{i}
+		for j := i + 1; j < len(candidates); j++ {
+			if !assigned[j] && sharedTokens(docs[i], docs[j]) >= cfg.MinSharedTokens {
+				assigned[j] = true
+				cluster = append(cluster, j)
+			}
+		}
+		clusters = append(clusters, cluster)
+	}
+	sort.SliceStable(clusters, func(a, b int) bool {
+		return len(clusters[a]) > len(clusters[b])
+	})
+	return clusters
+}
+
+// clusterRepresentatives picks up to n candidate indices, one per cluster
+// from the largest clusters down, so extraction covers n distinct stories
+// instead of n copies of the top one. Each cluster is represented by its most
+// relevant member or, with preferDirect, its most relevant member that isn't
+// a Google wrapper URL (if any).
+func clusterRepresentatives(candidates []discovery.Candidate, cfg ConsensusConfig, n int, preferDirect bool) []int {
+	var out []int
+	for _, c := range consensusClusters(candidates, cfg) {
+		if len(out) >= n {
+			break
+		}
+		pick := c[0]
+		if preferDirect {
+			for _, i := range c {
+				if !discovery.IsWrapperURL(candidates[i].URL) {
+					pick = i
+					break
+				}
+			}
+		}
+		out = append(out, pick)
+	}
+	return out
+}
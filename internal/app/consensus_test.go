@@ -0,0 +1,221 @@
+package app
+
+import (
+	"testing"
+
+	"newscheck/internal/discovery"
+)
+
+// wrapped is a Google News candidate that kept its wrapper URL.
+func wrapped(id, title, publisher string) discovery.Candidate {
+	return discovery.Candidate{
+		Title:     title + " - " + publisher,
+		URL:       "https://news.google.com/rss/articles/" + id,
+		Source:    "Google News RSS (en)",
+		Publisher: publisher,
+	}
+}
+
+func direct(url, title string) discovery.Candidate {
+	return discovery.Candidate{Title: title, URL: url}
+}
+
+func TestCandidatePublisher(t *testing.T) {
+	tests := []struct {
+		name string
+		c    discovery.Candidate
+		want string
+	}{
+		{"direct link", direct("https://www.reuters.com/world/x", "t"), "reuters.com"},
+		{"ccTLD", direct("https://www.bbc.co.uk/news/x", "t"), "bbc.co.uk"},
+		{"wrapper of a known outlet", wrapped("a", "Port strike", "Reuters"), "reuters.com"},
+		{"wrapper of another outlet", wrapped("b", "Port strike", "Ouest-France"), "ouest-france"},
+		{"wrapper named by its title only", discovery.Candidate{Title: "Port strike - Le Monde", URL: "https://news.google.com/rss/articles/c", Source: "Google News RSS (fr)"}, "lemonde.fr"},
+		{"no URL", discovery.Candidate{Title: "t", Source: "Some Feed"}, "some feed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := candidatePublisher(tt.c); got != tt.want {
+				t.Errorf("candidatePublisher = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsensusMethods(t *testing.T) {
+	// One story from three outlets, four links (Reuters both wrapped and
+	// direct), plus an unrelated story.
+	cands := []discovery.Candidate{
+		wrapped("1", "Port strike paralyses Antwerp harbour", "Reuters"),
+		wrapped("2", "Antwerp harbour port strike enters second day", "BBC"),
+		wrapped("3", "Port strike in Antwerp harbour", "Le Monde"),
+		direct("https://www.reuters.com/world/antwerp", "Port strike paralyses Antwerp harbour"),
+		direct("https://example.com/floods", "Floods hit southern Brazil"),
+	}
+	story := []string{cands[0].URL, cands[1].URL, cands[2].URL, cands[3].URL}
+	other := cands[4].URL
+
+	tests := []struct {
+		method                      string
+		wantStory, wantOther        int // scores
+		wantStoryDoms, wantOtherDom int
+	}{
+		// distinct other publishers in the cluster: 3 outlets - own
+		{ConsensusCluster, 2, 0, 3, 1},
+		// every other matching candidate, Reuters' two links included
+		{ConsensusPairwise, 3, 0, 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			cfg := DefaultConsensusConfig()
+			cfg.Method = tt.method
+			scores, domains := calculateConsensus(cands, cfg)
+			for _, u := range story {
+				if scores[u] != tt.wantStory || domains[u] != tt.wantStoryDoms {
+					t.Errorf("%s: score %d, domains %d; want %d, %d", u, scores[u], domains[u], tt.wantStory, tt.wantStoryDoms)
+				}
+			}
+			if scores[other] != tt.wantOther || domains[other] != tt.wantOtherDom {
+				t.Errorf("%s: score %d, domains %d; want %d, %d", other, scores[other], domains[other], tt.wantOther, tt.wantOtherDom)
+			}
+		})
+	}
+}
+
+func TestConsensusLabel(t *testing.T) {
+	cfg := DefaultConsensusConfig()
+	tests := []struct {
+		name           string
+		score, domains int
+		want           string
+	}{
+		{"none", 0, 1, "Low"},
+		{"medium", 2, 2, "Medium"},
+		{"high", 4, 3, "High"},
+		{"high score, too few domains", 5, 2, "Medium"},
+		{"very high", 6, 4, "Very High"},
+		{"very high score, high diversity only", 7, 3, "High"},
+		{"unknown domains skip the gate", 6, 0, "Very High"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.Label(tt.score, tt.domains); got != tt.want {
+				t.Errorf("Label(%d, %d) = %q, want %q", tt.score, tt.domains, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsensusLabelWrappedOutlets(t *testing.T) {
+	// Five outlets, all reached through Google News wrappers
+	var cands []discovery.Candidate
+	for i, pub := range []string{"Reuters", "BBC", "Le Monde", "CNN", "Al Jazeera"} {
+		cands = append(cands, wrapped(string(rune('a'+i)), "Port strike paralyses Antwerp harbour", pub))
+	}
+	// and one outlet reposted by an aggregator five times
+	for i := range 5 {
+		cands = append(cands, direct("https://aggregator.example/"+string(rune('a'+i)), "Floods hit southern Brazil towns"))
+	}
+	cfg := DefaultConsensusConfig()
+	cfg.Method = ConsensusPairwise
+	scores, domains := calculateConsensus(cands, cfg)
+	for _, c := range cands {
+		c.ConsensusScore, c.ConsensusDomains = scores[c.URL], domains[c.URL]
+		want := "High"
+		if c.ConsensusDomains == 1 {
+			want = "Medium" // 4 reposts of one site don't make it High
+		}
+		if got := cfg.CandidateLabel(c); got != want {
+			t.Errorf("%s: label %q (score %d, domains %d), want %q", c.URL, got, c.ConsensusScore, c.ConsensusDomains, want)
+		}
+	}
+}
+
+func TestDropThinStories(t *testing.T) {
+	tests := []struct {
+		name       string
+		cands      []discovery.Candidate
+		minSources int
+		want       int // candidates kept
+	}{
+		{
+			name: "single-source story dropped",
+			cands: []discovery.Candidate{
+				direct("https://example.com/a", "Port strike paralyses Antwerp harbour"),
+				direct("https://example.com/b", "Antwerp harbour port strike continues"),
+			},
+			minSources: 2,
+			want:       0,
+		},
+		{
+			name: "wrapped story from several outlets survives",
+			cands: []discovery.Candidate{
+				wrapped("1", "Port strike paralyses Antwerp harbour", "Reuters"),
+				wrapped("2", "Antwerp harbour port strike continues", "BBC"),
+			},
+			minSources: 2,
+			want:       2,
+		},
+		{
+			name: "one outlet wrapped twice is still one source",
+			cands: []discovery.Candidate{
+				wrapped("1", "Port strike paralyses Antwerp harbour", "Reuters"),
+				wrapped("2", "Antwerp harbour port strike continues", "Reuters"),
+			},
+			minSources: 2,
+			want:       0,
+		},
+		{
+			name:       "min 1 keeps everything",
+			cands:      []discovery.Candidate{direct("https://example.com/a", "Port strike paralyses Antwerp harbour")},
+			minSources: 1,
+			want:       1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyConsensus(tt.cands, DefaultConsensusConfig())
+			if got := dropThinStories(tt.cands, tt.minSources); len(got) != tt.want {
+				t.Errorf("kept %d, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestPickTopByCluster(t *testing.T) {
+	// Sorted by relevance: three copies of one story, then two other stories
+	cands := []discovery.Candidate{
+		wrapped("1", "Port strike paralyses Antwerp harbour", "Reuters"),
+		wrapped("2", "Antwerp harbour port strike paralyses shipping", "BBC"),
+		direct("https://www.dw.com/port-strike", "Port strike paralyses Antwerp harbour shipping"),
+		direct("https://www.lemonde.fr/pension", "Pension reform vote delayed in French parliament"),
+		direct("https://www.elpais.com/drought", "Drought forces water restrictions across Catalonia"),
+	}
+	cfg := DefaultConsensusConfig()
+
+	if got := pickTop(cands, ExtractByRelevance, cfg, 3); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Fatalf("by relevance = %v, want the three copies [0 1 2]", got)
+	}
+
+	got := pickTop(cands, ExtractByCluster, cfg, 3)
+	if len(got) != 3 || got[0] != 0 {
+		t.Fatalf("by cluster = %v, want 3 picks led by the best copy of the big story", got)
+	}
+	seen := map[int]bool{}
+	for _, i := range got {
+		if (i == 1 || i == 2) || seen[i] {
+			t.Errorf("by cluster = %v, want one pick per story", got)
+		}
+		seen[i] = true
+	}
+	if !seen[3] || !seen[4] {
+		t.Errorf("by cluster = %v, want the two other stories too", got)
+	}
+
+	if got := pickTop(cands, ExtractByClusterDirect, cfg, 1); len(got) != 1 || got[0] != 2 {
+		t.Errorf("cluster-direct = %v, want [2], the direct link of the big story", got)
+	}
+	if got := pickTop(cands, ExtractByCluster, cfg, 10); len(got) != 3 {
+		t.Errorf("by cluster with n > clusters = %v, want one per cluster", got)
+	}
+}
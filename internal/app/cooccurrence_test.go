@@ -0,0 +1,33 @@
+package app
+
+import (
+	"testing"
+
+	"newscheck/internal/text"
+)
+
+func TestTermsNearCountry(t *testing.T) {
+	tests := []struct {
+		name, title string
+		terms       []string
+		countries   []string
+		stems       bool
+		want        bool
+	}{
+		{"adjacent", "France pension strikes", []string{"pension"}, []string{"france"}, false, true},
+		{"within window", "Pension protests spread in France", []string{"pension"}, []string{"france"}, false, true},
+		{"beyond window", "Pension reform sparks fresh nationwide protests across cities of France", []string{"pension"}, []string{"france"}, false, false},
+		{"multi-word country", "Tariffs imposed by the United States", []string{"tariffs"}, []string{"united states"}, false, true},
+		{"country missing", "Pension reform protests", []string{"pension"}, []string{"france"}, false, false},
+		{"stem needed", "France votes today", []string{"voting"}, []string{"france"}, false, false},
+		{"stem match", "France votes today", []string{"voting"}, []string{"france"}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toks := text.Tokenize(tt.title)
+			if got := termsNearCountry(toks, tt.terms, tt.countries, cooccurrenceWindow, tt.stems); got != tt.want {
+				t.Errorf("termsNearCountry(%q) = %v, want %v", tt.title, got, tt.want)
+			}
+		})
+	}
+}
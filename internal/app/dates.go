@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+)
+
+// articleDateLayouts are the publish-date formats the extraction worker is
+// seen to return.
+var articleDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// parseArticleDate reads a worker date; one without a zone is taken as UTC.
+func parseArticleDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range articleDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// DayRange turns inclusive YYYY-MM-DD bounds into a search window: from
+// 00:00 on the first day to the end of the last (the next midnight minus
+// 1ns), as days in loc (nil = UTC), returned in UTC. Candidate dates are
+// compared as instants, so an item stamped 23:30-05:00 on the last day is
+// out of a UTC range (04:30 UTC the day after) whatever zone its feed used.
+func DayRange(fromStr, toStr string, loc *time.Location) (time.Time, time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	from, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(fromStr), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %w", err)
+	}
+	to, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(toStr), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %w", err)
+	}
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from date must not be after to date")
+	}
+	// AddDate, not 24h, so a DST change on the last day is still a full day
+	end := to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	return from.UTC(), end.UTC(), nil
+}
+
+// backfillPublishedAt gives undated candidates the publish date found by
+// extraction (matched on canonical URL, original or final). Returns how many
+// candidates got a date.
+func backfillPublishedAt(candidates []discovery.Candidate, articles []extract.Article) int {
+	dates := map[string]time.Time{}
+	for _, a := range articles {
+		if a.PublishedAt == nil {
+			continue
+		}
+		t, ok := parseArticleDate(*a.PublishedAt)
+		if !ok {
+			continue
+		}
+		dates[discovery.CanonicalizeURL(a.URL)] = t
+		if a.FinalURL != "" {
+			dates[discovery.CanonicalizeURL(a.FinalURL)] = t
+		}
+	}
+
+	n := 0
+	for i := range candidates {
+		if !candidates[i].Undated {
+			continue
+		}
+		if t, ok := dates[discovery.CanonicalizeURL(candidates[i].URL)]; ok {
+			candidates[i].PublishedAt = t
+			candidates[i].Undated = false
+			n++
+		}
+	}
+	return n
+}
+
+// candidateDate formats a candidate's publish date for listings.
+func candidateDate(c discovery.Candidate) string {
+	if c.Undated {
+		return "undated"
+	}
+	return c.PublishedAt.Format(time.RFC3339)
+}
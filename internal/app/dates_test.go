@@ -0,0 +1,88 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+)
+
+func TestDayRange(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	tests := []struct {
+		name, from, to   string
+		loc              *time.Location
+		wantFrom, wantTo string
+		wantErr          bool
+	}{
+		{"utc single day", "2026-03-01", "2026-03-01", nil, "2026-03-01T00:00:00Z", "2026-03-01T23:59:59.999999999Z", false},
+		{"utc range", "2026-03-01", "2026-03-03", time.UTC, "2026-03-01T00:00:00Z", "2026-03-03T23:59:59.999999999Z", false},
+		{"paris days in utc", "2026-03-01", "2026-03-01", paris, "2026-02-28T23:00:00Z", "2026-03-01T22:59:59.999999999Z", false},
+		{"dst change on the last day", "2026-03-29", "2026-03-29", paris, "2026-03-28T23:00:00Z", "2026-03-29T21:59:59.999999999Z", false},
+		{"from after to", "2026-03-03", "2026-03-01", nil, "", "", true},
+		{"bad date", "2026-13-01", "2026-03-01", nil, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := DayRange(tt.from, tt.to, tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := from.Format(time.RFC3339Nano); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := to.Format(time.RFC3339Nano); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestUndatedCandidateGetsExtractionDate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	recent := time.Now().Add(-48 * time.Hour)
+	cands := []discovery.Candidate{
+		{Title: "Port strike spreads", URL: "https://a.example/1?utm_source=rss", Undated: true},
+		{Title: "Port strike ends", URL: "https://b.example/2", Undated: true},
+		{Title: "Port strike talks", URL: "https://c.example/3", Undated: true},
+		{Title: "Port strike dated", URL: "https://d.example/4", PublishedAt: recent},
+	}
+	if got := candidateDate(cands[0]); got != "undated" {
+		t.Errorf("candidateDate before extraction = %q, want undated", got)
+	}
+
+	// Undated candidates aren't dropped by the age floor
+	kept := filterCandidates(cands, "port strike", Intent{}, nil, FilterOptions{FreshnessFloor: 7 * 24 * time.Hour})
+	if len(kept) != 4 {
+		t.Fatalf("kept %v, want all four (undated pass the floor)", urlsOf(kept))
+	}
+
+	arts := []extract.Article{
+		{URL: "https://a.example/1", PublishedAt: str("2026-03-02T08:00:00Z")},
+		{URL: "https://short.example/x", FinalURL: "https://b.example/2", PublishedAt: str("2026-03-02")},
+		{URL: "https://c.example/3", PublishedAt: str("last Tuesday")},
+		{URL: "https://d.example/4", PublishedAt: str("2026-03-05")},
+	}
+	if n := backfillPublishedAt(cands, arts); n != 2 {
+		t.Errorf("backfilled %d, want 2", n)
+	}
+	if cands[0].Undated || !cands[0].PublishedAt.Equal(time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("candidate 0 = %v undated %v, want 2026-03-02 08:00", cands[0].PublishedAt, cands[0].Undated)
+	}
+	if cands[1].Undated || !cands[1].PublishedAt.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("candidate 1 (matched by final URL) = %v undated %v", cands[1].PublishedAt, cands[1].Undated)
+	}
+	if !cands[2].Undated {
+		t.Error("unparseable extraction date cleared Undated")
+	}
+	if !cands[3].PublishedAt.Equal(recent) {
+		t.Error("a feed date was overwritten")
+	}
+}
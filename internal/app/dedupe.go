@@ -0,0 +1,55 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/text"
+)
+
+// DedupeStrategy decides when two candidates are the same article.
+type DedupeStrategy string
+
+const (
+	// DedupeExactURL merges only identical URLs.
+	DedupeExactURL DedupeStrategy = "exact-url"
+	// DedupeCanonicalURL merges AMP/www/tracking variants (the default).
+	DedupeCanonicalURL DedupeStrategy = "canonical-url"
+	// DedupeTitle merges identical normalized titles, whatever the site.
+	DedupeTitle DedupeStrategy = "title"
+	// DedupeTitleAndDomain merges identical titles on one publisher (see
+	// candidatePublisher), which catches one article reachable under several
+	// paths.
+	DedupeTitleAndDomain DedupeStrategy = "title-domain"
+)
+
+// ParseDedupeStrategy accepts the strategy names; "" means the default.
+func ParseDedupeStrategy(s string) (DedupeStrategy, error) {
+	switch d := DedupeStrategy(strings.ToLower(strings.TrimSpace(s))); d {
+	case "":
+		return DedupeCanonicalURL, nil
+	case DedupeExactURL, DedupeCanonicalURL, DedupeTitle, DedupeTitleAndDomain:
+		return d, nil
+	}
+	return "", fmt.Errorf("unknown dedupe strategy %q (want %s, %s, %s or %s)", s, DedupeExactURL, DedupeCanonicalURL, DedupeTitle, DedupeTitleAndDomain)
+}
+
+// key returns the identity of c under the strategy. Title keys fall back to
+// the canonical URL when the title normalizes to nothing.
+func (d DedupeStrategy) key(c discovery.Candidate) string {
+	switch d {
+	case DedupeExactURL:
+		return strings.TrimSpace(c.URL)
+	case DedupeTitle, DedupeTitleAndDomain:
+		title := strings.Join(text.Tokenize(text.NormalizeTitle(c.Title, c.Publisher, c.Source)), " ")
+		if title == "" {
+			break
+		}
+		if d == DedupeTitleAndDomain {
+			return candidatePublisher(c) + "|" + title
+		}
+		return title
+	}
+	return discovery.CanonicalizeURL(c.URL)
+}
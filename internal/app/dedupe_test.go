@@ -0,0 +1,174 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestDedupeCandidatesCounts(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cand := func(title, url string) discovery.Candidate {
+		return discovery.Candidate{Title: title, URL: url, PublishedAt: day}
+	}
+
+	tests := []struct {
+		name         string
+		strategy     DedupeStrategy
+		in           []discovery.Candidate
+		wantOut      int
+		wantMerged   int
+		wantUnusable int
+	}{
+		{
+			name:     "distinct articles",
+			strategy: DedupeCanonicalURL,
+			in:       []discovery.Candidate{cand("Pension strikes spread", "https://a.com/1"), cand("Port strike ends", "https://a.com/2")},
+			wantOut:  2,
+		},
+		{
+			name:       "tracking variant merged",
+			strategy:   DedupeCanonicalURL,
+			in:         []discovery.Candidate{cand("Pension strikes spread", "https://a.com/1"), cand("Pension strikes spread", "https://a.com/1?utm_source=x")},
+			wantOut:    1,
+			wantMerged: 1,
+		},
+		{
+			name:         "blank URL and short title are drops, not merges",
+			strategy:     DedupeCanonicalURL,
+			in:           []discovery.Candidate{cand("Pension strikes spread", "https://a.com/1"), cand("Pension strikes spread", " "), cand("Hi", "https://a.com/3")},
+			wantOut:      1,
+			wantUnusable: 2,
+		},
+		{
+			name:         "both at once",
+			strategy:     DedupeTitle,
+			in:           []discovery.Candidate{cand("Pension strikes spread", "https://a.com/1"), cand("Pension strikes spread", "https://b.com/9"), cand("", "https://c.com/1")},
+			wantOut:      1,
+			wantMerged:   1,
+			wantUnusable: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, merged, unusable := dedupeCandidates(tt.in, tt.strategy)
+			if len(out) != tt.wantOut || merged != tt.wantMerged || unusable != tt.wantUnusable {
+				t.Errorf("got %d out, %d merged, %d unusable; want %d, %d, %d",
+					len(out), merged, unusable, tt.wantOut, tt.wantMerged, tt.wantUnusable)
+			}
+		})
+	}
+}
+
+func TestDedupeCandidatesOrder(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	cand := func(title, url string, age time.Duration) discovery.Candidate {
+		return discovery.Candidate{Title: title, URL: url, PublishedAt: day.Add(-age)}
+	}
+	in := []discovery.Candidate{
+		cand("Port strike day two", "https://c.com/2", 0),
+		cand("Port strike begins", "https://b.com/1", time.Hour),
+		cand("Port strike day two", "https://a.com/2", 0),
+		cand("Dockers walk out", "https://d.com/1", time.Hour),
+		cand("Port strike begins", "https://b.com/1?utm_source=x", 2*time.Hour),
+		{Title: "Undated port strike story", URL: "https://e.com/1"},
+		{Title: "Another undated story", URL: "https://a.com/9"},
+	}
+	want := []string{"https://a.com/2", "https://c.com/2", "https://b.com/1", "https://d.com/1", "https://a.com/9", "https://e.com/1"}
+	for run := 0; run < 20; run++ {
+		out, _, _ := dedupeCandidates(in, DedupeCanonicalURL)
+		got := urlsOf(out)
+		if len(got) != len(want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("run %d: got %q, want %q", run, got, want)
+			}
+		}
+	}
+}
+
+func TestDedupeTitleAndDomain(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []discovery.Candidate
+		want int
+	}{
+		{
+			name: "same title, same site, two paths",
+			in:   []discovery.Candidate{direct("https://www.reuters.com/a", "Port strike paralyses Antwerp"), direct("https://reuters.com/world/a", "Port strike paralyses Antwerp")},
+			want: 1,
+		},
+		{
+			name: "same wire title on two sites",
+			in:   []discovery.Candidate{direct("https://www.reuters.com/a", "Port strike paralyses Antwerp"), direct("https://www.bbc.co.uk/a", "Port strike paralyses Antwerp")},
+			want: 2,
+		},
+		{
+			name: "same wire title from two outlets behind wrappers",
+			in:   []discovery.Candidate{wrapped("1", "Port strike paralyses Antwerp", "Reuters"), wrapped("2", "Port strike paralyses Antwerp", "BBC")},
+			want: 2,
+		},
+		{
+			name: "one outlet wrapped and direct",
+			in:   []discovery.Candidate{wrapped("1", "Port strike paralyses Antwerp", "Reuters"), direct("https://www.reuters.com/a", "Port strike paralyses Antwerp")},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, _, _ := dedupeCandidates(tt.in, DedupeTitleAndDomain)
+			if len(out) != tt.want {
+				t.Errorf("got %d candidates, want %d", len(out), tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeMergesProvenance(t *testing.T) {
+	old := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) // no recency boost
+	found := func(url, by string, day int) discovery.Candidate {
+		return discovery.Candidate{Title: "Port strike spreads", URL: url, FoundBy: by, PublishedAt: old.AddDate(0, 0, day)}
+	}
+	in := []discovery.Candidate{
+		found("https://a.com/1", "global | port strike", 0),
+		found("https://a.com/1?utm_source=x", "country:FR | port strike", 1),
+		found("https://a.com/1", "global | port strike", 0),
+		found("https://a.com/1", "global | dockers walk out", 0),
+		{Title: "Port strike ends early", URL: "https://b.com/1", FoundBy: "global | port strike", PublishedAt: old},
+	}
+	out, merged, _ := dedupeCandidates(in, DedupeCanonicalURL)
+	if len(out) != 2 || merged != 3 {
+		t.Fatalf("got %d out, %d merged; want 2 and 3", len(out), merged)
+	}
+	var multi, single discovery.Candidate
+	for _, c := range out {
+		if strings.HasPrefix(c.URL, "https://a.com/") {
+			multi = c
+		} else {
+			single = c
+		}
+	}
+	want := []string{"global | port strike", "country:FR | port strike", "global | dockers walk out"}
+	if !reflect.DeepEqual(multi.Provenance, want) {
+		t.Errorf("Provenance = %q, want %q", multi.Provenance, want)
+	}
+	if b, _ := json.Marshal(multi); !strings.Contains(string(b), `"provenance":[`) {
+		t.Errorf("JSON %s lacks provenance", b)
+	}
+
+	// Same title match; the story found by three plans gets 2 points per
+	// extra plan
+	ranked := filterCandidates([]discovery.Candidate{single, multi}, "port strike", Intent{}, nil, FilterOptions{})
+	if len(ranked) != 2 || ranked[0].URL != multi.URL {
+		t.Fatalf("ranked = %v, want the multi-plan story first", urlsOf(ranked))
+	}
+	if diff := ranked[0].RelevanceScore - ranked[1].RelevanceScore; diff != 2*provenanceBoost {
+		t.Errorf("score difference = %d, want %d", diff, 2*provenanceBoost)
+	}
+}
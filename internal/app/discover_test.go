@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+// fakeSource records every Discover call ("GL/lang|query") and its limit,
+// and answers with results, if set.
+type fakeSource struct {
+	mu      sync.Mutex
+	calls   []string
+	limits  map[string]int // query -> limit
+	results func(p discovery.Plan, lang discovery.LanguageProfile) ([]discovery.Candidate, error)
+}
+
+func (f *fakeSource) Name() string { return "Fake" }
+
+func (f *fakeSource) Discover(_ context.Context, p discovery.Plan, lang discovery.LanguageProfile, _, _ time.Time, limit int) ([]discovery.Candidate, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, lang.GL+"/"+lang.Code+"|"+p.Query)
+	if f.limits == nil {
+		f.limits = map[string]int{}
+	}
+	f.limits[p.Query] = limit
+	f.mu.Unlock()
+	if f.results == nil {
+		return nil, nil
+	}
+	return f.results(p, lang)
+}
+
+func lastWeek() TimeRange {
+	now := time.Now()
+	return TimeRange{From: now.AddDate(0, 0, -7), To: now}
+}
+
+func TestGlobalPlansRunOnce(t *testing.T) {
+	targets := []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}, {ISO2: "BE", Lang: "fr"}, {ISO2: "CH", Lang: "fr"}}
+	plans := []SearchPlan{
+		{Query: "port strike", Scope: "global", Weight: 1},
+		{Query: "port strike Europe", Scope: "region:Europe", Weight: 1},
+	}
+	src := &fakeSource{}
+	_, _, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), targets,
+		[]DiscoverySource{{Source: src, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := map[string]int{}
+	for _, c := range src.calls {
+		_, q, _ := strings.Cut(c, "|")
+		count[q]++
+	}
+	if count["port strike"] != 1 {
+		t.Errorf("global plan ran %d times across %d targets, want 1", count["port strike"], len(targets))
+	}
+	if count["port strike Europe"] != len(targets) {
+		t.Errorf("regional plan ran %d times, want once per target (%d)", count["port strike Europe"], len(targets))
+	}
+}
+
+func TestGlobalPlansFanOutOverAnchors(t *testing.T) {
+	targets := []geo.DiscoveryTarget{{ISO2: "US", Lang: "en", Global: true}, {ISO2: "FR", Lang: "fr", Global: true}}
+	src := &fakeSource{}
+	_, _, err := runDiscoveryWithTargets(context.Background(), []SearchPlan{{Query: "port strike", Scope: "global", Weight: 1}},
+		lastWeek(), targets, []DiscoverySource{{Source: src, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.calls) != len(targets) {
+		t.Errorf("calls = %v, want one per global anchor", src.calls)
+	}
+}
+
+func TestCollapseSharedLocalesCutsCalls(t *testing.T) {
+	resolved := []geo.CountryInfo{
+		{Name: "Germany", ISO2: "DE", Languages: []string{"de"}},
+		{Name: "France", ISO2: "FR", Languages: []string{"fr"}},
+		{Name: "Ireland", ISO2: "IE", Languages: []string{"en", "ga"}},
+	}
+	targets := []geo.DiscoveryTarget{
+		{ISO2: "DE", Lang: "de"}, {ISO2: "DE", Lang: "en"},
+		{ISO2: "FR", Lang: "fr"}, {ISO2: "FR", Lang: "en"},
+		{ISO2: "IE", Lang: "en"}, {ISO2: "IE", Lang: "ga"},
+	}
+	plans := []SearchPlan{{Query: "port strike Europe", Scope: "region:Europe", Weight: 1}}
+	run := func(targets []geo.DiscoveryTarget) []string {
+		src := &fakeSource{}
+		if _, _, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), targets,
+			[]DiscoverySource{{Source: src, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil); err != nil {
+			t.Fatal(err)
+		}
+		return src.calls
+	}
+
+	kept, dropped := collapseSharedLocales(targets, resolved, []string{"en"})
+	before, after := run(targets), run(kept)
+	if len(before)-len(after) != 1 || len(dropped) != 1 {
+		t.Fatalf("calls %d -> %d (dropped %v), want one fewer", len(before), len(after), dropped)
+	}
+	// FR/en repeats DE/en; IE/en is local English and stays
+	if d := dropped[0]; d.ISO2 != "FR" || d.Lang != "en" {
+		t.Errorf("dropped %s/%s, want FR/en", d.ISO2, d.Lang)
+	}
+	for _, c := range after {
+		if strings.HasPrefix(c, "FR/en") {
+			t.Errorf("FR/en still queried: %v", after)
+		}
+	}
+}
+
+// fakeFeeds is a LocaleSource queried once per language, not per target.
+type fakeFeeds struct{ fakeSource }
+
+func (f *fakeFeeds) Name() string { return "Fake feeds" }
+
+func (f *fakeFeeds) Locales(targets []discovery.LanguageProfile) []discovery.LanguageProfile {
+	seen := map[string]bool{}
+	var out []discovery.LanguageProfile
+	for _, t := range targets {
+		if !seen[t.Code] {
+			seen[t.Code] = true
+			out = append(out, discovery.LanguageProfile{Code: t.Code})
+		}
+	}
+	return out
+}
+
+func TestRunDiscoveryWithFakeSources(t *testing.T) {
+	targets := []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}, {ISO2: "BE", Lang: "fr"}}
+	plans := []SearchPlan{{Query: "grève port", Scope: "region:Europe", Weight: 1}}
+	found := func(prefix string) func(discovery.Plan, discovery.LanguageProfile) ([]discovery.Candidate, error) {
+		return func(p discovery.Plan, lang discovery.LanguageProfile) ([]discovery.Candidate, error) {
+			return []discovery.Candidate{
+				{Title: "Grève dans les ports français", URL: "https://" + prefix + ".example/" + lang.GL},
+				{Title: "Grève dans les ports (copie)", URL: "https://shared.example/a"},
+			}, nil
+		}
+	}
+	news := &fakeSource{results: found("news")}
+	feeds := &fakeFeeds{fakeSource{results: found("feeds")}}
+	stats := newRunStats()
+
+	got, errs, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), targets,
+		[]DiscoverySource{{Source: news, PerPlan: 10}, {Source: feeds, PerPlan: 10}}, DedupeCanonicalURL, 2, nil, stats)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("err = %v, source errors = %v", err, errs)
+	}
+	if len(news.calls) != 2 || len(feeds.calls) != 1 {
+		t.Errorf("calls: news %v, feeds %v; want one per target and one per language", news.calls, feeds.calls)
+	}
+	if stats.PerSource["Fake"] != 4 || stats.PerSource["Fake feeds"] != 2 {
+		t.Errorf("PerSource = %v", stats.PerSource)
+	}
+
+	// Every source's results merged, the shared URL once (dedupeCandidates
+	// sorts its output)
+	want := []string{"https://feeds.example/", "https://news.example/BE", "https://news.example/FR", "https://shared.example/a"}
+	if urls := urlsOf(got); strings.Join(urls, " ") != strings.Join(want, " ") {
+		t.Errorf("candidates = %v, want %v", urls, want)
+	}
+}
+
+func TestStreamingCallback(t *testing.T) {
+	targets := []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}, {ISO2: "BE", Lang: "fr"}, {ISO2: "CH", Lang: "fr"}}
+	plans := []SearchPlan{{Query: "grève port", Scope: "region:Europe", Weight: 1}}
+
+	var mu sync.Mutex
+	var batches [][]discovery.Candidate
+	src := &fakeSource{results: func(p discovery.Plan, lang discovery.LanguageProfile) ([]discovery.Candidate, error) {
+		mu.Lock()
+		seen := len(batches)
+		mu.Unlock()
+		// One call at a time: every earlier call has already streamed
+		if want := map[string]int{"FR": 0, "BE": 1, "CH": 2}[lang.GL]; seen != want {
+			t.Errorf("%s called after %d callbacks, want %d", lang.GL, seen, want)
+		}
+		return []discovery.Candidate{
+			{Title: "Grève dans le port de " + lang.GL, URL: "https://z.example/" + lang.GL},
+			{Title: "Grève dans les ports européens", URL: "https://a.example/shared?utm_source=" + lang.GL},
+		}, nil
+	}}
+	onFound := func(source, target string, fresh []discovery.Candidate) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, fresh)
+	}
+
+	got, _, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), targets,
+		[]DiscoverySource{{Source: src, PerPlan: 10}}, DedupeCanonicalURL, 1, onFound, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The shared story streams once, with the first target's batch
+	wantBatches := []int{2, 1, 1}
+	if len(batches) != len(wantBatches) {
+		t.Fatalf("%d callbacks, want %d", len(batches), len(wantBatches))
+	}
+	streamed := map[string]bool{}
+	for i, b := range batches {
+		if len(b) != wantBatches[i] {
+			t.Errorf("callback %d got %d candidates, want %d", i, len(b), wantBatches[i])
+		}
+		for _, c := range b {
+			streamed[discovery.CanonicalizeURL(c.URL)] = true
+		}
+	}
+
+	// The final list is the same set, deduped and sorted
+	urls := urlsOf(got)
+	if len(urls) != len(streamed) || !sort.StringsAreSorted(urls) {
+		t.Errorf("final = %v, want the %d streamed candidates, sorted", urls, len(streamed))
+	}
+	for _, c := range got {
+		if !streamed[discovery.CanonicalizeURL(c.URL)] {
+			t.Errorf("%s in the final list was never streamed", c.URL)
+		}
+	}
+}
+
+func TestSourceErrorsCollected(t *testing.T) {
+	targets := []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}, {ISO2: "BE", Lang: "fr"}, {ISO2: "DE", Lang: "de"}}
+	plans := []SearchPlan{{Query: "port strike", Scope: "region:Europe", Weight: 1}}
+	src := &fakeSource{results: func(p discovery.Plan, lang discovery.LanguageProfile) ([]discovery.Candidate, error) {
+		if lang.GL == "DE" {
+			return []discovery.Candidate{{Title: "Hafenstreik", URL: "https://news.example/de"}}, nil
+		}
+		return nil, errors.New("fake: HTTP 429 Too Many Requests")
+	}}
+
+	candidates, errs, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), targets,
+		[]DiscoverySource{{Source: src, PerPlan: 10}}, DedupeCanonicalURL, 2, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 1 {
+		t.Errorf("got %d candidates, want the working target's 1", len(candidates))
+	}
+
+	var got []string
+	for _, e := range errs {
+		if e.Source != "Fake" || e.Plan != "port strike" || !strings.Contains(e.Err, "429") {
+			t.Errorf("unexpected error %+v", e)
+		}
+		got = append(got, e.Target)
+	}
+	sort.Strings(got)
+	if strings.Join(got, " ") != "BE/fr FR/fr" {
+		t.Errorf("failed targets = %v, want BE/fr and FR/fr", got)
+	}
+
+	summary := summarizeSourceErrors(errs)
+	if len(summary) != 1 || summary[0] != "Fake returned HTTP 429 for 2 targets" {
+		t.Errorf("summary = %q", summary)
+	}
+}
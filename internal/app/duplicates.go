@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+	"newscheck/internal/text"
+)
+
+// maxDuplicateDistance is the largest SimHash distance (out of 64 bits) at
+// which two article bodies count as the same story, e.g. one wire report
+// republished with a different intro line.
+const maxDuplicateDistance = 3
+
+// collapseDuplicates marks near-identical article bodies so the resume
+// doesn't weigh one wire story several times. Of each story's copies the
+// one from a preferred domain is kept, then the one with the highest
+// source weight (see OrderWeights.Sources), then the first in candidate
+// order (best ranked first); the others get DuplicateOf set to its URL.
+// Low-quality articles are ignored. Returns the articles fit for the
+// resume, in input order; the input keeps every article.
+func collapseDuplicates(articles []extract.Article, weights map[string]float64, preferred []string) []extract.Article {
+	type story struct {
+		hash  uint64
+		best  int
+		score float64
+		dups  []int
+	}
+	var stories []*story
+	// Preferred outranks any source weight
+	score := func(a extract.Article) float64 {
+		c := discovery.Candidate{URL: articleLabel(a), Publisher: a.Site}
+		w := weights[candidatePublisher(c)]
+		if isPreferred(c, preferred) {
+			w += preferredBoost
+		}
+		return w
+	}
+
+	for i, a := range articles {
+		if a.LowQuality {
+			continue
+		}
+		h := text.SimHash(a.Text)
+		var st *story
+		if h != 0 {
+			for _, s := range stories {
+				if text.HammingDistance(h, s.hash) <= maxDuplicateDistance {
+					st = s
+					break
+				}
+			}
+		}
+		if st == nil {
+			stories = append(stories, &story{hash: h, best: i, score: score(a)})
+			continue
+		}
+		if sc := score(a); sc > st.score {
+			st.dups = append(st.dups, st.best)
+			st.best, st.score = i, sc
+		} else {
+			st.dups = append(st.dups, i)
+		}
+	}
+
+	for _, st := range stories {
+		kept := articleLabel(articles[st.best])
+		for _, i := range st.dups {
+			articles[i].DuplicateOf = kept
+			fmt.Fprintf(os.Stderr, "Collapsed duplicate: %s (same text as %s)\n", articleLabel(articles[i]), kept)
+		}
+	}
+	usable := make([]extract.Article, 0, len(articles))
+	for _, a := range articles {
+		if !a.LowQuality && a.DuplicateOf == "" {
+			usable = append(usable, a)
+		}
+	}
+	return usable
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"newscheck/internal/extract"
+)
+
+func TestCollapseDuplicates(t *testing.T) {
+	const wire = "Dock workers in Le Havre and Marseille walked out on Monday over the pension reform, " +
+		"halting container traffic at the country's two largest ports. Unions said the strike would " +
+		"run until Friday and warned of further action if the government pushed the bill through " +
+		"parliament without a vote. Shipping lines diverted several vessels to Antwerp and Genoa."
+	other := "The central bank left interest rates unchanged on Thursday, citing slowing inflation " +
+		"and a cooling labour market, and signalled that cuts could come later in the year."
+	art := func(url, body string) extract.Article {
+		return extract.Article{URL: url, Text: body}
+	}
+
+	tests := []struct {
+		name      string
+		weights   map[string]float64
+		preferred []string
+		wantKept  []string
+		wantDupOf string // of https://example.com/a when it is collapsed
+	}{
+		{
+			name:      "first copy kept without weights",
+			wantKept:  []string{"https://example.com/a", "https://www.bbc.com/c"},
+			wantDupOf: "",
+		},
+		{
+			name:      "heavier source kept",
+			weights:   map[string]float64{"reuters.com": 1},
+			wantKept:  []string{"https://www.reuters.com/b", "https://www.bbc.com/c"},
+			wantDupOf: "https://www.reuters.com/b",
+		},
+		{
+			name:      "preferred domain beats source weight",
+			weights:   map[string]float64{"reuters.com": 1},
+			preferred: []string{"example.com"},
+			wantKept:  []string{"https://example.com/a", "https://www.bbc.com/c"},
+			wantDupOf: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := []extract.Article{
+				art("https://example.com/a", wire),
+				art("https://www.reuters.com/b", strings.ReplaceAll(wire, ",", "")), // re-punctuated copy
+				art("https://www.bbc.com/c", other),
+			}
+			got := collapseDuplicates(in, tt.weights, tt.preferred)
+			if len(got) != len(tt.wantKept) {
+				t.Fatalf("kept %d articles, want %v", len(got), tt.wantKept)
+			}
+			for i, u := range tt.wantKept {
+				if got[i].URL != u {
+					t.Errorf("kept[%d] = %s, want %s", i, got[i].URL, u)
+				}
+			}
+			if in[0].DuplicateOf != tt.wantDupOf {
+				t.Errorf("example.com DuplicateOf = %q, want %q", in[0].DuplicateOf, tt.wantDupOf)
+			}
+			if in[2].DuplicateOf != "" {
+				t.Errorf("distinct story marked duplicate of %q", in[2].DuplicateOf)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"newscheck/internal/geo"
+)
+
+// PlanExplanation is the full query -> plans chain for one request, as
+// printed by -explain. Field order follows the pipeline so diffs of the JSON
+// read top to bottom.
+type PlanExplanation struct {
+	Query      string `json:"query"`
+	Normalized string `json:"normalized"`
+	Scope      string `json:"scope"`
+
+	Intent    Intent                `json:"intent"`
+	Countries []string              `json:"countries"` // detected or chosen names
+	Resolved  []geo.CountryInfo     `json:"resolved"`
+	Scopes    []string              `json:"scopes"`
+	Plans     []SearchPlan          `json:"plans"`
+	Targets   []geo.DiscoveryTarget `json:"targets"`
+
+	// Auto-detection guesses with their confidence, and why any were
+	// ignored (ScopeAuto only).
+	Guesses []CountryGuess `json:"guesses,omitempty"`
+	Note    string         `json:"note,omitempty"`
+
+	// Targets removed by SearchRequest.CollapseLocales.
+	Collapsed []geo.DiscoveryTarget `json:"collapsed,omitempty"`
+}
+
+// explainSearch is steps 1-4 of Service.Search: intent, country resolution,
+// targets and plans. A region with no named country ("news in the
+// Caribbean") fans out to up to maxCountriesPerRegion member countries, each
+// searched in its own locales. workers bounds concurrent country lookups.
+func explainSearch(ctx context.Context, req SearchRequest, matcher *geo.CountryMatcher, resolver geo.Resolver, regions geo.Regions, workers int) *PlanExplanation {
+	intent := ExtractIntentIn(req.Query, req.QueryLang)
+
+	var countryNames []string
+	var guesses []CountryGuess
+	var note string
+	switch req.Scope {
+	case ScopeAuto:
+		guesses = autoDetectCountries(ctx, req.Query, intent, matcher, resolver)
+		countryNames, note = confidentCountries(guesses, req.MinCountryConfidence)
+		if len(countryNames) == 0 && len(intent.Regions) > 0 {
+			countryNames = regions.Countries(intent.Regions, maxCountriesPerRegion)
+		}
+	case ScopeChosen:
+		countryNames = []string{req.ChosenCountry}
+		intent.Countries = nil
+		intent.Regions = nil
+	case ScopeGlobal:
+		countryNames = []string{}
+		intent.Countries = nil
+		intent.Regions = nil
+	}
+
+	infos := make([]geo.CountryInfo, len(countryNames))
+	forEachLimit(len(countryNames), workers, func(i int) {
+		if info, err := resolver.ResolveCountry(ctx, countryNames[i]); err == nil {
+			infos[i] = info
+		}
+	})
+	resolved := make([]geo.CountryInfo, 0, len(countryNames))
+	for _, info := range infos {
+		if info.ISO2 != "" {
+			resolved = append(resolved, info)
+		}
+	}
+
+	plans := BuildSearchPlans(req.Query, intent, resolved, regions, req.Synonyms)
+	localizePlans(plans, resolved)
+	for i := range plans {
+		plans[i].Publisher = req.Publisher
+	}
+	scopes := make([]string, 0, len(plans))
+	for _, p := range plans {
+		scopes = append(scopes, p.Scope)
+	}
+
+	targets := buildTargets(resolved, req.GlobalTargets)
+	var collapsed []geo.DiscoveryTarget
+	if req.CollapseLocales {
+		targets, collapsed = collapseSharedLocales(targets, resolved, []string{"en", req.PivotLang})
+	}
+
+	return &PlanExplanation{
+		Query:      req.Query,
+		Normalized: normalizeQuery(req.Query),
+		Scope:      req.Scope.String(),
+		Intent:     intent,
+		Countries:  countryNames,
+		Guesses:    guesses,
+		Note:       note,
+		Resolved:   resolved,
+		Scopes:     uniqueSorted(scopes),
+		Plans:      plans,
+		Targets:    targets,
+		Collapsed:  collapsed,
+	}
+}
+
+func printExplanation(w io.Writer, ex *PlanExplanation) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(ex); err != nil {
+		return fmt.Errorf("encode explanation: %w", err)
+	}
+	return nil
+}
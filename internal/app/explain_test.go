@@ -0,0 +1,52 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"newscheck/internal/geo"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files under testdata")
+
+// mapResolver resolves the countries it lists, by lower-cased name.
+type mapResolver map[string]geo.CountryInfo
+
+func (m mapResolver) ResolveCountry(_ context.Context, name string) (geo.CountryInfo, error) {
+	if info, ok := m[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return info, nil
+	}
+	return geo.CountryInfo{}, errors.New("unknown country " + name)
+}
+
+func TestExplainSearchGolden(t *testing.T) {
+	resolver := mapResolver{
+		"france": {Name: "France", ISO2: "FR", Languages: []string{"fr"}, LanguageNames: map[string]string{"fr": "French"}},
+	}
+	req := SearchRequest{Query: "Pension strikes in France", Scope: ScopeAuto, QueryLang: "en"}
+	ex := explainSearch(context.Background(), req, nil, resolver, nil, 1)
+
+	var got bytes.Buffer
+	if err := printExplanation(&got, ex); err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "explain.golden.json")
+	if *update {
+		if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("explanation differs from %s (run with -update to accept):\n%s", golden, got.String())
+	}
+}
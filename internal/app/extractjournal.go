@@ -0,0 +1,161 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"newscheck/internal/extract"
+)
+
+// DefaultExtractJournalTTL is how long an unfinished run folder is kept
+// for -resume-extraction before open prunes it.
+const DefaultExtractJournalTTL = 7 * 24 * time.Hour
+
+// ExtractJournal saves every article ExtractAll finishes, as it finishes,
+// so a run that crashes can be resumed (Service.ResumeExtraction) without
+// extracting those URLs again. Unlike the candidate cache it is scoped to
+// one run: each run gets its own folder, named after its set of URLs and
+// pivot language, which is removed once every URL of the set has been
+// extracted. Folders of runs that had failures are pruned after TTL.
+type ExtractJournal struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewExtractJournal uses <user cache dir>/newscheck/extract-runs. Returns
+// nil (journaling off) when there is no user cache dir.
+func NewExtractJournal() *ExtractJournal {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return nil
+	}
+	return &ExtractJournal{Dir: filepath.Join(dir, "newscheck", "extract-runs"), TTL: DefaultExtractJournalTTL}
+}
+
+// extractRun is the journal folder of one run.
+type extractRun struct {
+	dir string
+}
+
+// open returns a run folder for urls and pivotLang. Without resume it is a
+// new folder, so concurrent runs of the same set never share or delete
+// each other's; with resume it is the newest folder of the set, if any.
+// Folders untouched for longer than TTL are pruned first. Nil-safe; a nil
+// run journals nothing.
+func (j *ExtractJournal) open(urls []string, pivotLang string, resume bool) (*extractRun, error) {
+	if j == nil || j.Dir == "" {
+		return nil, nil
+	}
+	now := time.Now()
+	j.prune(now)
+
+	set := append([]string(nil), urls...)
+	sort.Strings(set)
+	sum := sha256.Sum256([]byte(pivotLang + "\n" + strings.Join(set, "\n")))
+	prefix := hex.EncodeToString(sum[:8]) + "-"
+	if resume {
+		if dir := j.latest(prefix); dir != "" {
+			os.Chtimes(dir, now, now) // resuming keeps it alive for another TTL
+			return &extractRun{dir: dir}, nil
+		}
+	}
+	if err := os.MkdirAll(j.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(j.Dir, prefix+strconv.FormatInt(now.UnixNano(), 36)+"-")
+	if err != nil {
+		return nil, err
+	}
+	return &extractRun{dir: dir}, nil
+}
+
+// prune removes run folders not modified for longer than TTL, as
+// CandidateCache.put does for cache files. Errors are ignored; a folder
+// that can't be removed is retried on the next open.
+func (j *ExtractJournal) prune(now time.Time) {
+	if j.TTL <= 0 {
+		return
+	}
+	entries, _ := os.ReadDir(j.Dir)
+	for _, e := range entries {
+		if info, err := e.Info(); err == nil && e.IsDir() && now.Sub(info.ModTime()) > j.TTL {
+			os.RemoveAll(filepath.Join(j.Dir, e.Name()))
+		}
+	}
+}
+
+// latest returns the most recently modified run folder whose name starts
+// with prefix, or "".
+func (j *ExtractJournal) latest(prefix string) string {
+	entries, _ := os.ReadDir(j.Dir)
+	var best string
+	var bestMod time.Time
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if info, err := e.Info(); err == nil && (best == "" || info.ModTime().After(bestMod)) {
+			best, bestMod = filepath.Join(j.Dir, e.Name()), info.ModTime()
+		}
+	}
+	return best
+}
+
+func (r *extractRun) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(r.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// load returns the article journaled for url, if any.
+func (r *extractRun) load(url string) (*extract.Article, bool) {
+	if r == nil {
+		return nil, false
+	}
+	b, err := os.ReadFile(r.path(url))
+	if err != nil {
+		return nil, false
+	}
+	var art extract.Article
+	if err := json.Unmarshal(b, &art); err != nil {
+		return nil, false
+	}
+	return &art, true
+}
+
+// save journals art for url. The file is written under a temporary name
+// and renamed, so a crash mid-write leaves no half article behind.
+func (r *extractRun) save(url string, art extract.Article) error {
+	if r == nil {
+		return nil
+	}
+	b, err := json.Marshal(art)
+	if err != nil {
+		return err
+	}
+	path := r.path(url)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// finish removes the run folder.
+func (r *extractRun) finish() error {
+	if r == nil {
+		return nil
+	}
+	err := os.RemoveAll(r.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
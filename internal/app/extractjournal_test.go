@@ -0,0 +1,120 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"newscheck/internal/extract"
+)
+
+func TestExtractJournal(t *testing.T) {
+	urls := []string{"https://a/1", "https://a/2"}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, j *ExtractJournal)
+	}{
+		{
+			name: "resume reloads what the last run saved",
+			run: func(t *testing.T, j *ExtractJournal) {
+				r, err := j.open(urls, "en", false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := r.save(urls[0], extract.Article{Title: "one"}); err != nil {
+					t.Fatal(err)
+				}
+				r2, err := j.open([]string{urls[1], urls[0]}, "en", true)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if art, ok := r2.load(urls[0]); !ok || art.Title != "one" {
+					t.Errorf("load = %v, %v; want the saved article", art, ok)
+				}
+				if _, ok := r2.load(urls[1]); ok {
+					t.Error("load of an unsaved URL succeeded")
+				}
+			},
+		},
+		{
+			name: "a fresh run doesn't touch a concurrent one",
+			run: func(t *testing.T, j *ExtractJournal) {
+				r1, _ := j.open(urls, "en", false)
+				r1.save(urls[0], extract.Article{Title: "one"})
+				r2, _ := j.open(urls, "en", false)
+				if r1.dir == r2.dir {
+					t.Fatal("both runs got the same folder")
+				}
+				if _, ok := r2.load(urls[0]); ok {
+					t.Error("fresh run sees the other run's article")
+				}
+				if _, ok := r1.load(urls[0]); !ok {
+					t.Error("fresh run removed the other run's article")
+				}
+			},
+		},
+		{
+			name: "other pivot language is another set",
+			run: func(t *testing.T, j *ExtractJournal) {
+				r, _ := j.open(urls, "en", false)
+				r.save(urls[0], extract.Article{Title: "one"})
+				r2, _ := j.open(urls, "fr", true)
+				if _, ok := r2.load(urls[0]); ok {
+					t.Error("resumed a run of another pivot language")
+				}
+			},
+		},
+		{
+			name: "finish removes the folder",
+			run: func(t *testing.T, j *ExtractJournal) {
+				r, _ := j.open(urls, "en", false)
+				r.save(urls[0], extract.Article{Title: "one"})
+				if err := r.finish(); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := os.Stat(r.dir); !os.IsNotExist(err) {
+					t.Errorf("folder still there: %v", err)
+				}
+			},
+		},
+		{
+			name: "folders older than TTL are pruned on open",
+			run: func(t *testing.T, j *ExtractJournal) {
+				r, _ := j.open(urls, "en", false)
+				r.save(urls[0], extract.Article{Title: "one"})
+				old := time.Now().Add(-2 * j.TTL)
+				if err := os.Chtimes(r.dir, old, old); err != nil {
+					t.Fatal(err)
+				}
+				r2, _ := j.open(urls, "en", true)
+				if _, ok := r2.load(urls[0]); ok {
+					t.Error("resumed an expired run")
+				}
+				if _, err := os.Stat(r.dir); !os.IsNotExist(err) {
+					t.Errorf("expired folder still there: %v", err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, &ExtractJournal{Dir: filepath.Join(t.TempDir(), "runs"), TTL: time.Hour})
+		})
+	}
+}
+
+func TestExtractJournalNil(t *testing.T) {
+	var j *ExtractJournal
+	r, err := j.open([]string{"https://a/1"}, "en", true)
+	if r != nil || err != nil {
+		t.Fatalf("open = %v, %v; want nil, nil", r, err)
+	}
+	if err := r.save("https://a/1", extract.Article{}); err != nil {
+		t.Error(err)
+	}
+	if _, ok := r.load("https://a/1"); ok {
+		t.Error("nil run loaded an article")
+	}
+}
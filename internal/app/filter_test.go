@@ -0,0 +1,252 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+func TestFilterCandidates(t *testing.T) {
+	old := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) // no recency boost
+	cand := func(title, url string) discovery.Candidate {
+		return discovery.Candidate{Title: title, URL: url, PublishedAt: old}
+	}
+	france := []geo.CountryInfo{{Name: "France", ISO2: "FR", Languages: []string{"fr"}}}
+	local := func(c discovery.Candidate) discovery.Candidate {
+		c.TargetISO2, c.TargetLang = "FR", "fr"
+		return c
+	}
+	english := func(c discovery.Candidate) discovery.Candidate {
+		c.TargetISO2, c.TargetLang = "GB", "en"
+		return c
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		countries []geo.CountryInfo
+		opts      FilterOptions
+		in        []discovery.Candidate
+		wantURLs  []string
+		wantScore int // of the first result; 0 = don't check
+	}{
+		{
+			name:     "keyword match kept, no match dropped",
+			query:    "pension strikes",
+			in:       []discovery.Candidate{cand("Weather turns cold", "https://a/1"), cand("Pension strikes spread", "https://a/2")},
+			wantURLs: []string{"https://a/2"},
+		},
+		{
+			name:     "more matched terms rank first",
+			query:    "pension reform strikes",
+			in:       []discovery.Candidate{cand("Strikes spread", "https://a/1"), cand("Pension reform strikes spread", "https://a/2")},
+			wantURLs: []string{"https://a/2", "https://a/1"},
+		},
+		{
+			name:     "publisher suffix doesn't match",
+			query:    "reuters",
+			in:       []discovery.Candidate{cand("Markets fall - Reuters", "https://a/1")},
+			wantURLs: []string{},
+		},
+		{
+			name:     "stemming matches inflections",
+			query:    "voting",
+			opts:     FilterOptions{Stemming: true},
+			in:       []discovery.Candidate{cand("Parliament votes on budget", "https://a/1")},
+			wantURLs: []string{"https://a/1"},
+		},
+		{
+			name:     "no stemming, no inflections",
+			query:    "voting",
+			in:       []discovery.Candidate{cand("Parliament votes on budget", "https://a/1")},
+			wantURLs: []string{},
+		},
+		{
+			name:      "country name adds to a keyword match",
+			query:     "pension strikes",
+			countries: france,
+			in:        []discovery.Candidate{cand("Pension strikes in France", "https://a/1")},
+			wantURLs:  []string{"https://a/1"},
+			wantScore: 10 + 10 + phraseBoost + 5 + cooccurrenceBoost,
+		},
+		{
+			name:      "local-language target adds to a keyword match",
+			query:     "grève",
+			countries: france,
+			in:        []discovery.Candidate{local(cand("La grève continue", "https://a/1"))},
+			wantURLs:  []string{"https://a/1"},
+			wantScore: 10 + 5,
+		},
+		{
+			name:      "local-language target alone is no match",
+			query:     "grève",
+			countries: france,
+			in:        []discovery.Candidate{local(cand("Météo: le froid arrive", "https://a/1"))},
+			wantURLs:  []string{},
+		},
+		{
+			name:     "min relevance cuts weak matches",
+			query:    "pension reform strikes",
+			opts:     FilterOptions{MinRelevance: 20},
+			in:       []discovery.Candidate{cand("Strikes spread", "https://a/1"), cand("Pension reform strikes spread", "https://a/2")},
+			wantURLs: []string{"https://a/2"},
+		},
+		{
+			name:     "min results backfills",
+			query:    "pension reform strikes",
+			opts:     FilterOptions{MinRelevance: 100, MinResults: 1},
+			in:       []discovery.Candidate{cand("Strikes spread", "https://a/1"), cand("Pension reform strikes spread", "https://a/2")},
+			wantURLs: []string{"https://a/2"},
+		},
+		{
+			name:     "exact phrase outranks scattered keywords",
+			query:    "interest rate cut",
+			in:       []discovery.Candidate{cand("Rate debate: cut interest or wait?", "https://a/1"), cand("Central bank announces interest rate cut", "https://a/2")},
+			wantURLs: []string{"https://a/2", "https://a/1"},
+		},
+		{
+			name:     "quoted phrase outranks scattered keywords",
+			query:    `"rate cut" inflation`,
+			in:       []discovery.Candidate{cand("Inflation: cut the rate?", "https://a/1"), cand("Inflation cools after rate cut", "https://a/2")},
+			wantURLs: []string{"https://a/2", "https://a/1"},
+		},
+		{
+			name:     "pivot boost off keeps input order on a tie",
+			query:    "pension strikes",
+			in:       []discovery.Candidate{local(cand("Pension strikes in Paris", "https://a/1")), english(cand("Pension strikes in London", "https://a/2"))},
+			wantURLs: []string{"https://a/1", "https://a/2"},
+		},
+		{
+			name:      "pivot boost lifts candidates already in the pivot language",
+			query:     "pension strikes",
+			opts:      FilterOptions{PivotLang: "en", PivotBoost: 10},
+			in:        []discovery.Candidate{local(cand("Pension strikes in Paris", "https://a/1")), english(cand("Pension strikes in London", "https://a/2"))},
+			wantURLs:  []string{"https://a/2", "https://a/1"},
+			wantScore: 20 + 20 + 10,
+		},
+		{
+			name:     "pivot boost does not rescue a non-match",
+			query:    "pension strikes",
+			opts:     FilterOptions{PivotLang: "en", PivotBoost: 10},
+			in:       []discovery.Candidate{english(cand("Weather turns cold", "https://a/1")), local(cand("Pension strikes spread", "https://a/2"))},
+			wantURLs: []string{"https://a/2"},
+		},
+		{
+			name:     "publisher filter",
+			query:    "pension",
+			opts:     FilterOptions{Publisher: "Le Monde"},
+			in:       []discovery.Candidate{cand("Pension reform", "https://www.lemonde.fr/a"), cand("Pension reform", "https://www.bbc.com/b")},
+			wantURLs: []string{"https://www.lemonde.fr/a"},
+		},
+		{
+			name:     "preferred domain outranks an equal keyword match",
+			query:    "pension",
+			opts:     FilterOptions{PreferredDomains: []string{"lemonde.fr"}},
+			in:       []discovery.Candidate{cand("Pension reform", "https://www.bbc.com/b"), cand("Pension reform", "https://www.lemonde.fr/a")},
+			wantURLs: []string{"https://www.lemonde.fr/a", "https://www.bbc.com/b"},
+		},
+		{
+			name:     "preferred outlet behind a wrapper",
+			query:    "pension",
+			opts:     FilterOptions{PreferredDomains: []string{"ouest-france.fr"}},
+			in:       []discovery.Candidate{wrapped("1", "Pension reform", "BBC News"), wrapped("2", "Pension reform", "Ouest-France")},
+			wantURLs: []string{"https://news.google.com/rss/articles/2", "https://news.google.com/rss/articles/1"},
+		},
+		{
+			name:     "noise-listed domain dropped",
+			query:    "pension",
+			opts:     FilterOptions{Noise: &NoiseList{Domains: []string{"spam.example"}}},
+			in:       []discovery.Candidate{cand("Pension reform", "https://news.spam.example/a"), cand("Pension reform", "https://www.bbc.com/b")},
+			wantURLs: []string{"https://www.bbc.com/b"},
+		},
+		{
+			name:     "noise-listed outlet behind a wrapper dropped",
+			query:    "pension",
+			opts:     FilterOptions{Noise: &NoiseList{Domains: []string{"reuters.com"}}},
+			in:       []discovery.Candidate{wrapped("1", "Pension reform", "Reuters"), wrapped("2", "Pension reform", "Ouest-France")},
+			wantURLs: []string{"https://news.google.com/rss/articles/2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCandidates(tt.in, tt.query, Intent{}, tt.countries, tt.opts)
+			if len(got) != len(tt.wantURLs) {
+				t.Fatalf("got %d candidates %v, want %v", len(got), urlsOf(got), tt.wantURLs)
+			}
+			for i, u := range tt.wantURLs {
+				if got[i].URL != u {
+					t.Errorf("result %d = %s, want %s", i, got[i].URL, u)
+				}
+			}
+			if tt.wantScore > 0 && got[0].RelevanceScore != tt.wantScore {
+				t.Errorf("score = %d, want %d", got[0].RelevanceScore, tt.wantScore)
+			}
+		})
+	}
+}
+
+func urlsOf(cands []discovery.Candidate) []string {
+	out := make([]string, len(cands))
+	for i, c := range cands {
+		out[i] = c.URL
+	}
+	return out
+}
+
+func TestMinRelevanceWithBackfill(t *testing.T) {
+	old := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	cand := func(title, url string) discovery.Candidate {
+		return discovery.Candidate{Title: title, URL: url, PublishedAt: old}
+	}
+	// For "pension reform strikes": a/30 has every term (as a phrase), a/20
+	// two, a/10a and a/10b one each
+	in := []discovery.Candidate{
+		cand("Strikes spread", "https://a/10a"),
+		cand("Pension reform strikes spread", "https://a/30"),
+		cand("Reform strikes spread", "https://a/20"),
+		cand("Pension talks stall", "https://a/10b"),
+	}
+	tests := []struct {
+		name         string
+		min, results int
+		want         []string
+	}{
+		{"no cutoff keeps every match", 0, 0, []string{"https://a/30", "https://a/20", "https://a/10a", "https://a/10b"}},
+		{"cutoff alone", 20, 0, []string{"https://a/30", "https://a/20"}},
+		{"enough cleared, no backfill", 20, 2, []string{"https://a/30", "https://a/20"}},
+		{"backfill the best below the cutoff", 30, 3, []string{"https://a/30", "https://a/20", "https://a/10a"}},
+		{"backfill when nothing cleared", 100, 1, []string{"https://a/30"}},
+		{"backfill is capped by what matched", 100, 10, []string{"https://a/30", "https://a/20", "https://a/10a", "https://a/10b"}},
+		{"min results without a cutoff changes nothing", 0, 1, []string{"https://a/30", "https://a/20", "https://a/10a", "https://a/10b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := FilterOptions{MinRelevance: tt.min, MinResults: tt.results}
+			got := urlsOf(filterCandidates(in, "pension reform strikes", Intent{}, nil, opts))
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryPhrases(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"interest rate cut", []string{"interest rate cut"}},
+		{`"rate cut" inflation "Bank of England"`, []string{"rate cut", "bank of england"}},
+		{"“taux directeur” BCE", []string{"taux directeur"}},
+		{"inflation", nil},
+		{"why did the central bank cut interest rates again this year", nil},
+	}
+	for _, tt := range tests {
+		if got := queryPhrases(tt.query); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("queryPhrases(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
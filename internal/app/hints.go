@@ -0,0 +1,117 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"newscheck/internal/discovery"
+)
+
+// EmptyResultHint is a likely reason a search found nothing, with what to
+// try instead.
+type EmptyResultHint struct {
+	Cause string `json:"cause"`
+	Fix   string `json:"fix"`
+}
+
+// narrowWindowHours is the window length below which an empty result is
+// blamed (partly) on the window.
+const narrowWindowHours = 48
+
+// specificKeywordCount is how many keywords make a query "very specific"
+// when sources returned nothing at all.
+const specificKeywordCount = 5
+
+// emptyResultHints diagnoses a search that ended with no candidates from its
+// stats and source errors. Nil when there are candidates.
+func emptyResultHints(req SearchRequest, res *SearchResult) []EmptyResultHint {
+	if res == nil || len(res.Candidates) > 0 {
+		return nil
+	}
+	raw, thin := 0, 0
+	if res.Stats != nil {
+		raw, thin = res.Stats.CandidatesRaw, res.Stats.BelowMinSources
+	}
+
+	var hints []EmptyResultHint
+	if raw == 0 && len(res.SourceErrors) > 0 {
+		hints = append(hints, EmptyResultHint{
+			Cause: "no source returned anything and some failed: " + strings.Join(summarizeSourceErrors(res.SourceErrors), "; "),
+			Fix:   "retry in a few minutes (for consent pages, set " + discovery.GoogleNewsCookieEnv + ")",
+		})
+	}
+
+	if thin > 0 {
+		hints = append(hints, EmptyResultHint{
+			Cause: fmt.Sprintf("%d matching articles were dropped because their stories had fewer than %d sources", thin, req.Filter.MinSources),
+			Fix:   "lower -min-sources or widen the time window",
+		})
+	} else if raw > 0 {
+		// Sources delivered; the relevance filter threw everything out
+		cause := fmt.Sprintf("%d articles were found but none matched the query keywords", raw)
+		fix := "use fewer or more general keywords"
+		if req.Filter.MinRelevance > 0 {
+			cause = fmt.Sprintf("%d articles were found but none reached -min-relevance %d", raw, req.Filter.MinRelevance)
+			fix = "lower -min-relevance or set -min-results to keep the best matches"
+		}
+		hints = append(hints, EmptyResultHint{Cause: cause, Fix: fix})
+		if req.Filter.FreshnessFloor > 0 {
+			hints = append(hints, EmptyResultHint{
+				Cause: "the freshness floor drops anything older than " + req.Filter.FreshnessFloor.String(),
+				Fix:   "raise or remove the freshness floor",
+			})
+		}
+		if len(req.Filter.MaxAgePerSource) > 0 {
+			hints = append(hints, EmptyResultHint{
+				Cause: "per-source max ages drop older articles (" + formatMaxAges(req.Filter.MaxAgePerSource) + ")",
+				Fix:   "raise or remove the per-source max ages",
+			})
+		}
+	}
+
+	if !req.From.IsZero() && !req.To.IsZero() {
+		if h := req.To.Sub(req.From).Hours(); h <= narrowWindowHours {
+			hints = append(hints, EmptyResultHint{
+				Cause: fmt.Sprintf("the time window is only %.0f hours", h),
+				Fix:   "widen the time window (e.g. 7 days)",
+			})
+		}
+	}
+
+	if req.Scope != ScopeGlobal && len(res.Countries) > 0 {
+		names := make([]string, 0, len(res.Countries))
+		for _, c := range res.Countries {
+			names = append(names, c.Name)
+		}
+		hints = append(hints, EmptyResultHint{
+			Cause: "the search was limited to " + strings.Join(names, ", "),
+			Fix:   "try Global scope",
+		})
+	}
+
+	if raw == 0 && len(res.SourceErrors) == 0 && len(res.Intent.Keywords) >= specificKeywordCount {
+		hints = append(hints, EmptyResultHint{
+			Cause: fmt.Sprintf("the query has %d keywords and feeds need most of them to match", len(res.Intent.Keywords)),
+			Fix:   "shorten the query to its 2-3 key terms",
+		})
+	}
+
+	if len(hints) == 0 {
+		hints = append(hints, EmptyResultHint{
+			Cause: "no source had coverage of this query in the window",
+			Fix:   "widen the time window or try Global scope",
+		})
+	}
+	return hints
+}
+
+func printEmptyResultHints(w io.Writer, hints []EmptyResultHint) {
+	if len(hints) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nNothing found. Likely causes:")
+	for _, h := range hints {
+		fmt.Fprintf(w, "- %s; %s\n", h.Cause, h.Fix)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+func TestEmptyResultHints(t *testing.T) {
+	week := lastWeek()
+	global := SearchRequest{From: week.From, To: week.To, Scope: ScopeGlobal}
+	withReq := func(f func(*SearchRequest)) SearchRequest {
+		r := global
+		f(&r)
+		return r
+	}
+	raw := func(n, thin int) *RunStats {
+		s := newRunStats()
+		s.CandidatesRaw, s.BelowMinSources = n, thin
+		return s
+	}
+
+	tests := []struct {
+		name    string
+		req     SearchRequest
+		res     *SearchResult
+		wantFix []string
+	}{
+		{
+			name:    "sources failed",
+			req:     global,
+			res:     &SearchResult{Stats: raw(0, 0), SourceErrors: []SourceError{{Source: "Google News", Target: "FR/fr", Err: "HTTP 429"}}},
+			wantFix: []string{"retry in a few minutes"},
+		},
+		{
+			name:    "stories below min sources",
+			req:     withReq(func(r *SearchRequest) { r.Filter.MinSources = 3 }),
+			res:     &SearchResult{Stats: raw(40, 12)},
+			wantFix: []string{"lower -min-sources"},
+		},
+		{
+			name:    "nothing matched the keywords",
+			req:     global,
+			res:     &SearchResult{Stats: raw(40, 0)},
+			wantFix: []string{"fewer or more general keywords"},
+		},
+		{
+			name:    "relevance cutoff and age limits",
+			req:     withReq(func(r *SearchRequest) { r.Filter.MinRelevance, r.Filter.FreshnessFloor = 30, 24*time.Hour }),
+			res:     &SearchResult{Stats: raw(40, 0)},
+			wantFix: []string{"lower -min-relevance", "raise or remove the freshness floor"},
+		},
+		{
+			name:    "narrow window",
+			req:     withReq(func(r *SearchRequest) { r.From = r.To.Add(-12 * time.Hour) }),
+			res:     &SearchResult{Stats: raw(0, 0)},
+			wantFix: []string{"widen the time window (e.g. 7 days)"},
+		},
+		{
+			name:    "country scope",
+			req:     withReq(func(r *SearchRequest) { r.Scope = ScopeAuto }),
+			res:     &SearchResult{Stats: raw(0, 0), Countries: []geo.CountryInfo{{Name: "Guyana", ISO2: "GY"}}},
+			wantFix: []string{"try Global scope"},
+		},
+		{
+			name:    "very specific query",
+			req:     global,
+			res:     &SearchResult{Stats: raw(0, 0), Intent: Intent{Keywords: []string{"port", "strike", "antwerp", "dockers", "union", "wages"}}},
+			wantFix: []string{"shorten the query"},
+		},
+		{
+			name:    "no coverage",
+			req:     global,
+			res:     &SearchResult{Stats: raw(0, 0)},
+			wantFix: []string{"widen the time window or try Global scope"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hints := emptyResultHints(tt.req, tt.res)
+			var fixes []string
+			for _, h := range hints {
+				fixes = append(fixes, h.Fix)
+			}
+			if len(fixes) != len(tt.wantFix) {
+				t.Fatalf("fixes = %q, want %q", fixes, tt.wantFix)
+			}
+			for i, want := range tt.wantFix {
+				if !strings.Contains(fixes[i], want) {
+					t.Errorf("fix %d = %q, want %q", i, fixes[i], want)
+				}
+			}
+		})
+	}
+
+	if hints := emptyResultHints(global, &SearchResult{Candidates: []discovery.Candidate{{URL: "https://a/1"}}}); hints != nil {
+		t.Errorf("hints with candidates = %v, want none", hints)
+	}
+	var out bytes.Buffer
+	printEmptyResultHints(&out, emptyResultHints(global, &SearchResult{}))
+	if !strings.Contains(out.String(), "Nothing found. Likely causes:") {
+		t.Errorf("printed %q", out.String())
+	}
+}
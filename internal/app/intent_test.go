@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestExtractIntentDeterministic(t *testing.T) {
+	// Several terms tie on frequency, and the query hits topics, themes,
+	// regions and countries, which are matched through maps
+	queries := []string{
+		"Protests and strikes over pension reform in France, Germany and the Caribbean: economy, inflation, elections",
+		"grève manifestation réforme des retraites France Belgique",
+	}
+	for _, q := range queries {
+		first, err := json.Marshal(ExtractIntent(q))
+		if err != nil {
+			t.Fatal(err)
+		}
+		firstPlans, err := json.Marshal(BuildSearchPlans(q, ExtractIntent(q), nil, nil, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range 200 {
+			got, _ := json.Marshal(ExtractIntent(q))
+			if !bytes.Equal(got, first) {
+				t.Fatalf("ExtractIntent(%q) changed between runs:\n%s\n%s", q, first, got)
+			}
+			plans, _ := json.Marshal(BuildSearchPlans(q, ExtractIntent(q), nil, nil, nil))
+			if !bytes.Equal(plans, firstPlans) {
+				t.Fatalf("plans for %q changed between runs:\n%s\n%s", q, firstPlans, plans)
+			}
+		}
+	}
+}
+
+func TestDetectQueryLang(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"les manifestations contre la réforme des retraites en France", "fr"},
+		{"grève santé", "fr"},
+		{"protests against the pension reform in France", "en"},
+		{"elections economy", ""}, // too short for stopwords; English lexicon wins, which means ""
+		{"Macron", ""},
+	}
+	for _, tt := range tests {
+		if got := DetectQueryLang(tt.query); got != tt.want {
+			t.Errorf("DetectQueryLang(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryLangDrivesLexiconAndPivot(t *testing.T) {
+	// The French lexicon maps "grève" to Protests; English alone doesn't
+	if got := ExtractIntentIn("grève des dockers", "fr"); got.Lang != "fr" || !slices.Contains(got.Themes, "Protests") {
+		t.Errorf("French intent = %+v, want Lang fr and the Protests theme", got)
+	}
+	if got := ExtractIntentIn("grève des dockers", "en"); slices.Contains(got.Themes, "Protests") {
+		t.Errorf("English override still used the French lexicon: %+v", got)
+	}
+	if got := ExtractIntent("les manifestations contre la réforme des retraites"); got.Lang != "fr" {
+		t.Errorf("detected Lang = %q, want fr", got.Lang)
+	}
+
+	if DefaultPivotFor("fr") != "fr" || DefaultPivotFor("") != "en" || DefaultPivotFor("sw") != "en" {
+		t.Errorf("DefaultPivotFor: fr -> %q, \"\" -> %q, sw -> %q", DefaultPivotFor("fr"), DefaultPivotFor(""), DefaultPivotFor("sw"))
+	}
+}
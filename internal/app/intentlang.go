@@ -0,0 +1,105 @@
+package app
+
+import (
+	"sort"
+	"strings"
+
+	"newscheck/internal/langdetect"
+)
+
+// Localized patterns added to the English lexicons when the query is in that
+// language. Labels match the English lexicons so intents stay comparable.
+var localRegionLexicon = map[string]map[string][]string{
+	"fr": {"South America": {"amérique du sud", "amérique latine"}, "Caribbean": {"caraïbes", "antilles"}, "North America": {"amérique du nord"}, "Middle East": {"moyen-orient", "proche-orient"}, "Africa": {"afrique"}, "Asia": {"asie"}, "World": {"monde", "mondial"}},
+	"es": {"South America": {"sudamérica", "américa del sur", "latinoamérica", "américa latina"}, "Caribbean": {"caribe"}, "North America": {"norteamérica", "américa del norte"}, "Middle East": {"oriente medio", "medio oriente"}, "Africa": {"áfrica"}, "World": {"mundo", "mundial"}},
+	"pt": {"South America": {"américa do sul", "américa latina"}, "Caribbean": {"caribe"}, "Middle East": {"oriente médio"}, "Africa": {"áfrica"}, "Asia": {"ásia"}, "World": {"mundo", "mundial"}},
+	"de": {"South America": {"südamerika", "lateinamerika"}, "Caribbean": {"karibik"}, "North America": {"nordamerika"}, "Middle East": {"nahost", "naher osten"}, "Africa": {"afrika"}, "Asia": {"asien"}, "World": {"welt"}},
+}
+
+var localTopicLexicon = map[string]map[string][]string{
+	"fr": {"Politics": {"politique", "gouvernement", "parlement", "président", "ministre"}, "Economy": {"économie", "croissance", "pib", "banque centrale", "dette"}, "Security": {"sécurité", "militaire", "attaque", "attentat", "violence"}, "Health": {"santé", "épidémie", "hôpital"}, "Tech": {"technologie", "numérique", "cyber"}},
+	"es": {"Politics": {"política", "gobierno", "parlamento", "congreso", "presidente", "ministro"}, "Economy": {"economía", "inflación", "pib", "recesión", "banco central", "deuda"}, "Security": {"seguridad", "militar", "ataque", "violencia", "cártel", "pandilla"}, "Health": {"salud", "brote", "hospital"}, "Tech": {"tecnología", "ciber"}},
+	"pt": {"Politics": {"política", "governo", "parlamento", "congresso", "presidente", "ministro"}, "Economy": {"economia", "inflação", "pib", "recessão", "banco central", "dívida"}, "Security": {"segurança", "militar", "ataque", "violência", "facção"}, "Health": {"saúde", "surto", "hospital"}, "Tech": {"tecnologia", "ciber"}},
+	"de": {"Politics": {"politik", "regierung", "parlament", "bundestag", "präsident", "minister"}, "Economy": {"wirtschaft", "inflation", "rezession", "zentralbank", "schulden"}, "Security": {"sicherheit", "militär", "angriff", "anschlag", "gewalt"}, "Health": {"gesundheit", "ausbruch", "krankenhaus"}, "Tech": {"technologie", "cyber"}},
+}
+
+var localThemeLexicon = map[string]map[string][]string{
+	"fr": {"Elections": {"élection", "vote", "scrutin", "campagne"}, "Protests": {"manifestation", "grève", "émeute", "contestation"}, "Sanctions": {"sanction"}, "Corruption": {"corruption", "pot-de-vin"}, "Courts": {"tribunal", "juge", "procès"}, "Legislation": {"projet de loi", "législation"}, "Foreign policy": {"diplomatie", "traité", "sommet"}},
+	"es": {"Elections": {"elecci", "voto", "votación", "campaña", "balotaje"}, "Protests": {"protesta", "manifestación", "huelga", "disturbio"}, "Sanctions": {"sanci"}, "Corruption": {"corrupción", "soborno"}, "Courts": {"tribunal", "corte", "juez", "fallo"}, "Legislation": {"proyecto de ley", "ley", "legislación"}, "Foreign policy": {"diplomacia", "tratado", "cumbre"}},
+	"pt": {"Elections": {"eleição", "eleições", "voto", "votação", "campanha"}, "Protests": {"protesto", "manifestação", "greve"}, "Sanctions": {"sanç"}, "Corruption": {"corrupção", "propina"}, "Courts": {"tribunal", "supremo", "juiz"}, "Legislation": {"projeto de lei", "legislação"}, "Foreign policy": {"diplomacia", "tratado", "cúpula"}},
+	"de": {"Elections": {"wahl", "abstimmung", "wahlkampf"}, "Protests": {"protest", "demonstration", "streik", "unruhen"}, "Sanctions": {"sanktion"}, "Corruption": {"korruption", "bestechung"}, "Courts": {"gericht", "richter", "urteil"}, "Legislation": {"gesetz", "gesetzentwurf"}, "Foreign policy": {"diplomatie", "vertrag", "gipfel"}},
+}
+
+// DetectQueryLang guesses the ISO-639-1 language of a query, "" when unsure.
+// Queries are often too short for stopword detection, so it falls back to
+// counting localized lexicon words; the language must beat English there.
+func DetectQueryLang(query string) string {
+	if lang, ok := langdetect.Detect(query); ok {
+		return lang
+	}
+	t := strings.ToLower(query)
+	english := lexiconHits(t, regionLexicon, topicLexicon, themeLexicon)
+	langs := make([]string, 0, len(localThemeLexicon))
+	for lang := range localThemeLexicon {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	best, bestN, tie := "", english, false
+	for _, lang := range langs {
+		n := lexiconHits(t, localRegionLexicon[lang], localTopicLexicon[lang], localThemeLexicon[lang])
+		switch {
+		case n > bestN:
+			best, bestN, tie = lang, n, false
+		case n == bestN && best != "":
+			tie = true
+		}
+	}
+	if tie {
+		return ""
+	}
+	return best
+}
+
+// lexiconHits counts the patterns of lexs found in t.
+func lexiconHits(t string, lexs ...map[string][]string) int {
+	n := 0
+	for _, lex := range lexs {
+		for _, patterns := range lex {
+			for _, p := range patterns {
+				if strings.Contains(t, p) {
+					n++
+				}
+			}
+		}
+	}
+	return n
+}
+
+// lexiconFor returns the English lexicon plus lang's localized patterns.
+func lexiconFor(base map[string][]string, local map[string]map[string][]string, lang string) map[string][]string {
+	extra := local[strings.ToLower(lang)]
+	if len(extra) == 0 {
+		return base
+	}
+	out := make(map[string][]string, len(base)+len(extra))
+	for label, p := range base {
+		out[label] = p
+	}
+	for label, p := range extra {
+		out[label] = append(append([]string(nil), out[label]...), p...)
+	}
+	return out
+}
+
+// DefaultPivotFor is the pivot language to pre-select for a query in lang:
+// lang itself when it is an offered pivot, else English.
+func DefaultPivotFor(lang string) string {
+	if lang != "" {
+		for _, l := range PivotLanguages() {
+			if l.Code == lang {
+				return lang
+			}
+		}
+	}
+	return "en"
+}
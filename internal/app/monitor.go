@@ -0,0 +1,251 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+// DefaultMonitorSeenPath persists URLs already reported by `monitor`.
+const DefaultMonitorSeenPath = "data/monitor_seen.json"
+
+// seenRetention: URLs older than this are forgotten (they're out of any
+// realistic search window by then).
+const seenRetention = 30 * 24 * time.Hour
+
+// seenSet remembers which candidate URLs (canonicalized) were already
+// reported, with the time they were first seen.
+type seenSet struct {
+	path string
+	URLs map[string]time.Time `json:"urls"`
+}
+
+func loadSeenSet(path string) (*seenSet, error) {
+	s := &seenSet{path: filepath.Clean(path), URLs: map[string]time.Time{}}
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) || len(b) == 0 {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	if s.URLs == nil {
+		s.URLs = map[string]time.Time{}
+	}
+	return s, nil
+}
+
+// filterNew returns the candidates not seen before, one per URL. They stay
+// unseen until markSeen.
+func (s *seenSet) filterNew(cands []discovery.Candidate) []discovery.Candidate {
+	var fresh []discovery.Candidate
+	batch := map[string]struct{}{}
+	for _, c := range cands {
+		key := discovery.CanonicalizeURL(c.URL)
+		if _, ok := s.URLs[key]; ok {
+			continue
+		}
+		if _, ok := batch[key]; ok {
+			continue
+		}
+		batch[key] = struct{}{}
+		fresh = append(fresh, c)
+	}
+	return fresh
+}
+
+// markSeen records cands as seen at now and forgets URLs older than
+// seenRetention.
+func (s *seenSet) markSeen(cands []discovery.Candidate, now time.Time) {
+	for _, c := range cands {
+		s.URLs[discovery.CanonicalizeURL(c.URL)] = now
+	}
+	for k, t := range s.URLs {
+		if now.Sub(t) > seenRetention {
+			delete(s.URLs, k)
+		}
+	}
+}
+
+func (s *seenSet) save() error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// monitorEvent is what `monitor` emits for each tick with new candidates:
+// one JSON line in -out, and the webhook body.
+type monitorEvent struct {
+	Time       time.Time             `json:"time"`
+	Query      string                `json:"query"`
+	Candidates []discovery.Candidate `json:"candidates"`
+}
+
+// runMonitor re-runs a saved search every -every and reports only candidates
+// it hasn't reported before, until interrupted.
+func runMonitor(args []string) error {
+	fset := flag.NewFlagSet("monitor", flag.ContinueOnError)
+	reqPath := fset.String("request", "", "JSON file with search params (same body as POST /search)")
+	query := fset.String("query", "", "query to watch (alternative to -request)")
+	days := fset.Int("days", 1, "search window in days, with -query")
+	every := fset.Duration("every", 30*time.Minute, "interval between runs")
+	seenPath := fset.String("seen", DefaultMonitorSeenPath, "file remembering already reported URLs")
+	outPath := fset.String("out", "", "append new candidates to this file as JSON lines")
+	webhook := fset.String("webhook", "", "POST new candidates (JSON) to this URL")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if *every <= 0 {
+		return fmt.Errorf("-every must be positive")
+	}
+
+	var params SearchParams
+	switch {
+	case *reqPath != "":
+		b, err := os.ReadFile(*reqPath)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, &params); err != nil {
+			return fmt.Errorf("%s: %w", *reqPath, err)
+		}
+	case *query != "":
+		params = SearchParams{Query: *query, Days: *days}
+	default:
+		return fmt.Errorf("monitor needs -request or -query")
+	}
+	if ok, reason := validateQuery(params.Query); !ok {
+		return fmt.Errorf("invalid query (%s)", reason)
+	}
+
+	svc, err := NewService()
+	if err != nil {
+		return err
+	}
+	seen, err := loadSeenSet(*seenPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Monitoring %q every %s (Ctrl+C to stop)\n", params.Query, *every)
+	ticker := time.NewTicker(*every)
+	defer ticker.Stop()
+	for {
+		if err := monitorTick(ctx, svc, params, seen, *outPath, *webhook); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Println("monitor:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// monitorTick runs one search and emits the candidates not seen before.
+// They are marked seen only once every configured output took them, so a
+// failed delivery is retried on the next tick.
+func monitorTick(ctx context.Context, svc *Service, params SearchParams, seen *seenSet, outPath, webhook string) error {
+	now := time.Now()
+	req, err := params.Request(now)
+	if err != nil {
+		return err
+	}
+	req.NoCache = true // every tick must see the sources' latest items
+	res, err := svc.Search(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fresh := seen.filterNew(res.Candidates)
+	fmt.Printf("[%s] %d candidates, %d new\n", now.Format("2006-01-02 15:04"), len(res.Candidates), len(fresh))
+	if len(fresh) == 0 {
+		return saveSeen(seen, nil, now)
+	}
+
+	sort.Slice(fresh, func(i, j int) bool { return fresh[i].RelevanceScore > fresh[j].RelevanceScore })
+	for _, c := range fresh {
+		fmt.Printf("  + %s\n    %s\n", c.Title, c.URL)
+	}
+
+	ev := monitorEvent{Time: now, Query: params.Query, Candidates: fresh}
+	if outPath != "" {
+		if err := appendJSONLine(outPath, ev); err != nil {
+			return fmt.Errorf("writing %s: %w", outPath, err)
+		}
+	}
+	if webhook != "" {
+		if err := postJSON(ctx, webhook, ev); err != nil {
+			return fmt.Errorf("webhook: %w", err)
+		}
+	}
+	return saveSeen(seen, fresh, now)
+}
+
+// saveSeen marks cands seen and persists the set.
+func saveSeen(seen *seenSet, cands []discovery.Candidate, now time.Time) error {
+	seen.markSeen(cands, now)
+	if err := seen.save(); err != nil {
+		return fmt.Errorf("saving seen URLs: %w", err)
+	}
+	return nil
+}
+
+func appendJSONLine(path string, v any) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(v)
+}
+
+func postJSON(ctx context.Context, url string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Timeout: 15 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestSeenSetMarksOnlyDelivered(t *testing.T) {
+	seen, err := loadSeenSet(filepath.Join(t.TempDir(), "seen.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cands := []discovery.Candidate{
+		{URL: "https://example.com/a"},
+		{URL: "https://www.example.com/a?utm_source=x"},
+		{URL: "https://example.com/b"},
+	}
+
+	fresh := seen.filterNew(cands)
+	if len(fresh) != 2 {
+		t.Fatalf("filterNew = %d candidates, want 2 (variants of /a merged)", len(fresh))
+	}
+	// Delivery failed: nothing marked, the same candidates come back.
+	if again := seen.filterNew(cands); len(again) != 2 {
+		t.Fatalf("after a failed delivery filterNew = %d, want 2", len(again))
+	}
+
+	if err := saveSeen(seen, fresh, now); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := loadSeenSet(seen.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.filterNew(cands); len(got) != 0 {
+		t.Errorf("after delivery filterNew = %d, want 0", len(got))
+	}
+
+	// Entries past seenRetention are forgotten on the next mark.
+	reloaded.markSeen(nil, now.Add(seenRetention+time.Hour))
+	if got := reloaded.filterNew(cands); len(got) != 2 {
+		t.Errorf("after retention filterNew = %d, want 2", len(got))
+	}
+}
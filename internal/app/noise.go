@@ -0,0 +1,173 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"newscheck/internal/discovery"
+)
+
+// User-curated noise lists: JSON arrays of URLs and of publisher domains
+// that are dropped from every search. `newscheck noise add` grows them.
+const (
+	DefaultNoiseURLsPath    = "data/noise_urls.json"
+	DefaultNoiseDomainsPath = "data/noise_domains.json"
+)
+
+// NoiseList is what filterCandidates drops as known junk: flagged URLs
+// (compared canonicalized) and domains (subdomains included, Google News
+// wrappers judged by their outlet), plus the discovery blocklist
+// (discovery.IsListedHost). A nil list still applies the blocklist.
+type NoiseList struct {
+	URLs    map[string]struct{}
+	Domains []string
+}
+
+// LoadNoiseList reads the URL and domain lists; a missing file is empty.
+func LoadNoiseList(urlsPath, domainsPath string) (*NoiseList, error) {
+	urls, err := readNoiseFile(urlsPath)
+	if err != nil {
+		return nil, err
+	}
+	domains, err := readNoiseFile(domainsPath)
+	if err != nil {
+		return nil, err
+	}
+	n := &NoiseList{URLs: make(map[string]struct{}, len(urls)), Domains: normalizeDomains(domains)}
+	for _, u := range urls {
+		n.URLs[discovery.CanonicalizeURL(u)] = struct{}{}
+	}
+	return n, nil
+}
+
+func readNoiseFile(path string) ([]string, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) || len(b) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return out, nil
+}
+
+// Drops reports whether c is known noise.
+func (n *NoiseList) Drops(c discovery.Candidate) bool {
+	u, err := url.Parse(strings.TrimSpace(c.URL))
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if discovery.IsListedHost(host) {
+		return true
+	}
+	if n == nil {
+		return false
+	}
+	if _, ok := n.URLs[discovery.CanonicalizeURL(c.URL)]; ok {
+		return true
+	}
+	return publishedOn(c, n.Domains)
+}
+
+// addNoiseEntries merges entries into the JSON list at path, keeping it
+// sorted and free of repeats. Returns how many were new.
+func addNoiseEntries(path string, entries []string) (int, error) {
+	have, err := readNoiseFile(path)
+	if err != nil {
+		return 0, err
+	}
+	set := make(map[string]struct{}, len(have)+len(entries))
+	for _, e := range have {
+		set[e] = struct{}{}
+	}
+	added := 0
+	for _, e := range entries {
+		if _, ok := set[e]; !ok {
+			set[e] = struct{}{}
+			added++
+		}
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	out := make([]string, 0, len(set))
+	for e := range set {
+		out = append(out, e)
+	}
+	sort.Strings(out)
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, err
+	}
+	return added, os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// runNoise is `newscheck noise add <url|domain>...` and `newscheck noise
+// list`. http(s) URLs go to the URL list, anything else is a domain.
+func runNoise(args []string) error {
+	fs := flag.NewFlagSet("noise", flag.ContinueOnError)
+	urlsPath := fs.String("urls", DefaultNoiseURLsPath, "noise URL list")
+	domainsPath := fs.String("domains", DefaultNoiseDomainsPath, "noise domain list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "add":
+		var urls, domains []string
+		for _, a := range fs.Args()[1:] {
+			if isHTTPURL(a) {
+				urls = append(urls, a)
+			} else {
+				domains = append(domains, a)
+			}
+		}
+		domains = normalizeDomains(domains)
+		if len(urls) == 0 && len(domains) == 0 {
+			return fmt.Errorf("noise add needs at least one URL or domain")
+		}
+		if len(urls) > 0 {
+			n, err := addNoiseEntries(*urlsPath, urls)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: %d URL(s) added\n", *urlsPath, n)
+		}
+		if len(domains) > 0 {
+			n, err := addNoiseEntries(*domainsPath, domains)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: %d domain(s) added\n", *domainsPath, n)
+		}
+		return nil
+	case "list":
+		for _, p := range []string{*urlsPath, *domainsPath} {
+			entries, err := readNoiseFile(p)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s (%d):\n", p, len(entries))
+			for _, e := range entries {
+				fmt.Println("  " + e)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("usage: newscheck noise [-urls file] [-domains file] add <url|domain>... | list")
+}
@@ -0,0 +1,327 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+	"newscheck/internal/geo"
+)
+
+// cliOptions holds non-interactive CLI settings. Everything not set here
+// is still asked interactively by Run.
+type cliOptions struct {
+	Filter          FilterOptions
+	MinArticleChars int
+	Explain         bool
+	GlobalTargets   []geo.DiscoveryTarget
+
+	DiscoveryTimeout time.Duration
+	Dedupe           DedupeStrategy
+	NoCache          bool
+	CollapseLocales  bool
+
+	// MinCountryConfidence gates auto-detected countries; see SearchRequest.
+	MinCountryConfidence float64
+
+	// QueryLang overrides the detected query language; see SearchRequest.
+	QueryLang string
+
+	// Trace is where the search's full pipeline trace is written as JSON.
+	Trace string
+
+	// Stream prints candidates as each discovery call returns.
+	Stream bool
+
+	// Locale ("en-US") becomes the worldwide anchor for its language; see
+	// withLocale. Applied to GlobalTargets after parsing.
+	Locale string
+
+	// Parallelism derives per-stage concurrency (DeriveConcurrency); the
+	// stages set in Concurrency override it.
+	Parallelism int
+	Concurrency Concurrency
+
+	// Timeout multiplier for the single retry of a timed-out extraction.
+	TimeoutEscalation float64
+
+	// IncludeOriginal keeps the untranslated text next to the translation.
+	IncludeOriginal bool
+
+	// KeyActors adds the most-mentioned people, organizations and places
+	// to the resume.
+	KeyActors bool
+
+	// ResumeExtraction skips URLs an interrupted run already extracted.
+	ResumeExtraction bool
+
+	// Per-article cap on the text sent for summarization.
+	Summary SummaryLimits
+
+	// PreferredDomains are outlets pulled first and ranked higher.
+	PreferredDomains []string
+
+	// Publisher restricts the search to one outlet; see SearchRequest.
+	Publisher string
+
+	// Location is the zone of custom date ranges' days (-tz); nil = UTC.
+	Location *time.Location
+
+	// Format is FormatText or FormatURLs.
+	Format string
+
+	// SaveRaw keeps every Google News response body in this folder; FromRaw
+	// replays them instead of calling Google News (see discovery.GoogleNews).
+	SaveRaw string
+	FromRaw string
+
+	// Sources are the discovery backends to query (SourceGoogle, SourceBing,
+	// SourceRSS); empty = all available.
+	Sources []string
+
+	// FeedBrowserHeaders sends browser-like headers to curated feeds.
+	FeedBrowserHeaders bool
+
+	// FeedMatch weighs keyword hits in feed titles vs descriptions.
+	FeedMatch discovery.MatchWeights
+
+	// ResumeTemplate is a text/template file (or "default") rendered next
+	// to the resume DOCX.
+	ResumeTemplate string
+
+	// ConsensusMethod is ConsensusCluster or ConsensusPairwise.
+	ConsensusMethod string
+
+	// ExtractBy picks the "top N" to extract: ExtractByRelevance,
+	// ExtractByCluster or ExtractByClusterDirect.
+	ExtractBy string
+
+	// ExtractMode is extract.ModeFull or extract.ModeMetadataOnly (title,
+	// site, date and language only: no text, translation or resume).
+	ExtractMode string
+
+	// SinceFile keeps only candidates newer than the previous run's.
+	SinceFile string
+
+	// Batch mode: run every query in QueriesFile non-interactively.
+	QueriesFile string
+	Batch       BatchOptions
+
+	// URLsFile skips discovery and extracts the listed URLs ("-" = stdin);
+	// Label stands in for the query. Uses Batch.Pivot and Batch.OutDir.
+	URLsFile string
+	Label    string
+}
+
+// concurrency is the per-stage concurrency the flags ask for.
+func (o cliOptions) concurrency() Concurrency {
+	return DeriveConcurrency(o.Parallelism).withOverrides(o.Concurrency)
+}
+
+func parseCLIOptions(args []string) (cliOptions, error) {
+	var opts cliOptions
+
+	fs := flag.NewFlagSet("newscheck", flag.ContinueOnError)
+	fs.IntVar(&opts.Filter.PivotBoost, "pivot-boost", 0, "relevance points added to matching candidates already in the pivot language (no translation needed); 0 = off, 10 = one keyword match")
+	fs.DurationVar(&opts.Filter.FreshnessFloor, "freshness", 0, "only keep candidates newer than this (e.g. 48h), within the time window; 0 = off")
+	fs.Func("max-age-per-source", "freshness floor per source kind, e.g. feed=24h,country=168h (feed = curated/direct RSS, country = per-country news search); default = the window for all", func(v string) error {
+		ages, err := parseMaxAgePerSource(v)
+		if err != nil {
+			return err
+		}
+		opts.Filter.MaxAgePerSource = ages
+		return nil
+	})
+	fs.IntVar(&opts.Filter.MinRelevance, "min-relevance", 0, "drop candidates whose relevance score is below this (a title keyword match is worth 10); 0 = keep any match")
+	fs.IntVar(&opts.Filter.MinSources, "min-sources", 1, "only keep stories covered by at least this many distinct publishers (same-story clusters); 1 = off")
+	fs.IntVar(&opts.Filter.MinResults, "min-results", 0, "with -min-relevance: keep the best candidates under the cutoff until there are at least this many")
+	fs.BoolVar(&opts.Filter.Stemming, "stem", false, "match inflected forms of query keywords in titles (vote ~ voting)")
+	fs.IntVar(&opts.MinArticleChars, "min-article-chars", DefaultMinArticleChars, "extracted articles shorter than this are left out of reports/resume; 0 = off")
+
+	fs.IntVar(&opts.Summary.MaxChars, "summary-max-chars", DefaultSummaryMaxChars, "cut each article's text to this many characters in the summarization input (reports keep the full text); 0 = no limit")
+	fs.IntVar(&opts.Summary.TailChars, "summary-tail-chars", 0, "with -summary-max-chars: keep this many of the characters from the end of the article")
+	fs.Float64Var(&opts.TimeoutEscalation, "timeout-escalation", extract.DefaultTimeoutEscalation, "retry a timed-out extraction once with its timeout multiplied by this; <= 1 disables the retry")
+	fs.DurationVar(&opts.DiscoveryTimeout, "discovery-timeout", 0, "overall time budget for discovery (e.g. 90s); slow sources get less time as it runs out; 0 = no limit")
+	fs.Func("dedupe", "how duplicate candidates are matched: canonical-url (default), exact-url, title or title-domain", func(v string) error {
+		d, err := ParseDedupeStrategy(v)
+		if err != nil {
+			return err
+		}
+		opts.Dedupe = d
+		return nil
+	})
+	fs.Func("feed-match", "weights of a query keyword found in an RSS item's title vs only its description, e.g. title=3,description=1 (the default); description=0 matches titles only", func(v string) error {
+		w, err := discovery.ParseMatchWeights(v)
+		opts.FeedMatch = w
+		return err
+	})
+	fs.BoolVar(&opts.FeedBrowserHeaders, "feed-browser-headers", false, "send curated feeds a full browser-like header set (Accept-Language, Sec-Fetch-*), which gets past some basic bot checks")
+	fs.Func("publisher", "search within one outlet by name, e.g. Reuters or \"Le Monde\" (Google News source: operator; other sources' results must match it). Unlike -prefer, everything else is dropped", func(v string) error {
+		pub, err := discovery.SanitizePublisher(v)
+		opts.Publisher = pub
+		return err
+	})
+	fs.Func("sources", "comma-separated discovery backends to query: google, bing, rss (default: all available; bing needs BING_NEWS_API_KEY), e.g. rss when Google News is blocking you", func(v string) error {
+		keys, err := ParseSourceKeys(v)
+		opts.Sources = keys
+		return err
+	})
+	fs.Func("tz", "time zone of custom date ranges: each date is a whole day in this zone, e.g. Europe/Paris or Local (default UTC)", func(v string) error {
+		loc, err := time.LoadLocation(v)
+		opts.Location = loc
+		return err
+	})
+	fs.StringVar(&opts.Format, "format", FormatText, "output: text, or urls to print only the final candidates' URLs to stdout, one per line in relevance order (prompts and progress go to stderr; no extraction in interactive runs)")
+	fs.StringVar(&opts.SaveRaw, "save-raw", "", "debugging: save every Google News RSS response in this folder, one file per query and target")
+	fs.StringVar(&opts.FromRaw, "from-raw", "", "debugging: replay the Google News responses saved with -save-raw from this folder instead of calling Google News (combine with -sources google and a custom date range for a fully offline, repeatable run)")
+	fs.Func("prefer", "comma-separated outlets to pull first (via their curated feeds) and rank higher on a match, e.g. reuters.com,lemonde.fr", func(v string) error {
+		opts.PreferredDomains = normalizeDomains(strings.Split(v, ","))
+		return nil
+	})
+	fs.Float64Var(&opts.MinCountryConfidence, "min-country-confidence", DefaultMinCountryConfidence, "auto scope: confidence (0-1) a detected country needs to drive the search; below it the search goes global")
+	fs.StringVar(&opts.QueryLang, "query-lang", "", "language of the query (ISO-639-1) for keyword lexicons and the default pivot; empty = detect")
+	fs.BoolVar(&opts.CollapseLocales, "collapse-locales", false, "query the English/pivot-language baseline once instead of once per country where it isn't a local language (fewer calls)")
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "always run discovery instead of reusing an identical search's candidates from the last "+DefaultCandidateCacheTTL.String())
+	fs.IntVar(&opts.Parallelism, "parallelism", DefaultParallelism, "overall concurrency: discovery runs 2x this many calls at once, country resolution 1x, extraction 1/4 (min 1)")
+	fs.IntVar(&opts.Concurrency.Discovery, "discovery-workers", 0, "concurrent discovery calls; 0 = derived from -parallelism")
+	fs.IntVar(&opts.Concurrency.Resolution, "resolve-workers", 0, "concurrent country lookups; 0 = derived from -parallelism")
+	fs.IntVar(&opts.Concurrency.Extraction, "extract-workers", 0, "concurrent article extractions (each runs a browser); 0 = derived from -parallelism")
+	fs.BoolVar(&opts.Explain, "explain", false, "print the intent/plan/target chain as JSON and exit without searching")
+	fs.BoolVar(&opts.Stream, "stream", false, "print candidates as each source returns (unfiltered, deduped), before the final ranked list")
+	fs.StringVar(&opts.Trace, "trace", "", "write a JSON trace of the search (request, intent, countries, targets, plans, raw and filtered candidates, source errors, timings) to this file")
+
+	fs.StringVar(&opts.ResumeTemplate, "resume-template", "", "also render the resume through this Go text/template file (.md/.html/.txt); \"default\" = built-in Markdown")
+	fs.BoolVar(&opts.ResumeExtraction, "resume-extraction", false, "after a crash or Ctrl+C, rerun with the same selection to reuse the articles already extracted instead of starting over")
+	fs.BoolVar(&opts.KeyActors, "key-actors", false, "add a \"Key actors\" section to the resume: the people, organizations and places most mentioned across the extracted articles")
+	fs.BoolVar(&opts.IncludeOriginal, "include-original", false, "when translating to the pivot language, also put each article's original text in the report")
+	fs.StringVar(&opts.ConsensusMethod, "consensus", ConsensusCluster, "consensus score: cluster (distinct publishers covering the story) or pairwise (every matching candidate, duplicates included)")
+	fs.StringVar(&opts.ExtractBy, "extract-by", ExtractByRelevance, "how the top N articles to extract are chosen: relevance, cluster (one per same-story cluster, largest first) or cluster-direct (same, preferring direct publisher links over Google News ones)")
+	fs.StringVar(&opts.ExtractMode, "extract-mode", extract.ModeFull, "full, or metadata-only: fetch just each article's title, site, date, author and language (no text, translation or resume), much faster for a link list of many URLs")
+	fs.StringVar(&opts.SinceFile, "since-file", "", "only show candidates published after the previous run of the same query (frontier stored in this file)")
+	fs.StringVar(&opts.QueriesFile, "queries-file", "", "run every query in this file (text, one per line, or JSON) without prompts")
+	fs.IntVar(&opts.Batch.Days, "days", 7, "with -queries-file: search window in days")
+	fs.StringVar(&opts.Batch.Scope, "scope", "auto", "with -queries-file: auto, global or a country name")
+	fs.StringVar(&opts.URLsFile, "urls-file", "", "skip discovery: extract and summarize the URLs in this file (one per line, - for stdin)")
+	fs.StringVar(&opts.Label, "label", "", "with -urls-file or a pasted-URL query: query/label used for the summary and reports")
+	fs.StringVar(&opts.Batch.OutDir, "out-dir", "reports/batch", "with -queries-file, -urls-file or a pasted-URL query: where report folders are written")
+	fs.IntVar(&opts.Batch.Extract, "extract", 0, "with -queries-file: extract and summarize the top N candidates per query")
+	fs.StringVar(&opts.Batch.Pivot, "pivot", "en", "with -queries-file or -urls-file: pivot language for extraction")
+
+	fs.Func("global-targets", "anchor locales for worldwide searches, e.g. US:en,GB:en,FR:fr (default: "+formatTargets(DefaultGlobalTargets)+")", func(v string) error {
+		t, err := parseTargetList(v)
+		if err != nil {
+			return err
+		}
+		opts.GlobalTargets = t
+		return nil
+	})
+
+	fs.StringVar(&opts.Locale, "locale", "", "default locale for worldwide searches, e.g. en-US or fr-FR: searched first, replacing the other anchors in its language")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if opts.Locale != "" {
+		loc, err := discovery.ParseLocale(opts.Locale)
+		if err != nil {
+			return opts, err
+		}
+		opts.GlobalTargets = withLocale(opts.GlobalTargets, loc)
+	}
+	if opts.DiscoveryTimeout < 0 {
+		return opts, fmt.Errorf("-discovery-timeout must not be negative")
+	}
+	switch opts.ExtractBy {
+	case ExtractByRelevance, ExtractByCluster, ExtractByClusterDirect:
+	default:
+		return opts, fmt.Errorf("-extract-by must be %q, %q or %q", ExtractByRelevance, ExtractByCluster, ExtractByClusterDirect)
+	}
+	opts.Batch.ExtractBy = opts.ExtractBy
+	if opts.ExtractMode != extract.ModeFull && opts.ExtractMode != extract.ModeMetadataOnly {
+		return opts, fmt.Errorf("-extract-mode must be %q or %q", extract.ModeFull, extract.ModeMetadataOnly)
+	}
+	if opts.ConsensusMethod != ConsensusCluster && opts.ConsensusMethod != ConsensusPairwise {
+		return opts, fmt.Errorf("-consensus must be %q or %q", ConsensusCluster, ConsensusPairwise)
+	}
+	if opts.Format != FormatText && opts.Format != FormatURLs {
+		return opts, fmt.Errorf("-format must be %q or %q", FormatText, FormatURLs)
+	}
+	if opts.Format == FormatURLs && (opts.Explain || opts.URLsFile != "") {
+		return opts, fmt.Errorf("-format urls lists search results; it can't be used with -explain or -urls-file")
+	}
+	if opts.SaveRaw != "" && opts.FromRaw != "" {
+		return opts, fmt.Errorf("-save-raw and -from-raw are mutually exclusive")
+	}
+	if opts.URLsFile != "" && opts.QueriesFile != "" {
+		return opts, fmt.Errorf("-urls-file and -queries-file are mutually exclusive")
+	}
+	if opts.Batch.Extract < 0 {
+		return opts, fmt.Errorf("-extract must not be negative")
+	}
+	if opts.Filter.MinSources < 0 {
+		return opts, fmt.Errorf("-min-sources must not be negative")
+	}
+	if opts.Filter.MinRelevance < 0 || opts.Filter.MinResults < 0 {
+		return opts, fmt.Errorf("-min-relevance and -min-results must not be negative")
+	}
+	if opts.MinCountryConfidence < 0 || opts.MinCountryConfidence > 1 {
+		return opts, fmt.Errorf("-min-country-confidence must be between 0 and 1")
+	}
+	if opts.Parallelism < 1 {
+		return opts, fmt.Errorf("-parallelism must be at least 1")
+	}
+	if opts.Concurrency.Discovery < 0 || opts.Concurrency.Resolution < 0 || opts.Concurrency.Extraction < 0 {
+		return opts, fmt.Errorf("-discovery-workers, -resolve-workers and -extract-workers must not be negative")
+	}
+	if opts.QueryLang != "" {
+		opts.QueryLang = strings.ToLower(strings.TrimSpace(opts.QueryLang))
+		if len(opts.QueryLang) != 2 {
+			return opts, fmt.Errorf("-query-lang must be a two-letter ISO-639-1 code, got %q", opts.QueryLang)
+		}
+	}
+	if opts.Summary.MaxChars < 0 || opts.Summary.TailChars < 0 {
+		return opts, fmt.Errorf("-summary-max-chars and -summary-tail-chars must not be negative")
+	}
+	if opts.Filter.PivotBoost < 0 {
+		return opts, fmt.Errorf("-pivot-boost must not be negative")
+	}
+	if opts.Filter.FreshnessFloor < 0 {
+		return opts, fmt.Errorf("-freshness must not be negative")
+	}
+	return opts, nil
+}
+
+// parseTargetList parses "US:en,GB:en" into discovery targets.
+func parseTargetList(s string) ([]geo.DiscoveryTarget, error) {
+	var out []geo.DiscoveryTarget
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		iso2, lang, ok := strings.Cut(part, ":")
+		iso2, lang = strings.ToUpper(strings.TrimSpace(iso2)), strings.ToLower(strings.TrimSpace(lang))
+		if !ok || len(iso2) != 2 || lang == "" {
+			return nil, fmt.Errorf("invalid target %q (want ISO2:lang, e.g. GB:en)", part)
+		}
+		out = append(out, geo.DiscoveryTarget{ISO2: iso2, Lang: lang})
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no targets given")
+	}
+	return out, nil
+}
+
+func formatTargets(targets []geo.DiscoveryTarget) string {
+	parts := make([]string, len(targets))
+	for i, t := range targets {
+		parts[i] = t.ISO2 + ":" + t.Lang
+	}
+	return strings.Join(parts, ",")
+}
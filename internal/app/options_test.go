@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestLocaleReachesGoogleNews(t *testing.T) {
+	opts, err := parseCLIOptions([]string{"-locale", "en_au"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTargets(opts.GlobalTargets); got != "AU:en,FR:fr,DE:de,BR:pt" {
+		t.Errorf("GlobalTargets = %s, want AU:en first and no other English anchor", got)
+	}
+
+	var mu sync.Mutex
+	var params []string
+	g := discovery.NewGoogleNews()
+	g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		mu.Lock()
+		params = append(params, q.Get("hl")+" "+q.Get("gl")+" "+q.Get("ceid"))
+		mu.Unlock()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`<rss><channel></channel></rss>`)), Request: r}, nil
+	})}
+	plans := []SearchPlan{{Query: "port strike", Scope: "global", Weight: 1}}
+	_, errs, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), buildTargets(nil, opts.GlobalTargets),
+		[]DiscoverySource{{Source: g, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil)
+	if err != nil || len(errs) > 0 {
+		t.Fatalf("err = %v, source errors = %v", err, errs)
+	}
+
+	sort.Strings(params)
+	var english []string
+	for _, p := range params {
+		if strings.HasPrefix(p, "en-") {
+			english = append(english, p)
+		}
+	}
+	if len(english) != 1 || english[0] != "en-AU AU AU:en" {
+		t.Errorf("English calls = %v (all: %v), want one with en-AU AU AU:en", english, params)
+	}
+}
+
+func TestLocaleRejectsBadFormat(t *testing.T) {
+	for _, loc := range []string{"en", "english-US", "en-USA", "e1-US"} {
+		if _, err := parseCLIOptions([]string{"-locale", loc}); err == nil {
+			t.Errorf("-locale %q accepted", loc)
+		}
+	}
+}
+
+func TestBuildTargetsGlobalAnchors(t *testing.T) {
+	got := buildTargets(nil, nil)
+	if len(got) != len(DefaultGlobalTargets) {
+		t.Fatalf("got %d targets, want the %d default anchors", len(got), len(DefaultGlobalTargets))
+	}
+	countries, langs := map[string]bool{}, map[string]bool{}
+	for _, tg := range got {
+		if !tg.Global {
+			t.Errorf("%s/%s not marked Global", tg.ISO2, tg.Lang)
+		}
+		countries[tg.ISO2], langs[tg.Lang] = true, true
+	}
+	if len(countries) < 4 || len(langs) < 3 {
+		t.Errorf("anchors %s span %d countries and %d languages, want a diverse set", formatTargets(got), len(countries), len(langs))
+	}
+
+	custom, err := parseCLIOptions([]string{"-global-targets", "jp:ja, KE:sw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := formatTargets(buildTargets(nil, custom.GlobalTargets)); got != "JP:ja,KE:sw" {
+		t.Errorf("-global-targets anchors = %s, want JP:ja,KE:sw", got)
+	}
+
+	if got := buildTargets(nil, []geo.DiscoveryTarget{}); len(got) != 1 || got[0].ISO2 != "US" || got[0].Lang != "en" {
+		t.Errorf("empty anchor set = %v, want the US/en last resort", got)
+	}
+	if _, err := parseCLIOptions([]string{"-global-targets", "USA:en"}); err == nil {
+		t.Error("-global-targets USA:en accepted")
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestTiebreakOrder(t *testing.T) {
+	old := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC) // no recency credit at all
+	now := time.Now()                                  // over 24h old: no recency boost in the score
+	at := func(c discovery.Candidate, pub time.Time) discovery.Candidate {
+		c.PublishedAt = pub
+		return c
+	}
+	weights := map[string]float64{"reuters.com": 1, "bbc.co.uk": 0.5}
+
+	tests := []struct {
+		name string
+		in   []discovery.Candidate
+		want []string
+	}{
+		{
+			name: "newer first",
+			in: []discovery.Candidate{
+				at(direct("https://example.com/1", "Port strike spreads"), now.Add(-40*time.Hour)),
+				at(direct("https://example.com/2", "Port strike spreads"), now.Add(-30*time.Hour)),
+			},
+			want: []string{"https://example.com/2", "https://example.com/1"},
+		},
+		{
+			name: "same time, heavier source first",
+			in: []discovery.Candidate{
+				at(direct("https://example.com/1", "Port strike spreads"), old),
+				at(direct("https://www.bbc.co.uk/1", "Port strike spreads"), old),
+				at(direct("https://www.reuters.com/1", "Port strike spreads"), old),
+			},
+			want: []string{"https://www.reuters.com/1", "https://www.bbc.co.uk/1", "https://example.com/1"},
+		},
+		{
+			name: "source weight applies behind a wrapper",
+			in: []discovery.Candidate{
+				at(wrapped("1", "Port strike spreads", "Ouest-France"), old),
+				at(wrapped("2", "Port strike spreads", "Reuters"), old),
+			},
+			want: []string{"https://news.google.com/rss/articles/2", "https://news.google.com/rss/articles/1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := FilterOptions{Order: OrderWeights{Recency: 1, Source: 1, Sources: weights}}
+			got := urlsOf(filterCandidates(tt.in, "port strike", Intent{}, nil, opts))
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import "sync"
+
+// DefaultParallelism is the -parallelism default.
+const DefaultParallelism = 4
+
+// Concurrency is how many calls each pipeline stage runs at once. A zero
+// stage runs sequentially.
+type Concurrency struct {
+	Discovery  int `json:"discovery"`  // source calls (one per plan and locale)
+	Resolution int `json:"resolution"` // country-name lookups
+	Extraction int `json:"extraction"` // worker processes extracting articles
+}
+
+// DeriveConcurrency turns the single parallelism dial p into per-stage
+// limits, weighted by what each stage costs:
+//
+//	discovery  = 2p          small feed/API requests, mostly waiting on the network
+//	resolution = p           dataset/cache lookups, occasionally a REST call
+//	extraction = p/4, min 1  each call starts a Python worker with a browser
+//
+// p <= 0 means DefaultParallelism. So the default 4 gives 8/4/1 and 16
+// gives 32/16/4.
+func DeriveConcurrency(p int) Concurrency {
+	if p <= 0 {
+		p = DefaultParallelism
+	}
+	return Concurrency{
+		Discovery:  2 * p,
+		Resolution: p,
+		Extraction: max(1, p/4),
+	}
+}
+
+// withOverrides replaces the stages that o sets (> 0).
+func (c Concurrency) withOverrides(o Concurrency) Concurrency {
+	if o.Discovery > 0 {
+		c.Discovery = o.Discovery
+	}
+	if o.Resolution > 0 {
+		c.Resolution = o.Resolution
+	}
+	if o.Extraction > 0 {
+		c.Extraction = o.Extraction
+	}
+	return c
+}
+
+// forEachLimit calls fn(i) for every i in [0, n), at most workers at a time
+// (< 1 = one at a time), and returns when all are done.
+func forEachLimit(n, workers int, fn func(i int)) {
+	if workers <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
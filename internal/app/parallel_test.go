@@ -0,0 +1,70 @@
+package app
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeriveConcurrency(t *testing.T) {
+	tests := []struct {
+		p    int
+		want Concurrency
+	}{
+		{0, Concurrency{Discovery: 2 * DefaultParallelism, Resolution: DefaultParallelism, Extraction: max(1, DefaultParallelism/4)}},
+		{-3, DeriveConcurrency(DefaultParallelism)},
+		{1, Concurrency{Discovery: 2, Resolution: 1, Extraction: 1}},
+		{4, Concurrency{Discovery: 8, Resolution: 4, Extraction: 1}},
+		{16, Concurrency{Discovery: 32, Resolution: 16, Extraction: 4}},
+	}
+	for _, tt := range tests {
+		if got := DeriveConcurrency(tt.p); got != tt.want {
+			t.Errorf("DeriveConcurrency(%d) = %+v, want %+v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestConcurrencyFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want Concurrency
+	}{
+		{[]string{"-parallelism", "16"}, Concurrency{Discovery: 32, Resolution: 16, Extraction: 4}},
+		{[]string{"-parallelism", "16", "-extract-workers", "2"}, Concurrency{Discovery: 32, Resolution: 16, Extraction: 2}},
+		{[]string{"-discovery-workers", "3"}, DeriveConcurrency(DefaultParallelism).withOverrides(Concurrency{Discovery: 3})},
+	}
+	for _, tt := range tests {
+		opts, err := parseCLIOptions(tt.args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := opts.concurrency(); got != tt.want {
+			t.Errorf("%v: concurrency = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+	if _, err := parseCLIOptions([]string{"-resolve-workers", "-1"}); err == nil {
+		t.Error("negative -resolve-workers accepted")
+	}
+}
+
+func TestForEachLimit(t *testing.T) {
+	var running, peak, done atomic.Int32
+	forEachLimit(12, 3, func(int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+	})
+	if done.Load() != 12 {
+		t.Errorf("ran %d of 12", done.Load())
+	}
+	if p := peak.Load(); p > 3 {
+		t.Errorf("peak concurrency %d, want at most 3", p)
+	}
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/langdetect"
+)
+
+// PivotLanguage is a translation target the worker accepts via --target-lang.
+type PivotLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// PivotLangsEnv restricts (or extends) the allowed pivot codes, e.g. "en,fr,es".
+// Codes not in DefaultPivotLanguages are allowed and shown by code only.
+const PivotLangsEnv = "NEWSCHECK_PIVOT_LANGS"
+
+// DefaultPivotLanguages are translator codes the Python worker handles well.
+var DefaultPivotLanguages = []PivotLanguage{
+	{"en", "English"},
+	{"fr", "French"},
+	{"es", "Spanish"},
+	{"de", "German"},
+	{"it", "Italian"},
+	{"pt", "Portuguese"},
+	{"nl", "Dutch"},
+	{"pl", "Polish"},
+	{"ru", "Russian"},
+	{"uk", "Ukrainian"},
+	{"tr", "Turkish"},
+	{"ar", "Arabic"},
+	{"hi", "Hindi"},
+	{"ja", "Japanese"},
+	{"ko", "Korean"},
+	{"zh-CN", "Chinese (Simplified)"},
+}
+
+// PivotLanguages returns the allowed pivot languages: PivotLangsEnv when set,
+// otherwise DefaultPivotLanguages.
+func PivotLanguages() []PivotLanguage {
+	env := strings.TrimSpace(os.Getenv(PivotLangsEnv))
+	if env == "" {
+		return DefaultPivotLanguages
+	}
+	var out []PivotLanguage
+	for _, code := range strings.Split(env, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		l := PivotLanguage{Code: code, Name: code}
+		for _, d := range DefaultPivotLanguages {
+			if strings.EqualFold(d.Code, code) {
+				l = d
+				break
+			}
+		}
+		out = append(out, l)
+	}
+	if len(out) == 0 {
+		return DefaultPivotLanguages
+	}
+	return out
+}
+
+// ValidatePivotLang returns the canonical spelling of code if it is an
+// allowed pivot language. Empty means English.
+func ValidatePivotLang(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return "en", nil
+	}
+	for _, l := range PivotLanguages() {
+		if strings.EqualFold(l.Code, code) {
+			return l.Code, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported pivot language %q (set %s to allow it)", code, PivotLangsEnv)
+}
+
+// candidateLang is c's language: its discovery target's, else detected
+// from the title and description ("" when unsure).
+func candidateLang(c discovery.Candidate) string {
+	if c.TargetLang != "" {
+		return c.TargetLang
+	}
+	lang, _ := langdetect.Detect(c.Title + ". " + c.Description)
+	return lang
+}
+
+// sameLang compares language codes by their base ("zh-CN" ~ "zh").
+func sameLang(a, b string) bool {
+	a, _, _ = strings.Cut(strings.ToLower(a), "-")
+	b, _, _ = strings.Cut(strings.ToLower(b), "-")
+	return a != "" && a == b
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"newscheck/internal/extract"
+)
+
+func TestValidatePivotLang(t *testing.T) {
+	tests := []struct {
+		env, code, want string
+		wantErr         bool
+	}{
+		{code: "", want: "en"},
+		{code: "es", want: "es"},
+		{code: " JA ", want: "ja"},
+		{code: "zh-cn", want: "zh-CN"},
+		{code: "xx", wantErr: true},
+		{env: "en, sw", code: "sw", want: "sw"},
+		{env: "en, sw", code: "fr", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Setenv(PivotLangsEnv, tt.env)
+		got, err := ValidatePivotLang(tt.code)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s=%q: ValidatePivotLang(%q) = %q, %v; want %q, error %v", PivotLangsEnv, tt.env, tt.code, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPivotLanguagesFromEnv(t *testing.T) {
+	t.Setenv(PivotLangsEnv, "de,,sw ")
+	got := PivotLanguages()
+	if len(got) != 2 || got[0] != (PivotLanguage{"de", "German"}) || got[1] != (PivotLanguage{"sw", "sw"}) {
+		t.Errorf("PivotLanguages = %v, want German by name and sw by code", got)
+	}
+}
+
+// TestPivotReachesWorker checks that a non-default pivot is passed to the
+// worker's --target-lang unchanged.
+func TestPivotReachesWorker(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	script := filepath.Join(t.TempDir(), "worker.sh")
+	body := `printf '{"ok": true, "data": {"url": "%s", "title": "translated to %s", "text": "Dockers walked out."}}' "$2" "$3"`
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	svc := &Service{
+		Worker:      &extract.Worker{Command: []string{"sh", script, "{mode}", "{url}", "{target_lang}"}},
+		Concurrency: Concurrency{Extraction: 1},
+	}
+	pivot, err := ValidatePivotLang("pt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := svc.ExtractAll(context.Background(), []string{"https://a.example/1"}, pivot, nil)
+	if len(out) != 1 || !out[0].OK || out[0].Article.Title != "translated to pt" {
+		t.Errorf("outcome = %+v, want the worker to get target lang pt", out)
+	}
+}
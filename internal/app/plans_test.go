@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+func TestBuildSearchPlansRegionExpansion(t *testing.T) {
+	tests := []struct {
+		name    string
+		intent  Intent
+		regions geo.Regions
+		want    []string // expanded scopes, sorted
+	}{
+		{
+			name:    "default table",
+			intent:  Intent{Regions: []string{"Caribbean"}},
+			regions: nil,
+			want:    []string{"country:Cuba", "country:Dominican Republic", "country:Haiti", "country:Jamaica"},
+		},
+		{
+			name:    "service table",
+			intent:  Intent{Regions: []string{"Caribbean"}},
+			regions: geo.Regions{"Caribbean": {"Barbados", "Grenada"}},
+			want:    []string{"country:Barbados", "country:Grenada"},
+		},
+		{
+			name:    "region only in the service table",
+			intent:  Intent{Regions: []string{"Nordics"}},
+			regions: geo.Regions{"Nordics": {"Norway"}},
+			want:    []string{"country:Norway"},
+		},
+		{
+			name:    "named country wins",
+			intent:  Intent{Regions: []string{"Caribbean"}, Countries: []string{"Cuba"}},
+			regions: geo.Regions{"Caribbean": {"Barbados"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range BuildSearchPlans("port strike", tt.intent, nil, tt.regions, nil) {
+				if p.Explain == "country expansion from region" {
+					got = append(got, p.Scope)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expanded scopes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForcedCountryQueriesByName(t *testing.T) {
+	venezuela := geo.CountryInfo{Name: "Venezuela", ISO2: "VE", Languages: []string{"es"}}
+	plans := BuildSearchPlans("oil exports", Intent{}, []geo.CountryInfo{venezuela}, nil, nil)
+	if len(plans) == 0 || plans[0].Scope != "country:VE" || plans[0].ScopeTerm != "Venezuela" {
+		t.Fatalf("first plan = %+v, want scope country:VE searched as Venezuela", plans)
+	}
+
+	var mu sync.Mutex
+	var queries []string
+	g := discovery.NewGoogleNews()
+	g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		queries = append(queries, r.URL.Query().Get("q"))
+		mu.Unlock()
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`<rss><channel></channel></rss>`)), Request: r}, nil
+	})}
+	targets := []geo.DiscoveryTarget{{ISO2: "VE", Lang: "es"}}
+	if _, _, err := runDiscoveryWithTargets(context.Background(), plans[:1], lastWeek(), targets,
+		[]DiscoverySource{{Source: g, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 || queries[0] != "oil exports Venezuela" {
+		t.Errorf("queries = %q, want the country name appended, not VE", queries)
+	}
+}
+
+func TestWeightedPlanLimits(t *testing.T) {
+	plan := func(w int) SearchPlan { return SearchPlan{Query: "q", Weight: w} }
+	tests := []struct {
+		name       string
+		plans      []SearchPlan
+		perPlan    int
+		minPerPlan int
+		want       []int
+	}{
+		{"proportional to weight", []SearchPlan{plan(100), plan(75)}, 25, 0, []int{28, 21}},
+		{"equal weights split evenly", []SearchPlan{plan(80), plan(80), plan(80)}, 10, 0, []int{10, 10, 10}},
+		{"minimum per plan", []SearchPlan{plan(100), plan(1)}, 10, 5, []int{19, 5}},
+		{"no weights fall back to perPlan", []SearchPlan{plan(0), plan(0)}, 10, 0, []int{10, 10}},
+		{"negative weight counts as zero", []SearchPlan{plan(100), plan(-50)}, 10, 2, []int{20, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weightedPlanLimits(tt.plans, tt.perPlan, tt.minPerPlan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("weightedPlanLimits = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighWeightPlanFetchesMore(t *testing.T) {
+	plans := []SearchPlan{
+		{Query: "port strike", Scope: "global", Weight: 100},
+		{Query: "port strike economy", Scope: "global", Weight: 75},
+	}
+	src := &fakeSource{}
+	if _, _, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}},
+		[]DiscoverySource{{Source: src, PerPlan: 25, MinPerPlan: 5}}, DedupeCanonicalURL, 1, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	strong, weak := src.limits["port strike"], src.limits["port strike economy"]
+	if strong <= weak || weak < 5 {
+		t.Errorf("limits: original %d, expansion %d; want the original higher and both at least 5", strong, weak)
+	}
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"net/url"
+	"strings"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+// preferredBoost is added to a preferred-domain candidate that matches the
+// query. It never creates a match on its own.
+const preferredBoost = 8
+
+// normalizeDomains reduces "https://www.Reuters.com/world" and friends to
+// "reuters.com", dropping empties and repeats.
+func normalizeDomains(in []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, d := range in {
+		d = strings.ToLower(strings.TrimSpace(d))
+		if strings.Contains(d, "://") {
+			if u, err := url.Parse(d); err == nil {
+				d = u.Hostname()
+			}
+		}
+		d, _, _ = strings.Cut(d, "/")
+		d = strings.Trim(strings.TrimPrefix(d, "www."), ".")
+		if d == "" || seen[d] {
+			continue
+		}
+		seen[d] = true
+		out = append(out, d)
+	}
+	return out
+}
+
+// isPreferred reports whether c was published on one of domains or a
+// subdomain of one.
+func isPreferred(c discovery.Candidate, domains []string) bool {
+	return publishedOn(c, domains)
+}
+
+// publishedOn reports whether c's publisher is one of domains or a
+// subdomain of one. A Google News wrapper is judged by its outlet (see
+// candidatePublisher); an outlet known only by name matches a domain whose
+// leading label spells the same name ("Ouest-France", "ouest-france.fr").
+func publishedOn(c discovery.Candidate, domains []string) bool {
+	if len(domains) == 0 {
+		return false
+	}
+	wrapper := discovery.IsWrapperURL(c.URL)
+	var host string
+	if wrapper {
+		host = candidatePublisher(c)
+	} else {
+		u, err := url.Parse(strings.TrimSpace(c.URL))
+		if err != nil {
+			return false
+		}
+		host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	}
+	if host == "" {
+		return false
+	}
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+		if label, _, _ := strings.Cut(d, "."); wrapper && squashName(host) == squashName(label) {
+			return true
+		}
+	}
+	return false
+}
+
+// squashName drops the spacing and punctuation that differ between an
+// outlet's name and its domain label.
+func squashName(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "'", "", "’", "", "&", "", ".", "").Replace(s)
+}
+
+// preferredSource pulls the curated feeds of the preferred domains that the
+// run's targets wouldn't pull anyway. False when there are none.
+func (s *Service) preferredSource(domains []string, targets []geo.DiscoveryTarget) (DiscoverySource, bool) {
+	if len(domains) == 0 {
+		return DiscoverySource{}, false
+	}
+	langs := make([]string, 0, len(targets))
+	for _, t := range targets {
+		langs = append(langs, t.Lang)
+	}
+	for _, ds := range s.Sources {
+		curated, ok := ds.Source.(*discovery.CuratedFeeds)
+		if !ok {
+			continue
+		}
+		feeds := curated.FeedsForDomains(domains, curated.ForLanguages(langs))
+		if len(feeds) == 0 {
+			return DiscoverySource{}, false
+		}
+		return DiscoverySource{
+			Source:     &discovery.CuratedFeeds{World: curated.World.WithFeeds(feeds)},
+			PerPlan:    ds.PerPlan,
+			MinPerPlan: ds.MinPerPlan,
+		}, true
+	}
+	return DiscoverySource{}, false
+}
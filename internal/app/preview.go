@@ -0,0 +1,63 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/gingfrederik/docx"
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+)
+
+const (
+	cliPreviewChars    = 250 // printed after each extraction
+	reportPreviewChars = 300 // under each candidate in the scores report
+)
+
+// articlePreview returns the first paragraph of text, cut to max runes.
+func articlePreview(text string, max int) string {
+	text = strings.TrimSpace(text)
+	if i := strings.Index(text, "\n\n"); i > 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if r := []rune(text); len(r) > max {
+		return string(r[:max]) + "..."
+	}
+	return text
+}
+
+// articlePreviews maps candidate URLs (canonicalized, both the requested and
+// final URL) to a preview of the extracted text. Low-quality stubs are left
+// out. Returns nil when there is nothing to preview.
+func articlePreviews(articles []extract.Article) map[string]string {
+	var out map[string]string
+	for _, a := range articles {
+		if a.LowQuality {
+			continue
+		}
+		pv := articlePreview(a.Text, reportPreviewChars)
+		if pv == "" {
+			continue
+		}
+		if out == nil {
+			out = map[string]string{}
+		}
+		for _, u := range []string{a.URL, a.FinalURL} {
+			if strings.TrimSpace(u) != "" {
+				out[discovery.CanonicalizeURL(u)] = pv
+			}
+		}
+	}
+	return out
+}
+
+// addPreviewParagraph writes the extracted preview for c, if there is one.
+func addPreviewParagraph(f *docx.File, previews map[string]string, c discovery.Candidate) {
+	pv, ok := previews[discovery.CanonicalizeURL(c.URL)]
+	if !ok {
+		return
+	}
+	run := f.AddParagraph().AddText(pv)
+	run.Size(10)
+	run.Color("555555")
+}
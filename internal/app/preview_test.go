@@ -0,0 +1,92 @@
+package app
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+)
+
+func TestArticlePreview(t *testing.T) {
+	tests := []struct {
+		text string
+		max  int
+		want string
+	}{
+		{"  Dockers walked out.\n\nSecond paragraph.", 100, "Dockers walked out."},
+		{"Dockers   walked\nout.", 100, "Dockers walked out."},
+		{"Dockers walked out.", 7, "Dockers..."},
+		{"Grève générale", 5, "Grève..."},
+		{"  ", 100, ""},
+	}
+	for _, tt := range tests {
+		if got := articlePreview(tt.text, tt.max); got != tt.want {
+			t.Errorf("articlePreview(%q, %d) = %q, want %q", tt.text, tt.max, got, tt.want)
+		}
+	}
+}
+
+// docxText returns the raw document XML of a .docx file.
+func docxText(t *testing.T, path string) string {
+	t.Helper()
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		b, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	t.Fatalf("%s has no word/document.xml", path)
+	return ""
+}
+
+func TestScoresReportPreviews(t *testing.T) {
+	svc := &Service{Consensus: DefaultConsensusConfig()}
+	cands := []discovery.Candidate{
+		{URL: "https://a.example/1?utm_source=x", Title: "Port strike spreads"},
+		{URL: "https://b.example/2", Title: "Port strike ends"},
+		{URL: "https://c.example/3", Title: "Port strike stub"},
+	}
+	arts := []extract.Article{
+		{URL: "https://a.example/1", Title: "Port strike spreads", Text: "Dockers walked out at every major port.\n\nMore later."},
+		{URL: "https://c.example/3", Title: "Port strike stub", Text: "Subscribe to read.", LowQuality: true},
+	}
+
+	path := filepath.Join(t.TempDir(), "scores.docx")
+	if err := svc.GenerateScoresReport(path, cands, arts); err != nil {
+		t.Fatal(err)
+	}
+	doc := docxText(t, path)
+	if !strings.Contains(doc, "Dockers walked out at every major port.") {
+		t.Error("extracted candidate has no preview")
+	}
+	if strings.Contains(doc, "More later.") || strings.Contains(doc, "Subscribe to read.") {
+		t.Error("preview went past the first paragraph or included a low-quality stub")
+	}
+
+	// No extraction: no previews
+	path = filepath.Join(t.TempDir(), "scores.docx")
+	if err := svc.GenerateScoresReport(path, cands, nil); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(docxText(t, path), "Dockers walked out") {
+		t.Error("preview without extraction")
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"newscheck/internal/extract"
+)
+
+// DefaultMinArticleChars is the shortest extracted text considered a real
+// article. Shorter bodies are usually paywall teasers or JS-only shells.
+const DefaultMinArticleChars = 200
+
+// markLowQuality flags articles whose text is shorter than minChars and
+// returns the ones fit for the resume/report. Flagged articles stay in the
+// input slice (with LowQuality set) so JSON consumers still see them.
+// minChars <= 0 disables the check. Metadata-only articles have no text to
+// check and are kept.
+func markLowQuality(articles []extract.Article, minChars int) []extract.Article {
+	if minChars <= 0 {
+		return articles
+	}
+	usable := make([]extract.Article, 0, len(articles))
+	for i := range articles {
+		if articles[i].MetadataOnly {
+			usable = append(usable, articles[i])
+			continue
+		}
+		n := utf8.RuneCountInString(articles[i].Text)
+		if n < minChars {
+			articles[i].LowQuality = true
+			articles[i].QualityReason = fmt.Sprintf("text too short (%d < %d chars)", n, minChars)
+			fmt.Fprintf(os.Stderr, "Dropped from resume: %s (%s)\n", articleLabel(articles[i]), articles[i].QualityReason)
+			continue
+		}
+		usable = append(usable, articles[i])
+	}
+	return usable
+}
+
+func articleLabel(a extract.Article) string {
+	if a.FinalURL != "" {
+		return a.FinalURL
+	}
+	return a.URL
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"newscheck/internal/extract"
+)
+
+func TestMarkLowQuality(t *testing.T) {
+	long := strings.Repeat("Dockers walked out at every major port. ", 10)
+	articles := []extract.Article{
+		{URL: "https://a.example/1", Text: long},
+		{URL: "https://a.example/2", Text: "Subscribe to read the full story."},
+		{URL: "https://a.example/3", MetadataOnly: true},
+	}
+
+	usable := markLowQuality(articles, DefaultMinArticleChars)
+	if len(usable) != 2 || usable[0].URL != "https://a.example/1" || usable[1].URL != "https://a.example/3" {
+		t.Errorf("usable = %+v, want the full article and the metadata-only one", usable)
+	}
+	stub := articles[1]
+	if !stub.LowQuality || !strings.Contains(stub.QualityReason, "too short") {
+		t.Errorf("stub = %+v, want it flagged with a reason", stub)
+	}
+	if articles[0].LowQuality || articles[2].LowQuality {
+		t.Errorf("good articles flagged: %+v", articles)
+	}
+
+	// Flagged articles stay visible to JSON consumers
+	b, err := json.Marshal(stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"low_quality":true`) {
+		t.Errorf("JSON %s lacks the low_quality flag", b)
+	}
+
+	if got := markLowQuality([]extract.Article{{Text: "short"}}, 0); len(got) != 1 {
+		t.Errorf("minChars 0 dropped an article")
+	}
+}
+
+func TestStubArticlesSkipTheSummary(t *testing.T) {
+	svc := &Service{Worker: fakeWorker(t), Concurrency: Concurrency{Extraction: 1}, MinArticleChars: DefaultMinArticleChars}
+	articles, summary, err := svc.ExtractAndSummarize(context.Background(), []string{"https://a.example/1"}, "", "port strike", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(articles) != 1 || !articles[0].LowQuality {
+		t.Fatalf("articles = %+v, want the stub returned and flagged", articles)
+	}
+	if summary != "" {
+		t.Errorf("summary = %q, want none from a stub", summary)
+	}
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+)
+
+func TestGenerateReports(t *testing.T) {
+	svc := &Service{Consensus: DefaultConsensusConfig()}
+	arts := []extract.Article{{URL: "https://a/1", FinalURL: "https://a/1", Title: "Port strike", Site: "a", Text: "Dockers walked out."}}
+	cands := []discovery.Candidate{{URL: "https://a/1", Title: "Port strike"}}
+
+	tests := []struct {
+		name     string
+		articles []extract.Article
+		cands    []discovery.Candidate
+		wantDirs []string
+	}{
+		{name: "both", articles: arts, cands: cands, wantDirs: []string{"reports/", "scores/"}},
+		{name: "candidates only", cands: cands, wantDirs: []string{"scores/"}},
+		{name: "nothing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			var out bytes.Buffer
+			written, err := generateReports(&out, svc, tt.articles, tt.cands)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(written) != len(tt.wantDirs) {
+				t.Fatalf("wrote %v, want files in %v", written, tt.wantDirs)
+			}
+			for i, path := range written {
+				if !strings.HasPrefix(path, tt.wantDirs[i]) {
+					t.Errorf("file %d = %s, want it in %s", i, path, tt.wantDirs[i])
+				}
+				if _, err := os.Stat(path); err != nil {
+					t.Error(err)
+				}
+				if !strings.Contains(out.String(), path) {
+					t.Errorf("output doesn't mention %s:\n%s", path, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestReportsInTheSameSecondGetDistinctFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	svc := &Service{Consensus: DefaultConsensusConfig()}
+	arts := []extract.Article{{URL: "https://a/1", FinalURL: "https://a/1", Title: "Port strike", Site: "a", Text: "Dockers walked out."}}
+	cands := []discovery.Candidate{{URL: "https://a/1", Title: "Port strike"}}
+
+	seen := map[string]bool{}
+	for range 2 {
+		written, err := generateReports(io.Discard, svc, arts, cands)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range written {
+			if seen[p] {
+				t.Errorf("%s written twice", p)
+			}
+			seen[p] = true
+		}
+	}
+	for _, dir := range []string{"reports", "scores"} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 2 {
+			t.Errorf("%s/ has %d files, want 2", dir, len(entries))
+		}
+	}
+}
+
+func TestUniqueReportPath(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	want := []string{"resume_2026-03-03_10-00-00.docx", "resume_2026-03-03_10-00-00_2.docx", "resume_2026-03-03_10-00-00_3.docx"}
+	for _, w := range want {
+		path := uniqueReportPath(dir, "resume", now)
+		if path != filepath.Join(dir, w) {
+			t.Fatalf("uniqueReportPath = %s, want %s", path, w)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGenerateAllReportsFromSavedResult(t *testing.T) {
+	svc := &Service{Consensus: DefaultConsensusConfig()}
+	result := &SearchResult{Candidates: []discovery.Candidate{{URL: "https://a/1", Title: "Port strike"}}}
+	arts := []extract.Article{{URL: "https://a/1", FinalURL: "https://a/1", Title: "Port strike", Site: "a", Text: "Dockers walked out."}}
+	dir := filepath.Join(t.TempDir(), "session")
+
+	written, err := svc.GenerateAllReports(dir, result, arts, "Dockers shut every major port.", "port strike")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixes := []string{"articles_", "scores_", "resume_"}
+	if len(written) != len(prefixes) {
+		t.Fatalf("wrote %v, want %d reports", written, len(prefixes))
+	}
+	for i, path := range written {
+		if filepath.Dir(path) != dir || !strings.HasPrefix(filepath.Base(path), prefixes[i]) {
+			t.Errorf("report %d = %s, want %s* in %s", i, path, prefixes[i], dir)
+		}
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("%s: %v", path, err)
+		}
+	}
+
+	// No summary and no result: only the article report
+	written, err = svc.GenerateAllReports(dir, nil, arts, " ", "port strike")
+	if err != nil || len(written) != 1 || !strings.HasPrefix(filepath.Base(written[0]), "articles_") {
+		t.Errorf("without summary or result wrote %v (%v), want only the article report", written, err)
+	}
+}
+
+// keepOriginalScript answers like worker.py called with {args}: the
+// untranslated fields only come back with --keep-original.
+const keepOriginalScript = `url=""; keep=""
+while [ $# -gt 0 ]; do
+  case "$1" in
+  --url) url="$2"; shift ;;
+  --keep-original) keep=1 ;;
+  esac
+  shift
+done
+if [ -n "$keep" ]; then
+  printf '{"ok": true, "data": {"url": "%s", "title": "Port strike spreads", "lang": "fr", "text": "Dockers walked out.", "original_title": "La grève s étend", "original_text": "Les dockers ont débrayé."}}' "$url"
+else
+  printf '{"ok": true, "data": {"url": "%s", "title": "Port strike spreads", "lang": "fr", "text": "Dockers walked out."}}' "$url"
+fi
+`
+
+func TestArticleReportIncludesOriginal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	script := filepath.Join(t.TempDir(), "worker.sh")
+	if err := os.WriteFile(script, []byte(keepOriginalScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, include := range []bool{false, true} {
+		svc := &Service{
+			Worker:          &extract.Worker{Command: []string{"sh", script, "{args}"}, KeepOriginal: include},
+			Concurrency:     Concurrency{Extraction: 1},
+			IncludeOriginal: include,
+		}
+		out := svc.ExtractAll(context.Background(), []string{"https://a.example/1"}, "en", nil)
+		if len(out) != 1 || !out[0].OK {
+			t.Fatalf("include %v: outcome %+v", include, out)
+		}
+		art := *out[0].Article
+		if (art.OriginalText != "") != include || (art.OriginalTitle != "") != include {
+			t.Errorf("include %v: original title %q, text %q", include, art.OriginalTitle, art.OriginalText)
+		}
+
+		path := filepath.Join(t.TempDir(), "articles.docx")
+		if err := svc.GenerateArticleReport(path, []extract.Article{art}); err != nil {
+			t.Fatal(err)
+		}
+		doc := docxText(t, path)
+		if !strings.Contains(doc, "Dockers walked out.") {
+			t.Errorf("include %v: report lacks the translation", include)
+		}
+		if strings.Contains(doc, "Les dockers ont débrayé.") != include || strings.Contains(doc, "Original text (fr)") != include {
+			t.Errorf("include %v: original in report = %v", include, !include)
+		}
+	}
+}
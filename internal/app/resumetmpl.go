@@ -0,0 +1,126 @@
+package app
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"newscheck/internal/extract"
+)
+
+// defaultResumeTemplate is the Markdown twin of the resume DOCX.
+//
+//go:embed templates/resume.md.tmpl
+var defaultResumeTemplate string
+
+// DefaultResumeTemplateName selects the built-in template for -resume-template.
+const DefaultResumeTemplateName = "default"
+
+// ResumeTemplate renders the resume as text (Markdown, HTML, plain text...)
+// next to the DOCX. Ext is the output file extension, taken from the
+// template file name ("brief.html.tmpl" -> ".html").
+type ResumeTemplate struct {
+	T   *template.Template
+	Ext string
+}
+
+// ResumeData is what a resume template sees.
+type ResumeData struct {
+	Query     string
+	Summary   string
+	Generated time.Time
+	Sources   []ResumeSource // articles the summary was written from
+	KeyActors []KeyActor     // recurring names, when requested (-key-actors)
+}
+
+// ResumeSource is one summarized article.
+type ResumeSource struct {
+	Title       string
+	Site        string
+	URL         string
+	Author      string
+	PublishedAt string
+	Lang        string
+}
+
+var resumeFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+	"date":  func(layout string, t time.Time) string { return t.Format(layout) },
+}
+
+// LoadResumeTemplate parses the template at path, or the built-in Markdown
+// one for DefaultResumeTemplateName.
+func LoadResumeTemplate(path string) (*ResumeTemplate, error) {
+	name, body, ext := "resume.md", defaultResumeTemplate, ".md"
+	if path != DefaultResumeTemplateName {
+		b, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, err
+		}
+		name, body = filepath.Base(path), string(b)
+		ext = filepath.Ext(strings.TrimSuffix(name, ".tmpl"))
+		if ext == "" {
+			ext = ".txt"
+		}
+	}
+	t, err := template.New(name).Funcs(resumeFuncs).Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("resume template: %w", err)
+	}
+	return &ResumeTemplate{T: t, Ext: ext}, nil
+}
+
+func newResumeData(summary, query string, articles []extract.Article, actors []KeyActor, now time.Time) ResumeData {
+	d := ResumeData{Query: query, Summary: summary, Generated: now, KeyActors: actors}
+	for _, a := range articles {
+		if a.LowQuality || a.DuplicateOf != "" {
+			continue
+		}
+		d.Sources = append(d.Sources, ResumeSource{
+			Title:       a.Title,
+			Site:        a.Site,
+			URL:         articleLabel(a),
+			Author:      deref(a.Author),
+			PublishedAt: deref(a.PublishedAt),
+			Lang:        deref(a.Lang),
+		})
+	}
+	return d
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Render executes the template for one resume; actors may be nil.
+func (rt *ResumeTemplate) Render(summary, query string, articles []extract.Article, actors []KeyActor) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := rt.T.Execute(&buf, newResumeData(summary, query, articles, actors, time.Now())); err != nil {
+		return nil, fmt.Errorf("resume template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTemplatedResume renders rt into a new resume file in dir and returns
+// its path.
+func writeTemplatedResume(rt *ResumeTemplate, dir, summary, query string, articles []extract.Article, actors []KeyActor, now time.Time) (string, error) {
+	out, err := rt.Render(summary, query, articles, actors)
+	if err != nil {
+		return "", err
+	}
+	path := uniqueReportPathExt(dir, "resume", rt.Ext, now)
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/extract"
+)
+
+func resumeArticles() []extract.Article {
+	str := func(s string) *string { return &s }
+	return []extract.Article{
+		{URL: "https://a.example/1", FinalURL: "https://a.example/final", Title: "Port strike spreads", Site: "Harbour Times", Author: str("J. Doe"), Lang: str("en")},
+		{URL: "https://b.example/2", Title: "Stub", Site: "Paywall Daily", LowQuality: true},
+		{URL: "https://c.example/3", Title: "Port strike spreads (copy)", Site: "Copy Wire", DuplicateOf: "https://a.example/final"},
+	}
+}
+
+func TestCustomResumeTemplate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "brief.html.tmpl")
+	body := `<h1>{{upper .Query}}</h1><p>{{.Summary}}</p>
+<ul>{{range .Sources}}<li>{{.Site}} | {{.Author}} | {{.URL}}</li>{{end}}</ul>
+{{range .KeyActors}}<i>{{.Name}} ({{.Articles}})</i>{{end}}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rt, err := LoadResumeTemplate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.Ext != ".html" {
+		t.Errorf("Ext = %q, want .html", rt.Ext)
+	}
+
+	out, err := rt.Render("Dockers shut every major port.", "port strike", resumeArticles(), []KeyActor{{Name: "ITF", Articles: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<h1>PORT STRIKE</h1><p>Dockers shut every major port.</p>
+<ul><li>Harbour Times | J. Doe | https://a.example/final</li></ul>
+<i>ITF (2)</i>`
+	if string(out) != want {
+		t.Errorf("rendered\n%s\nwant\n%s", out, want)
+	}
+
+	written, err := writeTemplatedResume(rt, dir, "s", "q", nil, nil, time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(written) != "resume_2026-03-03_10-00-00.html" {
+		t.Errorf("wrote %s", written)
+	}
+}
+
+func TestDefaultResumeTemplate(t *testing.T) {
+	rt, err := LoadResumeTemplate(DefaultResumeTemplateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := rt.Render("Dockers shut every major port.", "port strike", resumeArticles(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"port strike", "Dockers shut every major port.", "- Port strike spreads (Harbour Times)"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("default resume lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(string(out), "Paywall Daily") || strings.Contains(string(out), "Copy Wire") {
+		t.Errorf("default resume lists a stub or duplicate:\n%s", out)
+	}
+}
+
+func TestResumeTemplateErrors(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.tmpl")
+	if err := os.WriteFile(bad, []byte("{{.Query"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadResumeTemplate(bad); err == nil {
+		t.Error("unparseable template loaded")
+	}
+
+	unknown := filepath.Join(dir, "unknown.tmpl")
+	if err := os.WriteFile(unknown, []byte("{{.Nope}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rt, err := LoadResumeTemplate(unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.Ext != ".txt" {
+		t.Errorf("Ext = %q, want .txt for a bare .tmpl", rt.Ext)
+	}
+	if _, err := rt.Render("s", "q", nil, nil); err == nil {
+		t.Error("template using an unknown field rendered")
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelection(t *testing.T) {
+	// top picks the first n, as pickTop does by relevance
+	top := func(n int) []int {
+		out := make([]int, n)
+		for i := range out {
+			out[i] = i
+		}
+		return out
+	}
+	tests := []struct {
+		line    string
+		want    []int
+		wantErr bool
+	}{
+		{"", []int{0, 1, 2}, false},
+		{"2", []int{0, 1}, false},
+		{"50", []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, false},
+		{"1,3,7-9", []int{0, 2, 6, 7, 8}, false},
+		{" 7-9 , 1, 8 ", []int{6, 7, 8, 0}, false},
+		{"1,,3", []int{0, 2}, false},
+		{"1,x", nil, true},
+		{"3-a", nil, true},
+		{"9-7", nil, true},
+		{"0,2", nil, true},
+		{"9-11", nil, true},
+		{",", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSelection(tt.line, 10, 3, top)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseSelection(%q) = %v, %v; want %v, err %v", tt.line, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package app
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/geo"
+)
+
+//go:embed web
+var webFS embed.FS
+
+// SearchParams is the JSON body accepted by POST /search. It mirrors the
+// desktop app's search form.
+type SearchParams struct {
+	Query          string `json:"query"`
+	Days           int    `json:"days"`       // 1, 7, 30, or -1 (Custom)
+	CustomFrom     string `json:"customFrom"` // YYYY-MM-DD
+	CustomTo       string `json:"customTo"`   // YYYY-MM-DD
+	Scope          int    `json:"scope"`      // 0=Auto, 1=Chosen, 2=Global
+	ChosenCountry  string `json:"chosenCountry"`
+	PivotLang      string `json:"pivotLang"`
+	QueryLang      string `json:"queryLang"` // "" = detect
+	FreshnessHours int    `json:"freshnessHours"`
+	MinRelevance   int    `json:"minRelevance"` // see FilterOptions
+	MinResults     int    `json:"minResults"`
+	MinSources     int    `json:"minSources"`
+	PivotBoost     int    `json:"pivotBoost"`
+	Dedupe         string `json:"dedupe"` // see DedupeStrategy; "" = canonical-url
+	NoCache        bool   `json:"noCache"`
+
+	CollapseLocales      bool     `json:"collapseLocales"`      // see SearchRequest
+	MinCountryConfidence float64  `json:"minCountryConfidence"` // 0 = default
+	PreferredDomains     []string `json:"preferredDomains"`     // e.g. ["reuters.com"]
+	MaxAgePerSource      string   `json:"maxAgePerSource"`      // e.g. "feed=24h,country=168h"; "" = uniform
+	Locale               string   `json:"locale"`               // e.g. "en-US"; see withLocale
+	Publisher            string   `json:"publisher"`            // e.g. "Reuters"; see SearchRequest
+}
+
+// SearchWindow turns a "last N days" choice (or -1 with custom dates) into a
+// time range. Custom dates are whole UTC days, both included (see DayRange).
+// Any other days below 1, including a missing one, is an error.
+func SearchWindow(days int, customFrom, customTo string, now time.Time) (time.Time, time.Time, error) {
+	if days == -1 {
+		from, to, err := DayRange(customFrom, customTo, time.UTC)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("custom range: %w", err)
+		}
+		return from, to, nil
+	}
+	if days < 1 {
+		return time.Time{}, time.Time{}, fmt.Errorf("days must be at least 1, or -1 with custom dates (got %d)", days)
+	}
+	if days == 1 {
+		return now.Add(-24 * time.Hour), now, nil
+	}
+	return now.AddDate(0, 0, -days), now, nil
+}
+
+// Request converts form-style params into a SearchRequest.
+func (p SearchParams) Request(now time.Time) (SearchRequest, error) {
+	from, to, err := SearchWindow(p.Days, p.CustomFrom, p.CustomTo, now)
+	if err != nil {
+		return SearchRequest{}, err
+	}
+	pivot, err := ValidatePivotLang(p.PivotLang)
+	if err != nil {
+		return SearchRequest{}, err
+	}
+	dedupe, err := ParseDedupeStrategy(p.Dedupe)
+	if err != nil {
+		return SearchRequest{}, err
+	}
+	maxAges, err := parseMaxAgePerSource(p.MaxAgePerSource)
+	if err != nil {
+		return SearchRequest{}, err
+	}
+	var publisher string
+	if p.Publisher != "" {
+		if publisher, err = discovery.SanitizePublisher(p.Publisher); err != nil {
+			return SearchRequest{}, err
+		}
+	}
+	var global []geo.DiscoveryTarget
+	if p.Locale != "" {
+		loc, err := discovery.ParseLocale(p.Locale)
+		if err != nil {
+			return SearchRequest{}, err
+		}
+		global = withLocale(nil, loc)
+	}
+	return SearchRequest{
+		Query:         p.Query,
+		From:          from,
+		To:            to,
+		Scope:         SearchScope(p.Scope),
+		ChosenCountry: p.ChosenCountry,
+		PivotLang:     pivot,
+		QueryLang:     p.QueryLang,
+		GlobalTargets: global,
+		Dedupe:        dedupe,
+		NoCache:       p.NoCache,
+
+		CollapseLocales:      p.CollapseLocales,
+		PreferredDomains:     p.PreferredDomains,
+		MinCountryConfidence: p.MinCountryConfidence,
+		Publisher:            publisher,
+		Filter: FilterOptions{
+			FreshnessFloor: time.Duration(p.FreshnessHours) * time.Hour,
+			MinRelevance:   p.MinRelevance,
+			MinResults:     p.MinResults,
+			MinSources:     p.MinSources,
+			PivotBoost:     p.PivotBoost,
+
+			MaxAgePerSource: maxAges,
+		},
+	}, nil
+}
+
+// NewHTTPHandler exposes the service over HTTP:
+//
+//	GET  /        minimal browser UI
+//	POST /search  SearchParams -> SearchResult
+//	POST /intent  {"query": "..."} -> Intent (no discovery)
+//	POST /extract {"urls": [...], "pivotLang": "en"} -> []ExtractOutcome
+func NewHTTPHandler(svc *Service) http.Handler {
+	mux := http.NewServeMux()
+
+	static, _ := fs.Sub(webFS, "web")
+	mux.Handle("GET /", http.FileServer(http.FS(static)))
+
+	mux.HandleFunc("POST /search", func(w http.ResponseWriter, r *http.Request) {
+		var p SearchParams
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&p); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("bad request body: %w", err))
+			return
+		}
+		if ok, reason := validateQuery(p.Query); !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid query (%s)", reason))
+			return
+		}
+		req, err := p.Request(time.Now())
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+		res, err := svc.Search(r.Context(), req)
+		if errors.Is(err, ErrURLQuery) {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf(`%w: POST them to /extract as {"urls": [...]}`, err))
+			return
+		}
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+
+	mux.HandleFunc("POST /intent", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query string `json:"query"`
+			Lang  string `json:"lang"` // "" = detect
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("bad request body: %w", err))
+			return
+		}
+		if ok, reason := validateQuery(body.Query); !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("invalid query (%s)", reason))
+			return
+		}
+		writeJSON(w, http.StatusOK, ExtractIntentIn(body.Query, body.Lang))
+	})
+
+	mux.HandleFunc("POST /extract", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			URLs      []string `json:"urls"`
+			PivotLang string   `json:"pivotLang"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("bad request body: %w", err))
+			return
+		}
+		if len(body.URLs) == 0 {
+			writeJSONError(w, http.StatusBadRequest, fmt.Errorf("no urls"))
+			return
+		}
+		pivot, err := ValidatePivotLang(body.PivotLang)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, svc.ExtractAll(r.Context(), body.URLs, pivot, nil))
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// runServe starts the HTTP server: `newscheck serve -addr :8080`.
+func runServe(args []string) error {
+	fset := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fset.String("addr", "127.0.0.1:8080", "listen address")
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+
+	svc, err := NewService()
+	if err != nil {
+		return err
+	}
+
+	url := *addr
+	if strings.HasPrefix(url, ":") {
+		url = "localhost" + url
+	}
+	fmt.Printf("Serving NewsCheck on http://%s/\n", url)
+	return http.ListenAndServe(*addr, NewHTTPHandler(svc))
+}
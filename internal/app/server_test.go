@@ -0,0 +1,102 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchHandlerRejectsBadRequests(t *testing.T) {
+	h := NewHTTPHandler(&Service{})
+	tests := []struct {
+		name, body, wantErr string
+	}{
+		{"url query", `{"query": "https://example.com/article", "days": 7}`, "/extract"},
+		{"bad json", `{`, "bad request body"},
+		{"empty query", `{"query": "", "days": 7}`, "invalid query"},
+		{"missing days", `{"query": "port strike"}`, "days must be at least 1"},
+		{"negative days", `{"query": "port strike", "days": -3}`, "days must be at least 1"},
+		{"custom range without dates", `{"query": "port strike", "days": -1}`, "custom range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(tt.body)))
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400 (body %s)", rec.Code, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantErr) {
+				t.Errorf("body = %s, want it to mention %q", rec.Body, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIntentHandler(t *testing.T) {
+	h := NewHTTPHandler(&Service{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/intent", strings.NewReader(`{"query": "grève des dockers en France"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body %s)", rec.Code, rec.Body)
+	}
+	var got Intent
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Lang != "fr" || !slices.Contains(got.Themes, "Protests") || !slices.Contains(got.Keywords, "dockers") {
+		t.Errorf("intent = %+v, want French, Protests and the dockers keyword", got)
+	}
+
+	// An explicit lang overrides detection
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/intent", strings.NewReader(`{"query": "grève des dockers", "lang": "en"}`)))
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Lang != "en" {
+		t.Errorf("lang = %q, want the requested en", got.Lang)
+	}
+
+	for _, body := range []string{`{`, `{"query": ""}`} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/intent", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", body, rec.Code)
+		}
+	}
+}
+
+func TestSearchWindow(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		days     int
+		from, to string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{name: "last day", days: 1, wantFrom: now.Add(-24 * time.Hour), wantTo: now},
+		{name: "last week", days: 7, wantFrom: now.AddDate(0, 0, -7), wantTo: now},
+		{name: "custom", days: -1, from: "2024-03-01", to: "2024-03-02",
+			wantFrom: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), wantTo: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)},
+		{name: "zero", days: 0, wantErr: true},
+		{name: "negative", days: -2, wantErr: true},
+		{name: "custom without dates", days: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := SearchWindow(tt.days, tt.from, tt.to, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (!from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo)) {
+				t.Errorf("window = %v..%v, want %v..%v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
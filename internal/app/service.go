@@ -2,8 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gingfrederik/docx"
@@ -15,26 +19,138 @@ import (
 type Service struct {
 	Resolver *geo.HybridResolver
 	Matcher  *geo.CountryMatcher
-	GN       *discovery.GoogleNews
-	RSS      *discovery.RSSFeeds
 	Worker   *extract.Worker
+
+	// Discovery backends, queried in order; see DefaultDiscoverySources.
+	Sources []DiscoverySource
+	// Recent discovery results reused by identical searches; nil = off.
+	Cache *CandidateCache
+
+	// Region -> member countries for "news in the Caribbean"-style queries.
+	Regions geo.Regions
+
+	// Publisher credibility used to order equally relevant candidates (see
+	// OrderWeights), unless the request brings its own.
+	SourceWeights map[string]float64
+
+	// Extracted articles shorter than this are flagged LowQuality and kept out of the summary.
+	MinArticleChars int
+
+	// IncludeOriginal adds the untranslated text (when the worker kept it,
+	// see extract.Worker.KeepOriginal) below each article in reports.
+	IncludeOriginal bool
+
+	// Same-story threshold for the consensus score and its report labels.
+	Consensus ConsensusConfig
+
+	// Per-article cap on the text sent for summarization.
+	Summary SummaryLimits
+
+	// Outlets to prioritize when a request names none (see
+	// SearchRequest.PreferredDomains).
+	PreferredDomains []string
+
+	// ResumeTemplate, when set, also renders the resume as text next to
+	// the DOCX in GenerateAllReports.
+	ResumeTemplate *ResumeTemplate
+
+	// Calls run at once per stage; see DeriveConcurrency.
+	Concurrency Concurrency
+
+	// User-flagged junk dropped from every search (see NoiseList).
+	Noise *NoiseList
+
+	// User-supplied domain synonyms (see Synonyms); nil = none.
+	Synonyms Synonyms
+
+	// ReportActors adds a "Key actors" section (see KeyActors) to resumes.
+	ReportActors bool
+
+	// Journal saves finished extractions so a crashed run can be resumed;
+	// nil = off, the default. Only the CLI turns it on (see newCLIService):
+	// serve and the desktop app have no -resume-extraction to use it.
+	// ResumeExtraction reuses the journal of an identical URL set instead of
+	// starting over.
+	Journal          *ExtractJournal
+	ResumeExtraction bool
 }
 
-func NewService() (*Service, error) {
+// DefaultDiscoverySources registers Google News, Bing News (only when
+// BING_NEWS_API_KEY is set) and the curated feeds.
+func DefaultDiscoverySources(curated *discovery.CuratedFeeds) []DiscoverySource {
+	sources := []DiscoverySource{{Source: discovery.NewGoogleNews(), PerPlan: 25, MinPerPlan: 5}}
+	if bing := discovery.NewBingNewsFromEnv(); bing != nil {
+		sources = append(sources, DiscoverySource{Source: bing, PerPlan: 25, MinPerPlan: 5})
+	}
+	if curated != nil {
+		sources = append(sources, DiscoverySource{Source: curated, PerPlan: 10, MinPerPlan: 3})
+	}
+	return sources
+}
+
+// newCountryResolution builds the resolver chain and the dataset matcher:
+// - In-memory/on-disk cache layer (geo.NewCache)
+// - Manual overrides dataset (country_languages.json)
+// - Local RestCountries dump (restcountries_all.json), if downloaded
+// - API fallback (RestCountries) with write-through auto cache (country_auto_cache.json)
+func newCountryResolution() (*geo.HybridResolver, *geo.CountryMatcher, error) {
 	cache := geo.NewCache("newscheck")
 	ds, err := geo.NewDatasetResolver("data/country_languages.json")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	autoStore, err := geo.NewAutoCacheStore("data/country_auto_cache.json")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	api := geo.NewRestCountriesResolver()
+
+	// The dump is optional (see `newscheck download-countries`)
+	var local geo.Resolver
+	if lr, err := geo.NewLocalRestCountriesResolver(geo.DefaultRestCountriesDumpPath); err == nil {
+		local = lr
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	api := geo.NewRestCountriesResolver(geo.WithBaseURL(os.Getenv(geo.RestCountriesBaseURLEnv)))
 	apiWithAuto := geo.NewAutoCacheResolver(autoStore, api)
-	resolver := geo.NewHybridResolver(cache, ds, apiWithAuto)
+	resolver := geo.NewHybridResolver(cache, ds, local, apiWithAuto)
 
 	matcher, err := geo.NewCountryMatcher("data/country_languages.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolver, matcher, nil
+}
+
+func NewService() (*Service, error) {
+	resolver, matcher, err := newCountryResolution()
+	if err != nil {
+		return nil, err
+	}
+
+	worker, err := extract.NewWorker()
+	if err != nil {
+		return nil, err
+	}
+
+	curated, err := discovery.LoadCuratedFeeds(discovery.DefaultCuratedFeedsPath)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := geo.LoadRegions(geo.DefaultRegionsPath)
+	if err != nil {
+		return nil, err
+	}
+	sourceWeights, err := LoadSourceWeights(DefaultSourceWeightsPath)
+	if err != nil {
+		return nil, err
+	}
+	synonyms, err := LoadSynonyms(DefaultSynonymsPath)
+	if err != nil {
+		return nil, err
+	}
+	noise, err := LoadNoiseList(DefaultNoiseURLsPath, DefaultNoiseDomainsPath)
 	if err != nil {
 		return nil, err
 	}
@@ -42,14 +158,19 @@ func NewService() (*Service, error) {
 	return &Service{
 		Resolver: resolver,
 		Matcher:  matcher,
-		GN:       discovery.NewGoogleNews(),
-		RSS:      discovery.NewRSSFeeds([]string{
-			"https://rss.nytimes.com/services/xml/rss/nyt/World.xml",
-			"https://www.theguardian.com/world/rss",
-			"https://feeds.bbci.co.uk/news/world/rss.xml",
-			"https://www.aljazeera.com/xml/rss/all.xml",
-		}),
-		Worker: extract.NewWorker(),
+		Worker:   worker,
+		Regions:  regions,
+		Sources:  DefaultDiscoverySources(curated),
+		Cache:    NewCandidateCache(),
+
+		SourceWeights: sourceWeights,
+		Noise:         noise,
+		Synonyms:      synonyms,
+
+		MinArticleChars: DefaultMinArticleChars,
+		Consensus:       DefaultConsensusConfig(),
+		Summary:         SummaryLimits{MaxChars: DefaultSummaryMaxChars},
+		Concurrency:     DeriveConcurrency(DefaultParallelism),
 	}, nil
 }
 
@@ -60,6 +181,54 @@ type SearchRequest struct {
 	Scope         SearchScope
 	ChosenCountry string
 	PivotLang     string
+	Filter        FilterOptions
+
+	// QueryLang (ISO-639-1) selects the intent lexicons and stopwords; ""
+	// detects it from Query.
+	QueryLang string
+
+	// Anchor locales for worldwide searches; nil = DefaultGlobalTargets.
+	GlobalTargets []geo.DiscoveryTarget
+
+	// Overall time budget for discovery; per-call timeouts shrink as it runs
+	// out. 0 = no limit.
+	DiscoveryTimeout time.Duration
+
+	// How duplicate candidates are recognized; "" = DedupeCanonicalURL.
+	Dedupe DedupeStrategy
+
+	// NoCache always runs discovery, ignoring (and not filling) Service.Cache.
+	NoCache bool
+
+	// MinCountryConfidence is what an auto-detected country needs (0..1) to
+	// drive the targets; below it the search falls back to Global (with a
+	// SearchResult.Note). 0 = DefaultMinCountryConfidence.
+	MinCountryConfidence float64
+
+	// CollapseLocales queries the English (or pivot language) baseline once
+	// instead of once per country where it isn't a local language. Fewer
+	// calls, at the cost of some country-specific English coverage.
+	CollapseLocales bool
+
+	// PreferredDomains ("reuters.com") are pulled first through their
+	// curated feeds and get a relevance boost; nil = Service.PreferredDomains.
+	PreferredDomains []string
+
+	// Publisher ("Reuters") restricts the search to one outlet: Google News
+	// plans get its source: operator and other sources' candidates must
+	// match it (see discovery.MatchesPublisher). "" = any outlet.
+	Publisher string
+
+	// Synonyms expand the query into extra plans and count toward title
+	// matches; nil = Service.Synonyms.
+	Synonyms Synonyms
+
+	// Trace fills SearchResult.Trace with every intermediate output.
+	Trace bool
+
+	// OnPartial, if set, streams candidates as discovery calls return (not
+	// on a candidate cache hit).
+	OnPartial PartialFunc `json:"-"`
 }
 
 type SearchResult struct {
@@ -67,100 +236,268 @@ type SearchResult struct {
 	Intent     Intent                `json:"Intent"`
 	Plans      []SearchPlan          `json:"Plans"`
 	Targets    []geo.DiscoveryTarget `json:"Targets"`
+	// Targets dropped by SearchRequest.CollapseLocales.
+	CollapsedTargets []geo.DiscoveryTarget `json:"CollapsedTargets,omitempty"`
+
+	// Countries named by the query/scope and those that resolved.
+	DetectedCountries []string          `json:"DetectedCountries"`
+	Countries         []geo.CountryInfo `json:"Countries"`
+	// Why auto-detected countries were set aside, if any were.
+	Note string `json:"Note,omitempty"`
+
+	Stats *RunStats `json:"Stats"`
+
+	// Failed discovery calls plus a human-readable digest of them.
+	SourceErrors []SourceError `json:"SourceErrors"`
+	ErrorSummary []string      `json:"ErrorSummary"`
+	// Curated feeds that could not be fetched or parsed this run. They
+	// don't fail their discovery call, so they are not in SourceErrors.
+	FeedFailures []discovery.FeedOutcome `json:"FeedFailures,omitempty"`
+
+	// Terms that recur across the returned candidates, top first.
+	TopThemes []TrendItem `json:"TopThemes,omitempty"`
+
+	// Likely causes and fixes when no candidates were found.
+	Hints []EmptyResultHint `json:"Hints,omitempty"`
+
+	// Full pipeline record, when SearchRequest.Trace is set.
+	Trace *Trace `json:"Trace,omitempty"`
 }
 
 func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchResult, error) {
-	// 1. Intent
-	intent := ExtractIntent(req.Query)
-
-	// 2. Country Resolution
-	var countryNames []string
-	switch req.Scope {
-	case ScopeAuto:
-		countryNames = s.Matcher.FindCountries(req.Query)
-		if len(countryNames) == 0 && len(intent.Countries) > 0 {
-			countryNames = append(countryNames, intent.Countries...)
-		}
-		if len(countryNames) == 0 {
-			hints := geo.ExtractCountryHints(req.Query)
-			for _, h := range hints {
-				info, err := s.Resolver.ResolveCountry(ctx, h)
-				if err == nil && info.ISO2 != "" && len(info.Languages) > 0 {
-					countryNames = append(countryNames, info.Name)
-					break
-				}
-			}
-		}
-	case ScopeChosen:
-		countryNames = []string{req.ChosenCountry}
-		intent.Countries = nil
-		intent.Regions = nil
-	case ScopeGlobal:
-		countryNames = []string{}
-		intent.Countries = nil
-		intent.Regions = nil
-	}
-
-	resolved := make([]geo.CountryInfo, 0, len(countryNames))
-	for _, name := range countryNames {
-		info, err := s.Resolver.ResolveCountry(ctx, name)
-		if err == nil && info.ISO2 != "" {
-			resolved = append(resolved, info)
+	if QueryURLs(req.Query) != nil {
+		return nil, ErrURLQuery
+	}
+	if req.Publisher != "" {
+		pub, err := discovery.SanitizePublisher(req.Publisher)
+		if err != nil {
+			return nil, err
 		}
+		req.Publisher = pub
+	}
+	stats := newRunStats()
+	start := time.Now()
+	if req.PreferredDomains == nil {
+		req.PreferredDomains = s.PreferredDomains
+	}
+	req.PreferredDomains = normalizeDomains(req.PreferredDomains)
+	if req.Synonyms == nil {
+		req.Synonyms = s.Synonyms
+	}
+	var trace *Trace
+	if req.Trace {
+		trace = newTrace(req, start)
 	}
 
-	// 3. Build Targets
-	targets := buildTargets(resolved)
-
-	// 4. Build Plans
-	plans := BuildSearchPlans(req.Query, intent, resolved)
+	// 1-4. Intent, country resolution, targets, plans
+	ex := explainSearch(ctx, req, s.Matcher, s.Resolver, s.Regions, s.Concurrency.Resolution)
+	intent, resolved, targets, plans := ex.Intent, ex.Resolved, ex.Targets, ex.Plans
+	stats.stage("resolve", start)
+	trace.stage("resolve", start)
 
 	// 5. Discovery
 	tr := TimeRange{From: req.From, To: req.To}
-	candidates, err := runDiscoveryWithTargets(ctx, plans, tr, targets, s.GN, s.RSS)
-	if err != nil {
-		return nil, err
+	start = time.Now()
+	dctx := ctx
+	if req.DiscoveryTimeout > 0 {
+		var cancel context.CancelFunc
+		dctx, cancel = context.WithTimeout(ctx, req.DiscoveryTimeout)
+		defer cancel()
+	}
+	var candidates []discovery.Candidate
+	var sourceErrs []SourceError
+	var feedFailures []discovery.FeedOutcome
+	cache := s.Cache
+	if req.NoCache {
+		cache = nil
 	}
+	cacheKey := candidateCacheKey(req, targets, s.Sources, start)
+	if cached, ok := cache.get(cacheKey, start); ok {
+		candidates = cached
+		stats.CacheHit = true
+	} else {
+		var err error
+		sources := s.Sources
+		if ps, ok := s.preferredSource(req.PreferredDomains, targets); ok {
+			sources = append([]DiscoverySource{ps}, sources...)
+		}
+		candidates, sourceErrs, err = runDiscoveryWithTargets(dctx, plans, tr, targets, sources, req.Dedupe, s.Concurrency.Discovery, req.OnPartial, stats)
+		if err != nil {
+			return nil, err
+		}
+		feedFailures = failedFeeds(sources, start)
+		// A run with failed calls is likely incomplete; don't serve it again
+		if len(sourceErrs) == 0 && len(candidates) > 0 {
+			if err := cache.put(cacheKey, candidates, time.Now()); err != nil {
+				fmt.Fprintln(os.Stderr, "Warning: candidate cache:", err)
+			}
+		}
+	}
+	for i := range candidates {
+		candidates[i].Publisher = discovery.NormalizeSourceName(candidates[i])
+		// Feeds stamp items in their own zones; one zone reads consistently
+		candidates[i].PublishedAt = candidates[i].PublishedAt.UTC()
+	}
+	stats.stage("discovery", start)
+	trace.stage("discovery", start)
+	if trace != nil {
+		trace.RawCandidates = append([]discovery.Candidate(nil), candidates...)
+	}
+	start = time.Now()
 
 	// 6. Filter & Score
-	candidates = filterCandidates(candidates, req.Query, intent, resolved)
-	consensus := calculateConsensus(candidates)
-	for i := range candidates {
-		candidates[i].ConsensusScore = consensus[candidates[i].URL]
+	filter := req.Filter
+	if filter.Order.Sources == nil {
+		filter.Order.Sources = s.SourceWeights
+	}
+	filter.PreferredDomains = req.PreferredDomains
+	if filter.Noise == nil {
+		filter.Noise = s.Noise
 	}
+	filter.PivotLang = req.PivotLang
+	filter.Publisher = req.Publisher
+	filter.Synonyms = req.Synonyms
+	candidates = filterCandidates(candidates, req.Query, intent, resolved, filter)
+	stats.MaxConsensus = max(stats.MaxConsensus, applyConsensus(candidates, s.Consensus))
+	if n := len(candidates); req.Filter.MinSources > 1 {
+		candidates = dropThinStories(candidates, req.Filter.MinSources)
+		stats.BelowMinSources = n - len(candidates)
+	}
+	stats.CandidatesFiltered = len(candidates)
+	stats.stage("filter", start)
+	trace.stage("filter", start)
 
-	return &SearchResult{
+	res := &SearchResult{
 		Candidates: candidates,
 		Intent:     intent,
 		Plans:      plans,
 		Targets:    targets,
-	}, nil
+
+		CollapsedTargets:  ex.Collapsed,
+		DetectedCountries: ex.Countries,
+		Note:              ex.Note,
+		Countries:         resolved,
+		Stats:             stats,
+
+		SourceErrors: sourceErrs,
+		ErrorSummary: summarizeSourceErrors(sourceErrs),
+		FeedFailures: feedFailures,
+	}
+	res.TopThemes = TopicTrends(candidates)
+	res.Hints = emptyResultHints(req, res)
+	if trace != nil {
+		trace.Plan = ex
+		trace.PerSource = stats.PerSource
+		trace.CacheHit = stats.CacheHit
+		trace.SourceErrors = sourceErrs
+		trace.FeedFailures = feedFailures
+		trace.Candidates = candidates
+		trace.FinishedAt = time.Now().UTC()
+		res.Trace = trace
+	}
+	return res, nil
+}
+
+// Explain runs the planning half of Search (intent, countries, plans,
+// targets) without contacting any news source.
+func (s *Service) Explain(ctx context.Context, req SearchRequest) *PlanExplanation {
+	if req.Synonyms == nil {
+		req.Synonyms = s.Synonyms
+	}
+	return explainSearch(ctx, req, s.Matcher, s.Resolver, s.Regions, s.Concurrency.Resolution)
+}
+
+// ExtractOutcome is the result of extracting one URL: the article, or why
+// there is none.
+type ExtractOutcome struct {
+	URL     string           `json:"url"`
+	OK      bool             `json:"ok"`
+	Error   string           `json:"error,omitempty"`
+	Article *extract.Article `json:"article,omitempty"`
+	// Resumed: the article comes from the journal of an interrupted run.
+	Resumed bool `json:"resumed,omitempty"`
+}
+
+// ExtractAll extracts each URL (translated to pivotLang, which must already
+// be valid), Concurrency.Extraction at a time, and returns one outcome per
+// URL, in order, failures included. done, if set, is called for each outcome
+// in URL order as soon as it and the ones before it are in, e.g. to show
+// progress; calls never overlap. Each article is journaled as it completes
+// (see ExtractJournal); with ResumeExtraction, URLs journaled by an
+// interrupted run of the same set are not extracted again.
+func (s *Service) ExtractAll(ctx context.Context, urls []string, pivotLang string, done func(i int, o ExtractOutcome)) []ExtractOutcome {
+	runKey := pivotLang
+	if s.Worker.MetadataOnly {
+		runKey += "\n" + extract.ModeMetadataOnly // a full run must not reuse these
+	}
+	run, err := s.Journal.open(urls, runKey, s.ResumeExtraction)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: extraction journal:", err)
+	}
+	out := make([]ExtractOutcome, len(urls))
+	ready := make([]bool, len(urls))
+	var mu sync.Mutex
+	next := 0
+	forEachLimit(len(urls), s.Concurrency.Extraction, func(i int) {
+		o := ExtractOutcome{URL: urls[i]}
+		if art, ok := run.load(urls[i]); ok {
+			o.OK, o.Article, o.Resumed = true, art, true
+		} else if art, err := s.Worker.Extract(ctx, urls[i], pivotLang); err != nil {
+			o.Error = err.Error()
+		} else {
+			o.OK = true
+			o.Article = &art
+			if err := run.save(urls[i], art); err != nil {
+				fmt.Fprintln(os.Stderr, "Warning: extraction journal:", err)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		out[i], ready[i] = o, true
+		for ; next < len(urls) && ready[next]; next++ {
+			if done != nil {
+				done(next, out[next])
+			}
+		}
+	})
+
+	// A run with failures stays journaled, so resuming retries only those
+	for _, o := range out {
+		if !o.OK {
+			return out
+		}
+	}
+	if err := run.finish(); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: extraction journal:", err)
+	}
+	return out
 }
 
 func (s *Service) ExtractAndSummarize(ctx context.Context, urls []string, pivotLang string, query string, apiKey string) ([]extract.Article, string, error) {
-	var extracted []extract.Article
+	pivotLang, err := ValidatePivotLang(pivotLang)
+	if err != nil {
+		return nil, "", err
+	}
 
-	for _, u := range urls {
-		art, err := s.Worker.Extract(ctx, u, pivotLang)
-		if err != nil {
-			fmt.Printf("Extract error for %s: %v\n", u, err) // Log to stdout for now
+	var extracted []extract.Article
+	for _, o := range s.ExtractAll(ctx, urls, pivotLang, nil) {
+		if !o.OK {
+			fmt.Fprintf(os.Stderr, "Extract error for %s: %s\n", o.URL, o.Error)
 			continue
 		}
-		extracted = append(extracted, art)
+		extracted = append(extracted, *o.Article)
 	}
 
-	var summary string
-	if len(extracted) > 0 {
-		var sb strings.Builder
-		sb.WriteString(fmt.Sprintf("User Query: %s\n\n", query))
-		sb.WriteString("Source Articles:\n")
-		for _, art := range extracted {
-			sb.WriteString(fmt.Sprintf("Title: %s\nSource: %s\nText:\n%s\n\n", art.Title, art.Site, art.Text))
-		}
-		fullText := sb.String()
+	// Low-quality articles and duplicate copies of the same story are
+	// returned (flagged) but not summarized
+	markLowQuality(extracted, s.MinArticleChars)
+	usable := collapseDuplicates(extracted, s.SourceWeights, normalizeDomains(s.PreferredDomains))
 
+	var summary string
+	if len(usable) > 0 && !s.Worker.MetadataOnly {
 		var err error
-		summary, err = s.Worker.Summarize(ctx, fullText, apiKey)
+		summary, err = s.Worker.Summarize(ctx, summaryInput(query, usable, s.Summary), apiKey)
 		if err != nil {
 			return extracted, "", err
 		}
@@ -178,6 +515,10 @@ func (s *Service) GenerateArticleReport(path string, articles []extract.Article)
 	f.AddParagraph() // Spacer
 
 	for _, art := range articles {
+		if art.LowQuality || art.DuplicateOf != "" {
+			continue
+		}
+
 		// Title
 		p := f.AddParagraph()
 		run := p.AddText(art.Title)
@@ -199,21 +540,55 @@ func (s *Service) GenerateArticleReport(path string, articles []extract.Article)
 		run.Size(10)
 		run.Color("0000FF")
 
-		// Simple text splitting by double newlines for paragraphs
-		paragraphs := strings.Split(art.Text, "\n\n")
-		for _, txt := range paragraphs {
-			txt = strings.TrimSpace(txt)
-			if txt != "" {
-				f.AddParagraph().AddText(txt)
-			}
-		}
+		addArticleBody(f, art, s.IncludeOriginal)
 		f.AddParagraph().AddText("--------------------------------------------------")
 	}
 
 	return f.Save(path)
 }
 
-func (s *Service) GenerateScoresReport(path string, candidates []discovery.Candidate) error {
+// addArticleBody writes the article text, one paragraph per blank-line
+// separated block, followed by the untranslated text when includeOriginal is
+// set and the worker kept it.
+func addArticleBody(f *docx.File, art extract.Article, includeOriginal bool) {
+	addTextParagraphs(f, art.Text)
+	if !includeOriginal || art.OriginalText == "" {
+		return
+	}
+	label := "Original text"
+	if art.Lang != nil && *art.Lang != "" {
+		label += " (" + *art.Lang + ")"
+	}
+	if art.OriginalTitle != "" {
+		label += ": " + art.OriginalTitle
+	}
+	run := f.AddParagraph().AddText(label)
+	run.Size(12)
+	run.Color("808080")
+	addTextParagraphs(f, art.OriginalText)
+}
+
+// candidateLink is a scores report's "The Guardian · https://..." line.
+func candidateLink(c discovery.Candidate) string {
+	if c.Publisher == "" {
+		return c.URL
+	}
+	return c.Publisher + " · " + c.URL
+}
+
+func addTextParagraphs(f *docx.File, text string) {
+	for _, txt := range strings.Split(text, "\n\n") {
+		txt = strings.TrimSpace(txt)
+		if txt != "" {
+			f.AddParagraph().AddText(txt)
+		}
+	}
+}
+
+// GenerateScoresReport writes the scores report. Candidates that appear in
+// articles (by URL) get a short preview of their extracted text; pass nil
+// when nothing was extracted.
+func (s *Service) GenerateScoresReport(path string, candidates []discovery.Candidate, articles []extract.Article) error {
 	f := docx.NewFile()
 
 	// Header
@@ -229,29 +604,29 @@ func (s *Service) GenerateScoresReport(path string, candidates []discovery.Candi
 	p.AddText("- Relevance Score (0-100): Indicates how closely the article matches your specific query keywords and country intent. Higher is better.")
 
 	p = f.AddParagraph()
-	p.AddText("- Consensus Score: Represents cross-source validation. It counts how many *other* independent sources are covering essentially the same story (based on keyword overlap). A higher score suggests a major, verified event.")
+	p.AddText("- Consensus Score: Represents cross-source validation. It counts how many *other* independent sources (publishers) are covering essentially the same story (based on keyword overlap). A higher score suggests a major, verified event.")
+
+	addThemesSection(f, candidates)
 
 	f.AddParagraph() // Spacer
 	f.AddParagraph().AddText("--------------------------------------------------")
 	f.AddParagraph() // Spacer
 
+	previews := articlePreviews(articles)
 	for _, c := range candidates {
 		p = f.AddParagraph()
 		run = p.AddText(c.Title)
 
 		p = f.AddParagraph()
-		run = p.AddText(c.URL)
+		run = p.AddText(candidateLink(c))
 		run.Size(10)
 
-		consensusDesc := "Low"
-		if c.ConsensusScore >= 2 { consensusDesc = "Medium" }
-		if c.ConsensusScore >= 4 { consensusDesc = "High" }
-		if c.ConsensusScore >= 6 { consensusDesc = "Very High" }
-
 		p = f.AddParagraph()
-		run = p.AddText(fmt.Sprintf("Relevance: %d | Consensus: %d (%s)", c.RelevanceScore, c.ConsensusScore, consensusDesc))
+		run = p.AddText(fmt.Sprintf("Relevance: %d | Consensus: %d (%s)", c.RelevanceScore, c.ConsensusScore, s.Consensus.CandidateLabel(c)))
 		run.Color("008000")
 
+		addPreviewParagraph(f, previews, c)
+
 		f.AddParagraph() // Spacer
 	}
 
@@ -274,6 +649,7 @@ func (s *Service) GenerateResumeReport(path string, summary string, query string
 	// Summary Content
 	p = f.AddParagraph()
 	p.AddText(summary)
+	addActorsSection(f, s.keyActors(articles))
 
 	f.AddParagraph() // Spacer
 	f.AddParagraph().AddText("--------------------------------------------------")
@@ -282,8 +658,57 @@ func (s *Service) GenerateResumeReport(path string, summary string, query string
 	p = f.AddParagraph()
 	p.AddText("Based on sources:")
 	for _, art := range articles {
+		if art.LowQuality || art.DuplicateOf != "" {
+			continue
+		}
 		f.AddParagraph().AddText(fmt.Sprintf("- %s (%s)", art.Title, art.Site))
 	}
 
 	return f.Save(path)
 }
+
+// GenerateAllReports writes the article, scores and resume documents for a
+// prior search into outDir and returns the paths written. Documents with no
+// content (no articles, no candidates, no summary) are skipped.
+func (s *Service) GenerateAllReports(outDir string, result *SearchResult, articles []extract.Article, summary, query string) ([]string, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating output dir: %w", err)
+	}
+
+	now := time.Now()
+	var written []string
+
+	if len(articles) > 0 {
+		path := uniqueReportPath(outDir, "articles", now)
+		if err := s.GenerateArticleReport(path, articles); err != nil {
+			return written, fmt.Errorf("article report: %w", err)
+		}
+		written = append(written, path)
+	}
+
+	if result != nil && len(result.Candidates) > 0 {
+		path := uniqueReportPath(outDir, "scores", now)
+		if err := s.GenerateScoresReport(path, result.Candidates, articles); err != nil {
+			return written, fmt.Errorf("scores report: %w", err)
+		}
+		written = append(written, path)
+	}
+
+	if strings.TrimSpace(summary) != "" {
+		path := uniqueReportPath(outDir, "resume", now)
+		if err := s.GenerateResumeReport(path, summary, query, articles); err != nil {
+			return written, fmt.Errorf("resume report: %w", err)
+		}
+		written = append(written, path)
+
+		if s.ResumeTemplate != nil {
+			path, err := writeTemplatedResume(s.ResumeTemplate, outDir, summary, query, articles, s.keyActors(articles), now)
+			if err != nil {
+				return written, err
+			}
+			written = append(written, path)
+		}
+	}
+
+	return written, nil
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+	"newscheck/internal/extract"
+	"newscheck/internal/geo"
+)
+
+// fakeWorkerScript answers like worker.py: URLs containing "fail" get a
+// worker error, others a short article echoing the URL; summarize gets a
+// fixed summary.
+const fakeWorkerScript = `[ "$1" = summarize ] && { echo '{"ok": true, "summary": "Dockers shut every major port."}'; exit 0; }
+case "$2" in
+*fail*) echo '{"ok": false, "error": "HTTP 404"}' ;;
+*) printf '{"ok": true, "data": {"url": "%s", "title": "Port strike spreads", "site": "example", "text": "Dockers walked out at every major port on Monday."}}' "$2" ;;
+esac
+`
+
+// fakeWorker runs fakeWorkerScript through sh instead of Python.
+func fakeWorker(t *testing.T) *extract.Worker {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	script := filepath.Join(t.TempDir(), "worker.sh")
+	if err := os.WriteFile(script, []byte(fakeWorkerScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return &extract.Worker{Command: []string{"sh", script, "{mode}", "{url}"}}
+}
+
+func TestExtractAllMixedOutcomes(t *testing.T) {
+	svc := &Service{Worker: fakeWorker(t), Concurrency: Concurrency{Extraction: 2}}
+	urls := []string{"https://a.example/1", "https://fail.example/2", "https://a.example/3"}
+
+	var order []int
+	out := svc.ExtractAll(context.Background(), urls, "", func(i int, o ExtractOutcome) {
+		order = append(order, i)
+	})
+	if len(out) != len(urls) {
+		t.Fatalf("got %d outcomes, want %d", len(out), len(urls))
+	}
+	for i, o := range out {
+		if o.URL != urls[i] {
+			t.Errorf("outcome %d is for %s, want %s", i, o.URL, urls[i])
+		}
+		wantOK := !strings.Contains(urls[i], "fail")
+		if o.OK != wantOK || (o.Article != nil) != wantOK || (o.Error != "") == wantOK {
+			t.Errorf("outcome %d = %+v, want OK %v", i, o, wantOK)
+		}
+	}
+	if !strings.Contains(out[1].Error, "HTTP 404") {
+		t.Errorf("failure error = %q, want the worker's reason", out[1].Error)
+	}
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Errorf("done called in order %v, want 0 1 2", order)
+	}
+
+	// The JSON the server returns carries the same per-URL status
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[1]["ok"] != false || decoded[1]["article"] != nil || decoded[0]["article"] == nil {
+		t.Errorf("JSON outcomes = %s", b)
+	}
+}
+
+// newTestService is a Service that only knows France and discovers
+// through src.
+func newTestService(src discovery.Source) *Service {
+	return &Service{
+		Resolver:    geo.NewHybridResolver(nil, mapResolver{"france": {Name: "France", ISO2: "FR", Languages: []string{"fr"}}}, nil, nil),
+		Sources:     []DiscoverySource{{Source: src, PerPlan: 10}},
+		Consensus:   DefaultConsensusConfig(),
+		Concurrency: Concurrency{Discovery: 2, Resolution: 1, Extraction: 1},
+	}
+}
+
+func portStrikeResults(p discovery.Plan, lang discovery.LanguageProfile) ([]discovery.Candidate, error) {
+	return []discovery.Candidate{{
+		Title:       "Port strike spreads to " + lang.GL,
+		URL:         "https://news.example/" + lang.GL,
+		PublishedAt: time.Now().Add(-time.Hour),
+	}}, nil
+}
+
+func TestCandidateCacheSkipsSources(t *testing.T) {
+	src := &fakeSource{results: portStrikeResults}
+	svc := newTestService(src)
+	svc.Cache = &CandidateCache{Dir: t.TempDir(), TTL: time.Hour}
+	now := time.Now()
+	req := SearchRequest{Query: "port strike", Scope: ScopeGlobal, QueryLang: "en", From: now.AddDate(0, 0, -7), To: now}
+
+	first, err := svc.Search(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := len(src.calls)
+	if calls == 0 || first.Stats.CacheHit {
+		t.Fatalf("first search: %d source calls, cache hit %v", calls, first.Stats.CacheHit)
+	}
+
+	second, err := svc.Search(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.calls) != calls {
+		t.Errorf("identical search made %d more source calls, want 0", len(src.calls)-calls)
+	}
+	if !second.Stats.CacheHit || len(second.Candidates) != len(first.Candidates) {
+		t.Errorf("second search: cache hit %v, %d candidates; want a hit with %d", second.Stats.CacheHit, len(second.Candidates), len(first.Candidates))
+	}
+
+	// A different query misses the cache
+	req.Query = "port strike wages"
+	if _, err := svc.Search(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.calls) == calls {
+		t.Error("a different query was served from the cache")
+	}
+
+	// NoCache bypasses it
+	calls = len(src.calls)
+	req.NoCache = true
+	if _, err := svc.Search(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if len(src.calls) == calls {
+		t.Error("NoCache search was served from the cache")
+	}
+}
+
+func TestExtractCuratedRSSCandidate(t *testing.T) {
+	const feed = `<?xml version="1.0"?><rss version="2.0"><channel><title>Harbour Times</title>
+<item><title>Port strike spreads to Antwerp</title><link>https://harbour.example/news/strike</link>
+<description>Dockers walked out at every major port.</description></item>
+</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(feed))
+	}))
+	defer srv.Close()
+	curated := &discovery.CuratedFeeds{World: discovery.NewRSSFeeds([]string{srv.URL}), ByLang: map[string]*discovery.RSSFeeds{}}
+
+	plans := []SearchPlan{{Query: "port strike", Scope: "global", Weight: 1}}
+	cands, errs, err := runDiscoveryWithTargets(context.Background(), plans, lastWeek(), []geo.DiscoveryTarget{{ISO2: "FR", Lang: "fr"}},
+		[]DiscoverySource{{Source: curated, PerPlan: 10}}, DedupeCanonicalURL, 1, nil, nil)
+	if err != nil || len(errs) > 0 || len(cands) != 1 {
+		t.Fatalf("discovery = %+v, %v, %v; want the feed's item", cands, errs, err)
+	}
+
+	svc := &Service{Worker: fakeWorker(t), Concurrency: Concurrency{Extraction: 1}, Consensus: DefaultConsensusConfig()}
+	picked := pickTop(cands, ExtractByClusterDirect, svc.Consensus, 5)
+	if len(picked) != 1 {
+		t.Fatalf("picked %v, want the curated candidate", picked)
+	}
+	out := svc.ExtractAll(context.Background(), []string{cands[picked[0]].URL}, "", nil)
+	if len(out) != 1 || !out[0].OK || out[0].Article.URL != "https://harbour.example/news/strike" {
+		t.Errorf("outcome = %+v, want the publisher URL extracted directly", out)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+// sinceFile stores, per query, the newest PublishedAt reported by the
+// previous run (-since-file). It is a lighter alternative to the monitor's
+// seen-URL store: anything not strictly newer than the frontier is dropped.
+type sinceFile struct {
+	path    string
+	Queries map[string]time.Time `json:"queries"` // queryKey -> frontier
+}
+
+func loadSinceFile(path string) (*sinceFile, error) {
+	f := &sinceFile{path: filepath.Clean(path), Queries: map[string]time.Time{}}
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) || len(b) == 0 {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.path, err)
+	}
+	if f.Queries == nil {
+		f.Queries = map[string]time.Time{}
+	}
+	return f, nil
+}
+
+// queryKey hashes the query so case and spacing changes map to one frontier.
+func queryKey(query string) string {
+	norm := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	sum := sha256.Sum256([]byte(norm))
+	return hex.EncodeToString(sum[:8])
+}
+
+// filterNewer keeps the candidates published after the query's frontier and
+// advances the frontier to the newest PublishedAt seen, but never past now:
+// a future-dated item would otherwise hide everything published before its
+// date. On the first run for a query (no frontier) everything is kept.
+// Undated candidates can't be placed relative to the frontier, so they are
+// only kept on that first run.
+func (f *sinceFile) filterNewer(query string, cands []discovery.Candidate, now time.Time) []discovery.Candidate {
+	key := queryKey(query)
+	frontier, known := f.Queries[key]
+
+	newest := frontier
+	var kept []discovery.Candidate
+	for _, c := range cands {
+		if c.PublishedAt.After(newest) {
+			newest = c.PublishedAt
+		}
+		if !known || c.PublishedAt.After(frontier) {
+			kept = append(kept, c)
+		}
+	}
+	if newest.After(now) {
+		newest = now
+	}
+	if !newest.IsZero() {
+		f.Queries[key] = newest.UTC()
+	}
+	return kept
+}
+
+func (f *sinceFile) save() error {
+	b, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// applySinceFile drops res.Candidates that aren't newer than the previous
+// run's frontier for query, then records the new frontier in path.
+func applySinceFile(w io.Writer, path, query string, res *SearchResult) error {
+	f, err := loadSinceFile(path)
+	if err != nil {
+		return err
+	}
+	before := len(res.Candidates)
+	res.Candidates = f.filterNewer(query, res.Candidates, time.Now())
+	if dropped := before - len(res.Candidates); dropped > 0 {
+		fmt.Fprintf(w, "Since last run: %d new, %d already reported\n", len(res.Candidates), dropped)
+	}
+	return f.save()
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestSinceFileFilterNewer(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(url string, d time.Duration) discovery.Candidate {
+		return discovery.Candidate{URL: url, PublishedAt: now.Add(d)}
+	}
+
+	tests := []struct {
+		name         string
+		frontier     time.Time // zero = first run
+		in           []discovery.Candidate
+		wantURLs     []string
+		wantFrontier time.Time
+	}{
+		{
+			name:         "first run keeps everything",
+			in:           []discovery.Candidate{at("a", -2*time.Hour), {URL: "undated"}},
+			wantURLs:     []string{"a", "undated"},
+			wantFrontier: now.Add(-2 * time.Hour),
+		},
+		{
+			name:         "only newer kept, frontier advances",
+			frontier:     now.Add(-3 * time.Hour),
+			in:           []discovery.Candidate{at("old", -4*time.Hour), at("same", -3*time.Hour), at("new", -time.Hour), {URL: "undated"}},
+			wantURLs:     []string{"new"},
+			wantFrontier: now.Add(-time.Hour),
+		},
+		{
+			name:         "future-dated item clamps the frontier to now",
+			frontier:     now.Add(-3 * time.Hour),
+			in:           []discovery.Candidate{at("future", 48*time.Hour), at("new", -time.Hour)},
+			wantURLs:     []string{"future", "new"},
+			wantFrontier: now,
+		},
+		{
+			name:         "a future frontier from an older run is pulled back",
+			frontier:     now.Add(24 * time.Hour),
+			in:           []discovery.Candidate{at("new", -time.Hour)},
+			wantURLs:     nil,
+			wantFrontier: now,
+		},
+		{
+			name:         "nothing newer keeps the frontier",
+			frontier:     now.Add(-time.Hour),
+			in:           []discovery.Candidate{at("old", -2*time.Hour)},
+			wantURLs:     nil,
+			wantFrontier: now.Add(-time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := loadSinceFile(filepath.Join(t.TempDir(), "since.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.frontier.IsZero() {
+				f.Queries[queryKey("Port Strike")] = tt.frontier
+			}
+			got := urlsOf(f.filterNewer("port  strike", tt.in, now))
+			if len(got) != len(tt.wantURLs) {
+				t.Fatalf("kept %q, want %q", got, tt.wantURLs)
+			}
+			for i := range got {
+				if got[i] != tt.wantURLs[i] {
+					t.Errorf("kept %q, want %q", got, tt.wantURLs)
+					break
+				}
+			}
+			if fr := f.Queries[queryKey("port strike")]; !fr.Equal(tt.wantFrontier) {
+				t.Errorf("frontier = %v, want %v", fr, tt.wantFrontier)
+			}
+		})
+	}
+}
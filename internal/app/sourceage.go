@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+// Source kinds for FilterOptions.MaxAgePerSource.
+const (
+	// SourceKindFeed: curated and direct RSS feeds, not tied to a target.
+	SourceKindFeed = "feed"
+	// SourceKindCountry: news search editions (Google News, Bing) for one
+	// country and language.
+	SourceKindCountry = "country"
+)
+
+// sourceKind classifies c by how it was discovered.
+func sourceKind(c discovery.Candidate) string {
+	if c.TargetISO2 == "" {
+		return SourceKindFeed
+	}
+	return SourceKindCountry
+}
+
+// parseMaxAgePerSource reads "feed=24h,country=168h". Kinds left out keep
+// the search window only.
+func parseMaxAgePerSource(s string) (map[string]time.Duration, error) {
+	out := map[string]time.Duration{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kind, age, ok := strings.Cut(part, "=")
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !ok || (kind != SourceKindFeed && kind != SourceKindCountry) {
+			return nil, fmt.Errorf("invalid max age %q: want %s=<duration> or %s=<duration>", part, SourceKindFeed, SourceKindCountry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(age))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid max age for %s: %q", kind, age)
+		}
+		out[kind] = d
+	}
+	return out, nil
+}
+
+// formatMaxAges renders ages as "country 168h0m0s, feed 24h0m0s".
+func formatMaxAges(ages map[string]time.Duration) string {
+	parts := make([]string, 0, len(ages))
+	for kind, d := range ages {
+		parts = append(parts, kind+" "+d.String())
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// sourceFloors turns the per-kind ages into publish-time floors relative to now.
+func sourceFloors(ages map[string]time.Duration, now time.Time) map[string]time.Time {
+	if len(ages) == 0 {
+		return nil
+	}
+	floors := make(map[string]time.Time, len(ages))
+	for kind, d := range ages {
+		if d > 0 {
+			floors[kind] = now.Add(-d)
+		}
+	}
+	return floors
+}
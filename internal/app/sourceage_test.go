@@ -0,0 +1,60 @@
+package app
+
+import (
+	"slices"
+	"sort"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestParseMaxAgePerSource(t *testing.T) {
+	got, err := parseMaxAgePerSource(" Feed=24h, country=168h ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[SourceKindFeed] != 24*time.Hour || got[SourceKindCountry] != 168*time.Hour {
+		t.Errorf("ages = %v", got)
+	}
+	if s := formatMaxAges(got); s != "country 168h0m0s, feed 24h0m0s" {
+		t.Errorf("formatMaxAges = %q", s)
+	}
+	for _, bad := range []string{"rss=24h", "feed", "feed=soon", "country=-1h", "feed=0s"} {
+		if _, err := parseMaxAgePerSource(bad); err == nil {
+			t.Errorf("%q accepted", bad)
+		}
+	}
+}
+
+func TestMaxAgePerSource(t *testing.T) {
+	now := time.Now()
+	feed := func(url string, age time.Duration) discovery.Candidate {
+		return discovery.Candidate{Title: "Port strike spreads", URL: url, Source: "Harbour Times", PublishedAt: now.Add(-age)}
+	}
+	country := func(url string, age time.Duration) discovery.Candidate {
+		c := feed(url, age)
+		c.TargetISO2, c.TargetLang = "FR", "fr"
+		return c
+	}
+	cands := []discovery.Candidate{
+		feed("https://feed.example/fresh", 2*time.Hour),
+		feed("https://feed.example/stale", 3*24*time.Hour),
+		country("https://country.example/recent", 3*24*time.Hour),
+		country("https://country.example/old", 10*24*time.Hour),
+		{Title: "Port strike undated", URL: "https://feed.example/undated", Undated: true},
+	}
+
+	all := filterCandidates(cands, "port strike", Intent{}, nil, FilterOptions{})
+	if len(all) != len(cands) {
+		t.Fatalf("uniform default kept %v, want all", urlsOf(all))
+	}
+
+	opts := FilterOptions{MaxAgePerSource: map[string]time.Duration{SourceKindFeed: 24 * time.Hour, SourceKindCountry: 7 * 24 * time.Hour}}
+	got := urlsOf(filterCandidates(cands, "port strike", Intent{}, nil, opts))
+	sort.Strings(got)
+	want := []string{"https://country.example/recent", "https://feed.example/fresh", "https://feed.example/undated"}
+	if !slices.Equal(got, want) {
+		t.Errorf("kept %v, want %v", got, want)
+	}
+}
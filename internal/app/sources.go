@@ -0,0 +1,80 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"newscheck/internal/discovery"
+)
+
+// Discovery backend names for -sources.
+const (
+	SourceGoogle = "google" // Google News RSS search
+	SourceBing   = "bing"   // Bing News API (needs BING_NEWS_API_KEY)
+	SourceRSS    = "rss"    // curated feeds
+)
+
+// sourceKey returns the -sources name of s, "" for other sources.
+func sourceKey(s discovery.Source) string {
+	switch s.(type) {
+	case *discovery.GoogleNews:
+		return SourceGoogle
+	case *discovery.BingNews:
+		return SourceBing
+	case *discovery.CuratedFeeds:
+		return SourceRSS
+	}
+	return ""
+}
+
+// ParseSourceKeys parses a comma-separated -sources list ("google,rss").
+func ParseSourceKeys(v string) ([]string, error) {
+	var keys []string
+	for _, k := range strings.Split(v, ",") {
+		k = strings.ToLower(strings.TrimSpace(k))
+		switch k {
+		case "":
+			continue
+		case SourceGoogle, SourceBing, SourceRSS:
+			keys = append(keys, k)
+		default:
+			return nil, fmt.Errorf("unknown source %q (want %s, %s or %s)", k, SourceGoogle, SourceBing, SourceRSS)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no source given")
+	}
+	return keys, nil
+}
+
+// selectSources keeps the sources named in keys, in their original order.
+// No keys keeps them all. Errors when none of keys is available, e.g. bing
+// without an API key.
+func selectSources(sources []DiscoverySource, keys []string) ([]DiscoverySource, error) {
+	if len(keys) == 0 {
+		return sources, nil
+	}
+	want := map[string]bool{}
+	for _, k := range keys {
+		want[k] = true
+	}
+	var out []DiscoverySource
+	for _, ds := range sources {
+		if want[sourceKey(ds.Source)] {
+			out = append(out, ds)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("none of the sources %s is available", strings.Join(keys, ","))
+	}
+	return out, nil
+}
+
+// sourceNames lists the names of sources, for cache keys.
+func sourceNames(sources []DiscoverySource) string {
+	names := make([]string, len(sources))
+	for i, ds := range sources {
+		names[i] = ds.Source.Name()
+	}
+	return strings.Join(names, ",")
+}
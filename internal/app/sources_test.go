@@ -0,0 +1,61 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"newscheck/internal/discovery"
+)
+
+func TestParseSourceKeys(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"google", []string{"google"}, false},
+		{" Google , RSS ", []string{"google", "rss"}, false},
+		{"bing,,rss", []string{"bing", "rss"}, false},
+		{"newsapi", nil, true},
+		{" , ", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSourceKeys(tt.in)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSourceKeys(%q) = %v, %v; want %v, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSelectSources(t *testing.T) {
+	all := []DiscoverySource{
+		{Source: discovery.NewGoogleNews()},
+		{Source: &discovery.CuratedFeeds{}},
+	}
+	tests := []struct {
+		name    string
+		keys    []string
+		want    []string
+		wantErr bool
+	}{
+		{"no keys keeps all", nil, []string{SourceGoogle, SourceRSS}, false},
+		{"keeps original order", []string{SourceRSS, SourceGoogle}, []string{SourceGoogle, SourceRSS}, false},
+		{"one", []string{SourceRSS}, []string{SourceRSS}, false},
+		{"unavailable", []string{SourceBing}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectSources(all, tt.keys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var keys []string
+			for _, ds := range got {
+				keys = append(keys, sourceKey(ds.Source))
+			}
+			if !reflect.DeepEqual(keys, tt.want) {
+				t.Errorf("selectSources = %v, want %v", keys, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunStats summarizes what each pipeline stage did, to help diagnose empty
+// or surprising results.
+type RunStats struct {
+	CandidatesRaw      int            `json:"candidates_raw"`      // all items returned by sources
+	Deduped            int            `json:"deduped"`             // merged into a duplicate
+	Unusable           int            `json:"unusable,omitempty"`  // dropped for a blank URL or unusable title
+	CandidatesFiltered int            `json:"candidates_filtered"` // kept after relevance filtering
+	PerSource          map[string]int `json:"per_source"`          // raw items per source
+	MaxConsensus       int            `json:"max_consensus"`       // largest same-story cluster (article + peers)
+	CacheHit           bool           `json:"cache_hit,omitempty"` // candidates came from the candidate cache
+
+	// Candidates dropped by FilterOptions.MinSources.
+	BelowMinSources int `json:"below_min_sources,omitempty"`
+
+	Extracted     int `json:"extracted"`
+	ExtractFailed int `json:"extract_failed"`
+
+	Stages []StageTiming `json:"stages"`
+}
+
+type StageTiming struct {
+	Stage    string        `json:"stage"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+func newRunStats() *RunStats {
+	return &RunStats{PerSource: map[string]int{}}
+}
+
+// stage records the time elapsed since start under name. Nil-safe.
+func (s *RunStats) stage(name string, start time.Time) {
+	if s == nil {
+		return
+	}
+	s.Stages = append(s.Stages, StageTiming{Stage: name, Duration: time.Since(start)})
+}
+
+func (s *RunStats) addSource(source string, n int) {
+	if s == nil {
+		return
+	}
+	s.CandidatesRaw += n
+	s.PerSource[source] += n
+}
+
+func printRunStats(w io.Writer, s *RunStats) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintln(w, "\nRun statistics:")
+	if s.CacheHit {
+		fmt.Fprintln(w, "- Discovery: reused cached candidates (-no-cache to refresh)")
+	}
+	fmt.Fprintf(w, "- Candidates: %d raw, %d duplicates removed, %d after filtering\n", s.CandidatesRaw, s.Deduped, s.CandidatesFiltered)
+	if s.Unusable > 0 {
+		fmt.Fprintf(w, "- Dropped %d candidate(s) with a blank URL or a blank/short title\n", s.Unusable)
+	}
+	if s.BelowMinSources > 0 {
+		fmt.Fprintf(w, "- Dropped %d candidate(s) from stories with too few sources (-min-sources)\n", s.BelowMinSources)
+	}
+
+	sources := make([]string, 0, len(s.PerSource))
+	for src := range s.PerSource {
+		sources = append(sources, src)
+	}
+	sort.Strings(sources)
+	parts := make([]string, 0, len(sources))
+	for _, src := range sources {
+		parts = append(parts, fmt.Sprintf("%s=%d", src, s.PerSource[src]))
+	}
+	if len(parts) > 0 {
+		fmt.Fprintln(w, "- Per source:", strings.Join(parts, ", "))
+	}
+
+	fmt.Fprintf(w, "- Largest consensus cluster: %d articles\n", s.MaxConsensus)
+	if s.Extracted > 0 || s.ExtractFailed > 0 {
+		fmt.Fprintf(w, "- Extraction: %d ok, %d failed\n", s.Extracted, s.ExtractFailed)
+	}
+	for _, st := range s.Stages {
+		fmt.Fprintf(w, "- %-10s %s\n", st.Stage+":", st.Duration.Round(time.Millisecond))
+	}
+}
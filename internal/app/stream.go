@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"newscheck/internal/discovery"
+)
+
+// PartialFunc receives discovery results as each source call returns:
+// the source, its target ("ISO2/lang" or feed language) and the candidates
+// not seen from earlier calls (by the request's dedupe key). Calls never
+// overlap. The results are unfiltered; Search's final list is the ranked one.
+type PartialFunc func(source, target string, fresh []discovery.Candidate)
+
+// streamNew returns the candidates of found whose dedupe key isn't in seen
+// yet, and adds them to it.
+func streamNew(found []discovery.Candidate, dedupe DedupeStrategy, seen map[string]struct{}) []discovery.Candidate {
+	var fresh []discovery.Candidate
+	for _, c := range found {
+		if strings.TrimSpace(c.URL) == "" || !discovery.HasUsableTitle(c.Title) {
+			continue
+		}
+		k := dedupe.key(c)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		fresh = append(fresh, c)
+	}
+	return fresh
+}
+
+// printPartial is the CLI's -stream output: one line per new candidate as
+// its source answers, before filtering and ranking.
+func printPartial(w io.Writer) PartialFunc {
+	total := 0
+	return func(source, target string, fresh []discovery.Candidate) {
+		if len(fresh) == 0 {
+			return
+		}
+		total += len(fresh)
+		fmt.Fprintf(w, "  +%d from %s %s (%d so far)\n", len(fresh), source, target, total)
+		for _, c := range fresh {
+			fmt.Fprintf(w, "    %s\n", c.Title)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"newscheck/internal/extract"
+)
+
+// DefaultSummaryMaxChars caps each article's text in the summarization
+// prompt. Generous: most articles fit whole.
+const DefaultSummaryMaxChars = 12000
+
+// SummaryLimits bounds how much of each article goes into the summarization
+// prompt. Reports always keep the full text.
+type SummaryLimits struct {
+	// MaxChars per article; 0 = no limit.
+	MaxChars int
+	// TailChars of MaxChars are taken from the end of the text (where
+	// conclusions and updates often sit); 0 keeps only the beginning.
+	TailChars int
+}
+
+// truncationMarker joins the kept head and tail of a truncated text.
+const truncationMarker = "\n[...]\n"
+
+// truncate shortens s to about l.MaxChars runes, keeping the beginning and
+// l.TailChars from the end, cut at whitespace where possible.
+func (l SummaryLimits) truncate(s string) string {
+	r := []rune(s)
+	if l.MaxChars <= 0 || len(r) <= l.MaxChars {
+		return s
+	}
+	tail := l.TailChars
+	if tail < 0 {
+		tail = 0
+	}
+	if tail > l.MaxChars/2 {
+		tail = l.MaxChars / 2
+	}
+	head := l.MaxChars - tail
+
+	headText := string(r[:cutBack(r, head)])
+	if tail == 0 {
+		return strings.TrimSpace(headText) + truncationMarker
+	}
+	tailText := string(r[cutForward(r, len(r)-tail):])
+	return strings.TrimSpace(headText) + truncationMarker + strings.TrimSpace(tailText)
+}
+
+// cutBack moves i back to the last whitespace within a short distance so a
+// word isn't split; i itself if there is none.
+func cutBack(r []rune, i int) int {
+	for j := i; j > 0 && i-j < 80; j-- {
+		if unicode.IsSpace(r[j]) {
+			return j
+		}
+	}
+	return i
+}
+
+// cutForward is cutBack towards the end of r.
+func cutForward(r []rune, i int) int {
+	for j := i; j < len(r) && j-i < 80; j++ {
+		if unicode.IsSpace(r[j]) {
+			return j
+		}
+	}
+	return i
+}
+
+// summaryInput builds the summarization prompt for query from articles,
+// truncating each article's text under limits.
+func summaryInput(query string, articles []extract.Article, limits SummaryLimits) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("User Query: %s\n\n", query))
+	sb.WriteString("Source Articles:\n")
+	for _, art := range articles {
+		sb.WriteString(fmt.Sprintf("Title: %s\nSource: %s\nText:\n%s\n\n", art.Title, art.Site, limits.truncate(art.Text)))
+	}
+	return sb.String()
+}
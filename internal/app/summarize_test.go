@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"newscheck/internal/extract"
+)
+
+func TestSummaryLimitsTruncate(t *testing.T) {
+	text := strings.Repeat("dockers walk out ", 100) + "talks resume Friday"
+
+	if got := (SummaryLimits{}).truncate(text); got != text {
+		t.Errorf("no limit changed the text")
+	}
+	if got := (SummaryLimits{MaxChars: 10000}).truncate(text); got != text {
+		t.Errorf("text under the limit changed")
+	}
+
+	head := SummaryLimits{MaxChars: 200}.truncate(text)
+	if !strings.HasPrefix(head, "dockers walk out") || !strings.HasSuffix(head, truncationMarker) {
+		t.Errorf("head only = %q, want the beginning and a trailing marker", head)
+	}
+	if n := utf8.RuneCountInString(strings.TrimSuffix(head, truncationMarker)); n > 200 {
+		t.Errorf("head only kept %d chars, want at most 200", n)
+	}
+
+	both := SummaryLimits{MaxChars: 200, TailChars: 50}.truncate(text)
+	if !strings.HasPrefix(both, "dockers walk out") || !strings.HasSuffix(both, "talks resume Friday") || !strings.Contains(both, truncationMarker) {
+		t.Errorf("head and tail = %q, want the beginning, a marker and the end", both)
+	}
+}
+
+// TestSummarizerGetsTruncatedText runs ExtractAndSummarize against a fake
+// worker that records its summarize stdin.
+func TestSummarizerGetsTruncatedText(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	long := strings.Repeat("Dockers walked out at every major port. ", 200)
+	dir := t.TempDir()
+	capture := filepath.Join(dir, "summary-input.txt")
+	script := filepath.Join(dir, "worker.sh")
+	body := fmt.Sprintf(`case "$1" in
+summarize) cat > %q; echo '{"ok": true, "summary": "Ports shut."}' ;;
+*) printf '{"ok": true, "data": {"url": "%%s", "title": "Port strike spreads", "site": "example", "text": "%s"}}' "$2" ;;
+esac
+`, capture, long)
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &Service{
+		Worker:      &extract.Worker{Command: []string{"sh", script, "{mode}", "{url}"}},
+		Concurrency: Concurrency{Extraction: 1},
+		Summary:     SummaryLimits{MaxChars: 500},
+	}
+	articles, summary, err := svc.ExtractAndSummarize(context.Background(), []string{"https://a.example/1"}, "", "port strike", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary != "Ports shut." {
+		t.Errorf("summary = %q", summary)
+	}
+	if len(articles) != 1 || articles[0].Text != long {
+		t.Fatalf("returned articles lost the full text: %+v", articles)
+	}
+
+	in, err := os.ReadFile(capture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(in), long) || !strings.Contains(string(in), truncationMarker) {
+		t.Errorf("summarizer got the full text, want it truncated")
+	}
+	if n := utf8.RuneCount(in); n > 700 {
+		t.Errorf("summarizer input is %d chars, want about 500 plus the prompt header", n)
+	}
+}
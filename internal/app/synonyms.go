@@ -0,0 +1,147 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"newscheck/internal/text"
+)
+
+// DefaultSynonymsPath maps query terms to domain synonyms, e.g.
+// {"central bank": ["BoE", "monetary policy"]}.
+const DefaultSynonymsPath = "data/synonyms.json"
+
+// Bounds on what synonyms add to a search: expansions kept per term, and
+// expanded queries per scope.
+const (
+	maxSynonymsPerTerm = 3
+	maxSynonymQueries  = 4
+)
+
+// synonymHitScore is added per query term whose synonym, rather than the
+// term itself, is in a title: a bit less than the keyword match it stands
+// in for.
+const synonymHitScore = 8
+
+// Synonyms maps a lowercased term (one or more words) to expansions the
+// generic lexicons don't know. A query containing the term gets extra
+// plans with the term swapped for each expansion (BuildSearchPlans), and
+// titles carrying an expansion count as matching it (filterCandidates).
+type Synonyms map[string][]string
+
+// LoadSynonyms reads a {"term": ["synonym", ...]} file. A missing file
+// yields no synonyms. Expansions are trimmed, deduped and capped at
+// maxSynonymsPerTerm.
+func LoadSynonyms(path string) (Synonyms, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	out := make(Synonyms, len(raw))
+	for term, exps := range raw {
+		term = strings.Join(text.Tokenize(term), " ")
+		if term == "" {
+			continue
+		}
+		seen := map[string]struct{}{term: {}}
+		for _, e := range exps {
+			e = strings.TrimSpace(e)
+			key := strings.Join(text.Tokenize(e), " ")
+			if _, dup := seen[key]; dup || key == "" {
+				continue
+			}
+			seen[key] = struct{}{}
+			if len(out[term]) < maxSynonymsPerTerm {
+				out[term] = append(out[term], e)
+			}
+		}
+	}
+	return out, nil
+}
+
+// synonymMatch is a term of the query that has synonyms.
+type synonymMatch struct {
+	Term       string
+	Expansions []string
+}
+
+// matches returns the terms of query with synonyms, longest first so
+// "central bank rate" prefers "central bank" over "bank", then
+// alphabetical. A term matches whole words only.
+func (s Synonyms) matches(query string) []synonymMatch {
+	if len(s) == 0 {
+		return nil
+	}
+	joined := " " + strings.Join(text.Tokenize(query), " ") + " "
+	var out []synonymMatch
+	for term, exps := range s {
+		if len(exps) > 0 && strings.Contains(joined, " "+term+" ") {
+			out = append(out, synonymMatch{Term: term, Expansions: exps})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i].Term) != len(out[j].Term) {
+			return len(out[i].Term) > len(out[j].Term)
+		}
+		return out[i].Term < out[j].Term
+	})
+	return out
+}
+
+// expandedQueries returns up to maxSynonymQueries variants of query, each
+// with one matched term replaced by one of its synonyms, and the
+// "term → synonym" note explaining it.
+func (s Synonyms) expandedQueries(query string) (queries, notes []string) {
+	base := strings.Join(text.Tokenize(query), " ")
+	for _, m := range s.matches(query) {
+		for _, e := range m.Expansions {
+			if len(queries) == maxSynonymQueries {
+				return queries, notes
+			}
+			q := strings.TrimSpace(strings.Replace(" "+base+" ", " "+m.Term+" ", " "+strings.ToLower(e)+" ", 1))
+			queries = append(queries, q)
+			notes = append(notes, m.Term+" → "+e)
+		}
+	}
+	return queries, notes
+}
+
+// synonymHits counts the matched terms absent from the tokenized title but
+// present through one of their synonyms.
+func synonymHits(matches []synonymMatch, titleTokens []string) int {
+	joined := " " + strings.Join(titleTokens, " ") + " "
+	n := 0
+	for _, m := range matches {
+		if strings.Contains(joined, " "+m.Term+" ") {
+			continue
+		}
+		for _, e := range m.Expansions {
+			if strings.Contains(joined, " "+strings.Join(text.Tokenize(e), " ")+" ") {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// cacheKey identifies the expansions applied to query, so editing the
+// synonyms file doesn't serve candidates found without them.
+func (s Synonyms) cacheKey(query string) string {
+	var parts []string
+	for _, m := range s.matches(query) {
+		parts = append(parts, m.Term+"="+strings.Join(m.Expansions, "/"))
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+func TestLoadSynonyms(t *testing.T) {
+	tests := []struct {
+		name string
+		json string // "" = no file
+		want Synonyms
+	}{
+		{name: "missing file", want: nil},
+		{name: "empty map", json: `{}`, want: Synonyms{}},
+		{
+			name: "terms tokenized, expansions deduped and capped",
+			json: `{"Central  Bank": ["BoE", "boe", " ECB ", "Fed", "SNB"], "": ["x"]}`,
+			want: Synonyms{"central bank": {"BoE", "ECB", "Fed"}},
+		},
+		{
+			name: "expansion equal to the term dropped",
+			json: `{"truce": ["Truce", "ceasefire"]}`,
+			want: Synonyms{"truce": {"ceasefire"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "synonyms.json")
+			if tt.json != "" {
+				if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := LoadSynonyms(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadSynonyms = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShippedSynonymsFiles(t *testing.T) {
+	got, err := LoadSynonyms(filepath.Join("..", "..", DefaultSynonymsPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("shipped %s has %d terms, want none", DefaultSynonymsPath, len(got))
+	}
+	example, err := LoadSynonyms(filepath.Join("..", "..", "data", "synonyms.example.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(example) == 0 {
+		t.Error("synonyms.example.json is empty")
+	}
+}
+
+func TestSynonymExpansion(t *testing.T) {
+	syn := Synonyms{"central bank": {"BoE", "ECB"}, "bank": {"lender"}}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantExtra []string // synonym-expanded plan queries, sorted
+	}{
+		{name: "no term", query: "pension strikes"},
+		{name: "longest term first", query: "central bank rates", wantExtra: []string{"boe rates", "central lender rates", "ecb rates"}},
+		{name: "whole words only", query: "banking rules"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range BuildSearchPlans(tt.query, Intent{}, nil, nil, syn) {
+				if strings.HasPrefix(p.Explain, "synonym expansion") {
+					got = append(got, p.Query)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantExtra) {
+				t.Errorf("expanded plans = %q, want %q", got, tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestSynonymHitBoostsCandidate(t *testing.T) {
+	old := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	in := []discovery.Candidate{
+		{Title: "Rates held steady", URL: "https://a/1", PublishedAt: old},
+		{Title: "BoE holds rates steady", URL: "https://a/2", PublishedAt: old},
+	}
+	query := "central bank rates"
+	scoreOf := func(opts FilterOptions) map[string]int {
+		out := map[string]int{}
+		for _, c := range filterCandidates(in, query, Intent{}, nil, opts) {
+			out[c.URL] = c.RelevanceScore
+		}
+		return out
+	}
+	without := scoreOf(FilterOptions{})
+	with := scoreOf(FilterOptions{Synonyms: Synonyms{"central bank": {"BoE"}}})
+	if got := with["https://a/2"] - without["https://a/2"]; got != synonymHitScore {
+		t.Errorf("synonym hit added %d, want %d", got, synonymHitScore)
+	}
+	if with["https://a/1"] != without["https://a/1"] {
+		t.Errorf("title without the synonym changed score: %d -> %d", without["https://a/1"], with["https://a/1"])
+	}
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"newscheck/internal/discovery"
+)
+
+// Trace records everything one Search did, for auditing and regression
+// runs: the exact request, the planning chain, candidates before and after
+// filtering, and per-stage timestamps. Set SearchRequest.Trace to get one.
+type Trace struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	Request SearchRequest    `json:"request"`
+	Plan    *PlanExplanation `json:"plan"` // intent, countries, targets, plans
+
+	Stages []TraceStage `json:"stages"`
+
+	// Discovery output after deduplication, before relevance filtering.
+	RawCandidates []discovery.Candidate   `json:"raw_candidates"`
+	PerSource     map[string]int          `json:"per_source"`
+	CacheHit      bool                    `json:"cache_hit,omitempty"`
+	SourceErrors  []SourceError           `json:"source_errors"`
+	FeedFailures  []discovery.FeedOutcome `json:"feed_failures,omitempty"`
+
+	// Filtered and scored candidates, as returned.
+	Candidates []discovery.Candidate `json:"candidates"`
+}
+
+// TraceStage is one pipeline step with its wall-clock bounds.
+type TraceStage struct {
+	Stage    string        `json:"stage"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+func newTrace(req SearchRequest, now time.Time) *Trace {
+	return &Trace{StartedAt: now.UTC(), Request: req}
+}
+
+// stage records a step that began at start. Nil-safe.
+func (t *Trace) stage(name string, start time.Time) {
+	if t == nil {
+		return
+	}
+	t.Stages = append(t.Stages, TraceStage{Stage: name, Started: start.UTC(), Duration: time.Since(start)})
+}
+
+// writeTrace saves t as indented JSON at path, creating parent directories.
+func writeTrace(path string, t *Trace) error {
+	b, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTraceFileHasEveryStage(t *testing.T) {
+	svc := newTestService(&fakeSource{results: portStrikeResults})
+	now := time.Now()
+	res, err := svc.Search(context.Background(), SearchRequest{
+		Query: "port strike in France", Scope: ScopeChosen, ChosenCountry: "France", QueryLang: "en",
+		From: now.AddDate(0, 0, -7), To: now, Trace: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "traces", "run.json")
+	if err := writeTrace(path, res.Trace); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Trace
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	var stages []string
+	for _, s := range got.Stages {
+		stages = append(stages, s.Stage)
+		if s.Started.Before(got.StartedAt) || s.Started.After(got.FinishedAt) {
+			t.Errorf("stage %s started at %s, outside the run", s.Stage, s.Started)
+		}
+	}
+	if want := []string{"resolve", "discovery", "filter"}; !reflect.DeepEqual(stages, want) {
+		t.Errorf("stages = %v, want %v", stages, want)
+	}
+	if got.Request.Query != "port strike in France" {
+		t.Errorf("request query = %q", got.Request.Query)
+	}
+	if got.Plan == nil || len(got.Plan.Plans) == 0 || len(got.Plan.Targets) == 0 {
+		t.Errorf("plan = %+v, want the plans and targets", got.Plan)
+	}
+	if len(got.RawCandidates) == 0 || len(got.Candidates) == 0 || got.PerSource["Fake"] == 0 {
+		t.Errorf("raw %d, filtered %d, per source %v; want candidates from Fake", len(got.RawCandidates), len(got.Candidates), got.PerSource)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gingfrederik/docx"
+	"newscheck/internal/discovery"
+	"newscheck/internal/text"
+)
+
+// maxTrendItems is how many terms TopicTrends returns.
+const maxTrendItems = 15
+
+// TrendItem is one term that recurs across a result set.
+type TrendItem struct {
+	Term     string  `json:"term"`     // most common surface form of the stem
+	Articles int     `json:"articles"` // candidates mentioning it
+	Count    int     `json:"count"`    // mentions across all candidates
+	Weight   float64 `json:"weight"`   // summed TF-IDF
+}
+
+// TopicTrends returns the themes that dominate candidates: keywords of each
+// title (publisher suffix dropped) and description, grouped by stem and
+// ranked by TF-IDF summed over the candidates. The smoothed IDF
+// (1 + ln((1+N)/(1+df))) only tempers terms every article shares, so a
+// recurring term still beats a one-off. Terms in fewer than two candidates
+// are not trends and are left out.
+func TopicTrends(candidates []discovery.Candidate) []TrendItem {
+	type term struct {
+		df, count int
+		forms     map[string]int
+	}
+	terms := map[string]*term{}
+	for _, c := range candidates {
+		doc := text.NormalizeTitle(c.Title, c.Publisher, c.Source) + " " + c.Description
+		keep := map[string]struct{}{}
+		for _, k := range text.Keywords(doc, c.TargetLang, 0) {
+			keep[k] = struct{}{}
+		}
+		seen := map[string]struct{}{}
+		for _, tok := range text.Tokenize(doc) {
+			if _, ok := keep[tok]; !ok {
+				continue
+			}
+			stem := text.Stem(tok)
+			t := terms[stem]
+			if t == nil {
+				t = &term{forms: map[string]int{}}
+				terms[stem] = t
+			}
+			t.count++
+			t.forms[tok]++
+			if _, ok := seen[stem]; !ok {
+				seen[stem] = struct{}{}
+				t.df++
+			}
+		}
+	}
+
+	n := float64(len(candidates))
+	out := make([]TrendItem, 0, len(terms))
+	for _, t := range terms {
+		if t.df < 2 {
+			continue
+		}
+		idf := 1 + math.Log((1+n)/(1+float64(t.df)))
+		out = append(out, TrendItem{
+			Term:     commonestForm(t.forms),
+			Articles: t.df,
+			Count:    t.count,
+			Weight:   math.Round(float64(t.count)*idf*100) / 100,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Weight != out[j].Weight {
+			return out[i].Weight > out[j].Weight
+		}
+		return out[i].Term < out[j].Term
+	})
+	if len(out) > maxTrendItems {
+		out = out[:maxTrendItems]
+	}
+	return out
+}
+
+// commonestForm returns the most used form, ties alphabetical.
+func commonestForm(forms map[string]int) string {
+	best := ""
+	for f, n := range forms {
+		if best == "" || n > forms[best] || (n == forms[best] && f < best) {
+			best = f
+		}
+	}
+	return best
+}
+
+// trendsLine renders items as "strike (12), union (7)", counting articles.
+func trendsLine(items []TrendItem) string {
+	parts := make([]string, len(items))
+	for i, t := range items {
+		parts[i] = fmt.Sprintf("%s (%d)", t.Term, t.Articles)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// addThemesSection adds the "Top themes" lines of a scores report.
+func addThemesSection(f *docx.File, candidates []discovery.Candidate) {
+	themes := TopicTrends(candidates)
+	if len(themes) == 0 {
+		return
+	}
+	f.AddParagraph() // Spacer
+	f.AddParagraph().AddText("Top themes (articles mentioning each):")
+	f.AddParagraph().AddText(trendsLine(themes))
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"newscheck/internal/discovery"
+)
+
+func trendCandidates() []discovery.Candidate {
+	return []discovery.Candidate{
+		{Title: "Dockers strike at Antwerp port - Reuters", Publisher: "Reuters"},
+		{Title: "Port strike spreads as dockers walk out", Description: "The strike closed the port."},
+		{Title: "Antwerp port strike enters second day"},
+		{Title: "Strikes hit European ports"},
+		{Title: "Cold weather expected this weekend"},
+	}
+}
+
+func TestTopicTrends(t *testing.T) {
+	got := TopicTrends(trendCandidates())
+	if len(got) < 3 {
+		t.Fatalf("trends = %+v, want at least strike, port and dockers", got)
+	}
+	top := map[string]bool{got[0].Term: true, got[1].Term: true}
+	if !top["strike"] || !top["port"] {
+		t.Errorf("top trends = %+v, want strike and port", got[:2])
+	}
+	terms := map[string]TrendItem{}
+	for i, tr := range got {
+		terms[tr.Term] = tr
+		if i > 0 && tr.Weight > got[i-1].Weight {
+			t.Errorf("trends not sorted by weight: %+v", got)
+		}
+	}
+	if terms["strike"].Articles != 4 || terms["port"].Articles != 4 || terms["antwerp"].Articles != 2 || terms["dockers"].Articles != 2 {
+		t.Errorf("trends = %+v", got)
+	}
+	for _, oneOff := range []string{"weather", "reuters", "european"} {
+		if _, ok := terms[oneOff]; ok {
+			t.Errorf("%q is a trend, want one-offs and publisher names left out", oneOff)
+		}
+	}
+	if len(TopicTrends(nil)) != 0 || len(TopicTrends(trendCandidates()[:1])) != 0 {
+		t.Error("a single candidate has no trends")
+	}
+}
+
+func TestScoresReportThemes(t *testing.T) {
+	svc := &Service{Consensus: DefaultConsensusConfig()}
+	path := filepath.Join(t.TempDir(), "scores.docx")
+	if err := svc.GenerateScoresReport(path, trendCandidates(), nil); err != nil {
+		t.Fatal(err)
+	}
+	doc := docxText(t, path)
+	if !strings.Contains(doc, "Top themes") || !strings.Contains(doc, "strike (4)") {
+		t.Errorf("scores report lacks the themes section")
+	}
+}
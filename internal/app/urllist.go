@@ -0,0 +1,45 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"newscheck/internal/discovery"
+)
+
+// Output formats for -format.
+const (
+	FormatText = "text" // the interactive report (default)
+	FormatURLs = "urls" // only the final candidates' URLs, one per line
+)
+
+// urlList writes candidate URLs, one per line, for piping into other tools.
+// Variants of one page (see discovery.CanonicalizeURL) are written once,
+// across every write; tracking parameters are dropped. Nil-safe: a nil list
+// writes nothing.
+type urlList struct {
+	w    io.Writer
+	seen map[string]struct{}
+}
+
+func newURLList(w io.Writer) *urlList {
+	return &urlList{w: w, seen: map[string]struct{}{}}
+}
+
+// write lists candidates in their (relevance) order.
+func (l *urlList) write(candidates []discovery.Candidate) error {
+	if l == nil {
+		return nil
+	}
+	for _, c := range candidates {
+		key := discovery.CanonicalizeURL(c.URL)
+		if _, ok := l.seen[key]; ok {
+			continue
+		}
+		l.seen[key] = struct{}{}
+		if _, err := fmt.Fprintln(l.w, discovery.StripTracking(c.URL)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
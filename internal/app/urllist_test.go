@@ -0,0 +1,84 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"newscheck/internal/discovery"
+)
+
+func TestURLList(t *testing.T) {
+	var buf bytes.Buffer
+	l := newURLList(&buf)
+	batches := [][]discovery.Candidate{
+		{{URL: "https://example.com/a?utm_source=x&id=1"}, {URL: "https://www.example.com/a?id=1#top"}, {URL: "https://example.com/b"}},
+		{{URL: "https://example.com/b?fbclid=1"}, {URL: "https://example.com/c?z=2&a=1"}},
+	}
+	for _, b := range batches {
+		if err := l.write(b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "https://example.com/a?id=1\nhttps://example.com/b\nhttps://example.com/c?z=2&a=1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("wrote\n%s\nwant\n%s", got, want)
+	}
+
+	var nilList *urlList
+	if err := nilList.write(batches[0]); err != nil {
+		t.Errorf("nil list: %v", err)
+	}
+}
+
+func TestRunURLsSkipsDiscovery(t *testing.T) {
+	dir := t.TempDir()
+	list := filepath.Join(dir, "urls.txt")
+	body := "# pasted from the desk\nhttps://a.example/1\n\nhttps://fail.example/2\nhttps://a.example/1\nhttps://a.example/3\n"
+	if err := os.WriteFile(list, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	src := &fakeSource{}
+	svc := newTestService(src)
+	svc.Worker = fakeWorker(t)
+	var out bytes.Buffer
+	reportDir, err := runURLs(context.Background(), &out, svc, list, "Port strike", "en", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(src.calls) != 0 {
+		t.Errorf("discovery ran: %v", src.calls)
+	}
+	if !strings.Contains(out.String(), "2 of 3 extracted") {
+		t.Errorf("output = %q, want 2 of 3 URLs extracted", out.String())
+	}
+
+	files, err := os.ReadDir(reportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	got := strings.Join(names, " ")
+	if !strings.Contains(got, "articles") || !strings.Contains(got, "resume") {
+		t.Errorf("reports = %v, want an articles report and a resume", names)
+	}
+}
+
+func TestRunURLsRejectsBadLines(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "urls.txt")
+	if err := os.WriteFile(list, []byte("https://a.example/1\nnot a url\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := runURLs(context.Background(), io.Discard, newTestService(&fakeSource{}), list, "", "en", t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("err = %v, want the bad line reported", err)
+	}
+}
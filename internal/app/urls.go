@@ -0,0 +1,132 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// readURLsFile reads one URL per line from path ("-" = stdin). Blank lines
+// and "#" comments are skipped, repeats dropped; anything that isn't an
+// http(s) URL is an error.
+func readURLsFile(path string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var urls []string
+	seen := map[string]struct{}{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !isHTTPURL(line) {
+			return nil, fmt.Errorf("%s:%d: not an http(s) URL: %q", path, n, line)
+		}
+		if _, ok := seen[line]; ok {
+			continue
+		}
+		seen[line] = struct{}{}
+		urls = append(urls, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// isHTTPURL reports whether s is an absolute http(s) URL.
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ErrURLQuery is returned by Service.Search for a query that is only URLs
+// (see QueryURLs): those are articles to extract, not keywords to search.
+var ErrURLQuery = errors.New("the query is a URL: extract it instead of searching")
+
+// QueryURLs returns the URLs when a query is nothing but pasted links
+// (whitespace-separated, "www." ones get https://), else nil.
+func QueryURLs(query string) []string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return nil
+	}
+	var urls []string
+	seen := map[string]struct{}{}
+	for _, f := range fields {
+		f = strings.Trim(f, "<>\"'()")
+		if strings.HasPrefix(strings.ToLower(f), "www.") {
+			f = "https://" + f
+		}
+		if !isHTTPURL(f) {
+			return nil
+		}
+		if _, ok := seen[f]; !ok {
+			seen[f] = struct{}{}
+			urls = append(urls, f)
+		}
+	}
+	return urls
+}
+
+// runURLs extracts and summarizes the URLs listed in path, skipping
+// discovery, and writes the article and resume reports into a new folder
+// under outDir. label stands in for the query in the summary prompt and the
+// resume.
+func runURLs(ctx context.Context, w io.Writer, svc *Service, path, label, pivot, outDir string) (string, error) {
+	urls, err := readURLsFile(path)
+	if err != nil {
+		return "", err
+	}
+	if len(urls) == 0 {
+		return "", fmt.Errorf("%s: no URLs", path)
+	}
+	return extractURLs(ctx, w, svc, urls, label, pivot, outDir)
+}
+
+// extractURLs is runURLs for URLs already in hand (a pasted-URL query).
+func extractURLs(ctx context.Context, w io.Writer, svc *Service, urls []string, label, pivot, outDir string) (string, error) {
+	pivot, err := ValidatePivotLang(pivot)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(label) == "" {
+		label = "Provided articles"
+	}
+
+	fmt.Fprintf(w, "Extracting %d URLs...\n", len(urls))
+	articles, summary, err := svc.ExtractAndSummarize(ctx, urls, pivot, label, "")
+	if err != nil {
+		fmt.Fprintln(w, "Summarization failed:", err)
+	}
+	fmt.Fprintf(w, "%d of %d extracted\n", len(articles), len(urls))
+	if len(articles) == 0 {
+		return "", fmt.Errorf("no article could be extracted")
+	}
+
+	dir := filepath.Join(outDir, time.Now().Format("20060102_150405")+"_"+slugify(label))
+	written, err := svc.GenerateAllReports(dir, nil, articles, summary, label)
+	if err != nil {
+		return dir, err
+	}
+	for _, file := range written {
+		fmt.Fprintln(w, "Saved:", file)
+	}
+	return dir, nil
+}
@@ -0,0 +1,189 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultBingNewsEndpoint = "https://api.bing.microsoft.com/v7.0/news/search"
+
+// BingNews queries the Bing News Search API (v7).
+// It needs a subscription key; see NewBingNewsFromEnv.
+type BingNews struct {
+	Client   *http.Client
+	APIKey   string
+	Endpoint string
+}
+
+func NewBingNews(apiKey string) *BingNews {
+	return &BingNews{
+		Client:   &http.Client{Timeout: 20 * time.Second},
+		APIKey:   apiKey,
+		Endpoint: defaultBingNewsEndpoint,
+	}
+}
+
+// NewBingNewsFromEnv returns a BingNews source when BING_NEWS_API_KEY is set, nil otherwise.
+func NewBingNewsFromEnv() *BingNews {
+	key := strings.TrimSpace(os.Getenv("BING_NEWS_API_KEY"))
+	if key == "" {
+		return nil
+	}
+	return NewBingNews(key)
+}
+
+// ---------- API structs ----------
+type bingNewsResponse struct {
+	Value []bingNewsArticle `json:"value"`
+}
+
+type bingNewsArticle struct {
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	Description   string `json:"description"`
+	DatePublished string `json:"datePublished"`
+	Provider      []struct {
+		Name string `json:"name"`
+	} `json:"provider"`
+}
+
+func (b *BingNews) Discover(ctx context.Context, p Plan, lang LanguageProfile, from, to time.Time, limit int) ([]Candidate, error) {
+	if b.APIKey == "" {
+		return nil, errors.New("bing news: missing api key")
+	}
+
+	q := buildScopedQuery(p.Query, p.Scope, p.ScopeTerm)
+
+	params := url.Values{}
+	params.Set("q", q)
+	params.Set("count", fmt.Sprintf("%d", limit))
+	params.Set("sortBy", "Date")
+	params.Set("textFormat", "Raw")
+	if mkt := bingMarket(lang); mkt != "" {
+		params.Set("mkt", mkt)
+	}
+	if f := bingFreshness(from, to); f != "" {
+		params.Set("freshness", f)
+	}
+
+	callCtx, cancel := withCallTimeout(ctx, b.Client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodGet, b.Endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", b.APIKey)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("bing news http %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var res bingNewsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+
+	return bingArticlesToCandidates(res.Value, p, lang, from, to, limit), nil
+}
+
+func bingArticlesToCandidates(items []bingNewsArticle, p Plan, lang LanguageProfile, from, to time.Time, limit int) []Candidate {
+	out := make([]Candidate, 0, limit)
+	shortTitles := 0
+	for _, it := range items {
+		if len(out) >= limit {
+			break
+		}
+
+		if !HasUsableTitle(it.Name) {
+			shortTitles++
+			continue
+		}
+
+		pub, _ := parseBingDate(it.DatePublished)
+		if !inWindow(pub, from, to) {
+			continue
+		}
+
+		u := strings.TrimSpace(it.URL)
+		if !isValidPublisherURL(u) {
+			continue
+		}
+
+		source := "Bing News (" + lang.Code + ")"
+		if len(it.Provider) > 0 && strings.TrimSpace(it.Provider[0].Name) != "" {
+			source = strings.TrimSpace(it.Provider[0].Name)
+		}
+
+		out = append(out, Candidate{
+			Title:       strings.TrimSpace(it.Name),
+			URL:         u,
+			Source:      source,
+			Description: strings.TrimSpace(it.Description),
+			PublishedAt: pub,
+			Undated:     pub.IsZero(),
+			FoundBy:     fmt.Sprintf("%s | %s", p.Scope, p.Query),
+			TargetISO2:  lang.GL,
+			TargetLang:  lang.Code,
+		})
+	}
+	logShortTitles("Bing News", shortTitles)
+	return out
+}
+
+// bingMarket maps a language profile to a Bing market code, e.g. (fr, FR) -> "fr-FR".
+func bingMarket(lang LanguageProfile) string {
+	code := strings.ToLower(strings.TrimSpace(lang.Code))
+	gl := strings.ToUpper(strings.TrimSpace(lang.GL))
+	if code == "" || gl == "" {
+		return ""
+	}
+	return code + "-" + gl
+}
+
+// bingFreshness picks the narrowest Bing freshness bucket covering the window.
+// Results are still filtered by from/to afterwards.
+func bingFreshness(from, to time.Time) string {
+	if from.IsZero() {
+		return ""
+	}
+	span := time.Since(from)
+	switch {
+	case span <= 24*time.Hour:
+		return "Day"
+	case span <= 7*24*time.Hour:
+		return "Week"
+	case span <= 31*24*time.Hour:
+		return "Month"
+	}
+	return ""
+}
+
+func parseBingDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.0000000", "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
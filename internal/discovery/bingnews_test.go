@@ -0,0 +1,81 @@
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const bingFixture = `{"value": [
+  {"name": "Pension strikes halt French ports", "url": "https://www.lemonde.fr/a",
+   "description": "Dock workers walked out.", "datePublished": "2026-03-03T10:00:00.0000000Z",
+   "provider": [{"name": "Le Monde"}]},
+  {"name": "Brief", "url": "https://www.lemonde.fr/b", "datePublished": "2026-03-03T11:00:00.0000000Z"},
+  {"name": "Pension strikes began last year", "url": "https://www.lemonde.fr/c", "datePublished": "2025-01-03T10:00:00Z"},
+  {"name": "Undated pension strike explainer", "url": "https://www.france24.com/d"}
+]}`
+
+func TestBingNewsDiscover(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Ocp-Apim-Subscription-Key"); got != "key" {
+			t.Errorf("subscription key = %q, want key", got)
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "pension strikes" || q.Get("mkt") != "fr-FR" || q.Get("count") != "10" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(bingFixture))
+	}))
+	defer srv.Close()
+
+	b := NewBingNews("key")
+	b.Endpoint = srv.URL
+	lang := LanguageProfile{Code: "fr", HL: "fr", GL: "FR", CEID: "FR:fr"}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := b.Discover(context.Background(), Plan{Query: "pension strikes", Scope: "global"}, lang, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The short title and the out-of-window item are dropped
+	want := []struct {
+		url, source string
+		undated     bool
+	}{
+		{"https://www.lemonde.fr/a", "Le Monde", false},
+		{"https://www.france24.com/d", "Bing News (fr)", true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates %+v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		c := got[i]
+		if c.URL != w.url || c.Source != w.source || c.Undated != w.undated {
+			t.Errorf("candidate %d = %s %q undated=%v, want %s %q undated=%v", i, c.URL, c.Source, c.Undated, w.url, w.source, w.undated)
+		}
+		if c.TargetISO2 != "FR" || c.TargetLang != "fr" {
+			t.Errorf("candidate %d target = %s/%s, want FR/fr", i, c.TargetISO2, c.TargetLang)
+		}
+	}
+	if pub := got[0].PublishedAt; !pub.Equal(time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("PublishedAt = %s", pub)
+	}
+}
+
+func TestBingNewsHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	b := NewBingNews("key")
+	b.Endpoint = srv.URL
+	now := time.Now()
+	_, err := b.Discover(context.Background(), Plan{Query: "x", Scope: "global"}, LanguageProfile{Code: "en", GL: "US"}, now.AddDate(0, 0, -1), now, 10)
+	if err == nil {
+		t.Fatal("want an error on HTTP 403")
+	}
+}
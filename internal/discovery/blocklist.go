@@ -0,0 +1,97 @@
+package discovery
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// BlockedHostsEnv lists extra hosts (comma-separated) whose links are never
+// treated as publisher URLs, on top of the built-in Google rules.
+const BlockedHostsEnv = "NEWSCHECK_BLOCKED_HOSTS"
+
+// BlockedHosts adds hosts to reject alongside BlockedHostsEnv. A host also
+// covers its subdomains.
+var BlockedHosts []string
+
+var envBlockedHosts = sync.OnceValue(func() []string {
+	return splitHosts(os.Getenv(BlockedHostsEnv))
+})
+
+func splitHosts(s string) []string {
+	var out []string
+	for _, h := range strings.Split(s, ",") {
+		h = strings.ToLower(strings.Trim(strings.TrimSpace(h), "."))
+		if h != "" {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// isBlockedHost reports whether links on host are Google wrappers or on the
+// configured blocklist rather than publisher articles.
+func isBlockedHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	return isGoogleHost(host) || IsListedHost(host)
+}
+
+// IsListedHost reports whether host (or a parent domain) is on BlockedHosts
+// or BlockedHostsEnv. Unlike the Google rules, these are publishers nobody
+// wants, so callers filtering finished candidates can apply them too.
+func IsListedHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, lists := range [][]string{BlockedHosts, envBlockedHosts()} {
+		for _, b := range lists {
+			b = strings.ToLower(b)
+			if host == b || strings.HasSuffix(host, "."+b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsWrapperURL reports whether rawURL points at Google (a Google News
+// article wrapper or redirect) rather than the publisher, so the worker has
+// to unwrap it before extracting.
+func IsWrapperURL(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	return isGoogleHost(strings.ToLower(u.Hostname()))
+}
+
+// isGoogleHost matches google.<tld> on any ccTLD (google.de,
+// news.google.com.br, www.google.co.uk) and googleusercontent.com.
+func isGoogleHost(host string) bool {
+	if host == "googleusercontent.com" || strings.HasSuffix(host, ".googleusercontent.com") {
+		return true
+	}
+	labels := strings.Split(host, ".")
+	for i, l := range labels {
+		if l != "google" {
+			continue
+		}
+		// What follows must look like a public suffix: "com", "de",
+		// "co.uk", "com.br". This keeps google.example.org and
+		// google.abc.com publishers.
+		rest := labels[i+1:]
+		if len(rest) == 0 || len(rest) > 2 || (len(rest) == 2 && len(rest[1]) != 2) {
+			continue
+		}
+		ok := true
+		for _, r := range rest {
+			if len(r) < 2 || len(r) > 3 {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package discovery
+
+import "testing"
+
+func TestIsGoogleHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"google.com", true},
+		{"news.google.com", true},
+		{"www.google.co.uk", true},
+		{"news.google.com.br", true},
+		{"google.de", true},
+		{"lh3.googleusercontent.com", true},
+		{"google.example.org", false},
+		{"google.abc.com", false},
+		{"google.com.evil.io", false},
+		{"notgoogle.com", false},
+		{"google-news.co.uk", false},
+		{"googleusercontent.com.evil.io", false},
+		{"lemonde.fr", false},
+	}
+	for _, tt := range tests {
+		if got := isGoogleHost(tt.host); got != tt.want {
+			t.Errorf("isGoogleHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestIsWrapperURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://news.google.com/rss/articles/CBMiabc", true},
+		{"https://news.google.co.uk/articles/CBMiabc", true},
+		{"https://WWW.Google.com.br/url?q=https://g1.globo.com/x", true},
+		{"https://www.google.example.org/article", false},
+		{"https://www.bbc.co.uk/news/world-1", false},
+	}
+	for _, tt := range tests {
+		if got := IsWrapperURL(tt.url); got != tt.want {
+			t.Errorf("IsWrapperURL(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package discovery
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// trackingParams are query parameters that never change which article a URL
+// points to. Matched case-insensitively; "utm_" is a prefix match.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "mc_cid": true, "mc_eid": true,
+	"ocid": true, "cmpid": true, "cmp": true, "ref": true, "ref_src": true, "src": true,
+	"smid": true, "sr_share": true, "igshid": true, "taid": true, "ito": true, "at_medium": true,
+	"at_campaign": true, "guccounter": true, "outputtype": true, "amp": true,
+}
+
+// CanonicalizeURL returns a comparison key for an article URL, so variants of
+// the same page (tracking params, fragments, www., AMP editions) collapse in
+// dedupe. The result is for comparing, not necessarily for fetching.
+//
+// AMP rules: an "amp." host prefix, an "/amp" path segment (leading, inner or
+// trailing) and a ".amp"/".amp.html" suffix are all dropped.
+func CanonicalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(raw)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, p := range []string{"www.", "amp."} {
+		host = strings.TrimPrefix(host, p)
+	}
+
+	// Paths keep their case: some sites (and Google News IDs) are case-sensitive
+	path := u.EscapedPath()
+	for _, suf := range []string{".amp.html", ".amp"} {
+		if strings.HasSuffix(strings.ToLower(path), suf) {
+			path = path[:len(path)-len(suf)]
+		}
+	}
+	segs := strings.Split(path, "/")
+	kept := segs[:0]
+	for _, s := range segs {
+		if strings.EqualFold(s, "amp") {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	path = strings.TrimRight(strings.Join(kept, "/"), "/")
+
+	q := u.Query()
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		if !isTrackingParam(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(host)
+	b.WriteString(path)
+	for i, k := range keys {
+		if i == 0 {
+			b.WriteByte('?')
+		} else {
+			b.WriteByte('&')
+		}
+		vals := q[k]
+		sort.Strings(vals)
+		b.WriteString(url.QueryEscape(k) + "=" + url.QueryEscape(strings.Join(vals, ",")))
+	}
+	return b.String()
+}
+
+// StripTracking returns raw without its tracking parameters (see
+// CanonicalizeURL) and fragment, still fetchable. Unparseable or relative
+// input is returned trimmed.
+func StripTracking(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	// Rewrite the query only when a parameter goes, and keep the rest as
+	// written: some sites serve another page when parameters are reordered
+	// or re-escaped.
+	pairs := strings.Split(u.RawQuery, "&")
+	kept := pairs[:0]
+	for _, p := range pairs {
+		k, _, _ := strings.Cut(p, "=")
+		if uk, err := url.QueryUnescape(k); err == nil {
+			k = uk
+		}
+		if !isTrackingParam(k) {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) < len(pairs) {
+		u.RawQuery = strings.Join(kept, "&")
+	}
+	u.Fragment, u.RawFragment = "", ""
+	return u.String()
+}
+
+func isTrackingParam(k string) bool {
+	lk := strings.ToLower(k)
+	return trackingParams[lk] || strings.HasPrefix(lk, "utm_")
+}
+
+// resolveItemLink turns a feed item's link into an absolute URL: relative
+// links are resolved against base (the feed or site URL) and
+// protocol-relative ones ("//host/path") get https. Returns "" if link can't
+// be made absolute.
+func resolveItemLink(link, base string) string {
+	link = strings.TrimSpace(link)
+	if link == "" {
+		return ""
+	}
+	if strings.HasPrefix(link, "//") {
+		return "https:" + link
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	if ref.IsAbs() {
+		return link
+	}
+	b, err := url.Parse(strings.TrimSpace(base))
+	if err != nil || !b.IsAbs() {
+		return ""
+	}
+	return b.ResolveReference(ref).String()
+}
@@ -0,0 +1,67 @@
+package discovery
+
+import "testing"
+
+func TestStripTracking(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"no query", "https://example.com/a", "https://example.com/a"},
+		{"untouched query keeps order and escaping", "https://example.com/a?z=1&a=b%20c&id=7", "https://example.com/a?z=1&a=b%20c&id=7"},
+		{"tracking dropped, order kept", "https://example.com/a?z=1&utm_source=x&a=2&fbclid=y", "https://example.com/a?z=1&a=2"},
+		{"case-insensitive keys", "https://example.com/a?UTM_Medium=x&id=3", "https://example.com/a?id=3"},
+		{"only tracking", "https://example.com/a?utm_source=x&gclid=1", "https://example.com/a"},
+		{"escaped key", "https://example.com/a?utm%5Fsource=x&id=3", "https://example.com/a?id=3"},
+		{"fragment dropped", "https://example.com/a?id=3#comments", "https://example.com/a?id=3"},
+		{"trimmed", "  https://example.com/a  ", "https://example.com/a"},
+		{"relative left alone", "/a?utm_source=x", "/a?utm_source=x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripTracking(tt.in); got != tt.want {
+				t.Errorf("StripTracking(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalizeURL(t *testing.T) {
+	tests := []struct {
+		name, a, b string
+		same       bool
+	}{
+		{"tracking and fragment", "https://www.example.com/a?utm_source=x#top", "https://example.com/a", true},
+		{"param order", "https://example.com/a?x=1&y=2", "https://example.com/a?y=2&x=1", true},
+		{"amp edition", "https://example.com/amp/a", "https://example.com/a", true},
+		{"other article", "https://example.com/a?id=1", "https://example.com/a?id=2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := CanonicalizeURL(tt.a) == CanonicalizeURL(tt.b); same != tt.same {
+				t.Errorf("CanonicalizeURL(%q) == CanonicalizeURL(%q) is %v, want %v", tt.a, tt.b, same, tt.same)
+			}
+		})
+	}
+}
+
+func TestResolveItemLink(t *testing.T) {
+	const base = "https://harbour.example/section/feed.xml"
+	tests := []struct {
+		name, link, base, want string
+	}{
+		{"absolute untouched", "https://other.example/a", base, "https://other.example/a"},
+		{"protocol-relative gets https", "//cdn.harbour.example/a", base, "https://cdn.harbour.example/a"},
+		{"root-relative", "/news/a", base, "https://harbour.example/news/a"},
+		{"path-relative", "news/a?id=1", base, "https://harbour.example/section/news/a?id=1"},
+		{"trimmed", "  /news/a ", base, "https://harbour.example/news/a"},
+		{"blank", " ", base, ""},
+		{"relative without a usable base", "/news/a", "feed.xml", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveItemLink(tt.link, tt.base); got != tt.want {
+				t.Errorf("resolveItemLink(%q, %q) = %q, want %q", tt.link, tt.base, got, tt.want)
+			}
+		})
+	}
+}
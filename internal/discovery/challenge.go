@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrChallenge means a feed answered with a bot-protection page (a
+// Cloudflare "Just a moment..." JS challenge and the like) instead of XML.
+var ErrChallenge = errors.New("bot challenge page instead of feed")
+
+// challengeMarkers are lowercase snippets of common challenge pages.
+var challengeMarkers = [][]byte{
+	[]byte("cf-chl"),
+	[]byte("cf_chl_opt"),
+	[]byte("challenge-platform"),
+	[]byte("cf-browser-verification"),
+	[]byte("<title>just a moment...</title>"),
+	[]byte("attention required! | cloudflare"),
+	[]byte("enable javascript and cookies to continue"),
+	[]byte("ddos protection by"),
+}
+
+// isChallenge reports whether resp (with body raw) is a bot challenge: the
+// header Cloudflare sets on challenges, or an HTML body carrying one of the
+// known markers.
+func isChallenge(resp *http.Response, raw []byte) bool {
+	if strings.EqualFold(resp.Header.Get("Cf-Mitigated"), "challenge") {
+		return true
+	}
+	head := raw
+	if len(head) > 16<<10 {
+		head = head[:16<<10]
+	}
+	head = bytes.ToLower(head)
+	if !bytes.Contains(head, []byte("<html")) && !bytes.Contains(head, []byte("<!doctype html")) {
+		return false
+	}
+	for _, m := range challengeMarkers {
+		if bytes.Contains(head, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// browserHeaders is a fuller browser-like header set (see
+// RSSFeeds.BrowserHeaders). Accept-Encoding is left to the transport so
+// gzip is still decoded transparently.
+var browserHeaders = map[string]string{
+	"User-Agent":                "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+	"Accept":                    "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8, text/html;q=0.7, */*;q=0.5",
+	"Accept-Language":           "en-US,en;q=0.9",
+	"Cache-Control":             "no-cache",
+	"Sec-Ch-Ua":                 `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+	"Sec-Ch-Ua-Mobile":          "?0",
+	"Sec-Ch-Ua-Platform":        `"Windows"`,
+	"Sec-Fetch-Dest":            "document",
+	"Sec-Fetch-Mode":            "navigate",
+	"Sec-Fetch-Site":            "none",
+	"Sec-Fetch-User":            "?1",
+	"Upgrade-Insecure-Requests": "1",
+}
@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const cloudflarePage = `<!DOCTYPE html><html lang="en-US"><head><title>Just a moment...</title></head>
+<body><div id="challenge-platform"></div><noscript>Enable JavaScript and cookies to continue</noscript></body></html>`
+
+func TestIsChallenge(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		want   bool
+	}{
+		{"cloudflare page", http.Header{}, cloudflarePage, true},
+		{"mitigation header", http.Header{"Cf-Mitigated": {"challenge"}}, "", true},
+		{"feed", http.Header{}, workingFeed, false},
+		{"feed mentioning a challenge", http.Header{}, `<rss><item><title>cf-chl explained</title></item></rss>`, false},
+		{"plain HTML error", http.Header{}, "<html><body>Not found</body></html>", false},
+	}
+	for _, tt := range tests {
+		if got := isChallenge(&http.Response{Header: tt.header}, []byte(tt.body)); got != tt.want {
+			t.Errorf("%s: isChallenge = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRSSFeedsChallengePage(t *testing.T) {
+	var gotHeaders http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/403" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+		w.Write([]byte(cloudflarePage))
+	}))
+	defer srv.Close()
+
+	for _, path := range []string{"/403", "/200"} {
+		t.Run("HTTP "+strings.TrimPrefix(path, "/"), func(t *testing.T) {
+			r := NewRSSFeeds([]string{srv.URL + path})
+			r.BrowserHeaders = true
+			start := time.Now()
+			got, err := r.Discover(context.Background(), Plan{Query: "port strike", Scope: "global"}, start.AddDate(0, 0, -1), start, 10)
+			if err != nil || len(got) != 0 {
+				t.Fatalf("Discover = %v, %v; want no candidates and no error", got, err)
+			}
+			o := r.Outcomes(start)
+			if len(o) != 1 || o[0].OK || !strings.Contains(o[0].Err, ErrChallenge.Error()) {
+				t.Errorf("outcomes = %+v, want a challenge failure", o)
+			}
+			if gotHeaders.Get("Sec-Fetch-Mode") != "navigate" || !strings.Contains(gotHeaders.Get("User-Agent"), "Chrome") {
+				t.Errorf("request headers = %v, want the browser set", gotHeaders)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultCuratedFeedsPath maps language codes (and "world") to RSS feed URLs.
+const DefaultCuratedFeedsPath = "data/curated_feeds.json"
+
+// WorldFeeds is the key for feeds pulled on every search, whatever the targets.
+const WorldFeeds = "world"
+
+// DefaultWorldFeeds are used when the curated feeds file has no "world" entry
+// (or doesn't exist).
+var DefaultWorldFeeds = []string{
+	"https://rss.nytimes.com/services/xml/rss/nyt/World.xml",
+	"https://www.theguardian.com/world/rss",
+	"https://feeds.bbci.co.uk/news/world/rss.xml",
+	"https://www.aljazeera.com/xml/rss/all.xml",
+}
+
+// CuratedFeeds groups curated RSS feeds: World always, plus one group per
+// language, pulled when a discovery target uses that language.
+type CuratedFeeds struct {
+	World  *RSSFeeds
+	ByLang map[string]*RSSFeeds
+}
+
+// LoadCuratedFeeds reads a {"world": [...], "fr": [...], ...} file. A missing
+// file yields just DefaultWorldFeeds.
+func LoadCuratedFeeds(path string) (*CuratedFeeds, error) {
+	raw := map[string][]string{}
+	data, err := os.ReadFile(filepath.Clean(path))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	}
+
+	c := &CuratedFeeds{ByLang: map[string]*RSSFeeds{}}
+	for key, feeds := range raw {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" || len(feeds) == 0 {
+			continue
+		}
+		if key == WorldFeeds {
+			c.World = NewRSSFeeds(feeds)
+			continue
+		}
+		c.ByLang[key] = NewRSSFeeds(feeds)
+	}
+	if c.World == nil {
+		c.World = NewRSSFeeds(DefaultWorldFeeds)
+	}
+	return c, nil
+}
+
+// CuratedGroup is one set of curated feeds to run; Lang is "" for World.
+type CuratedGroup struct {
+	Lang  string
+	Feeds *RSSFeeds
+}
+
+// ForLanguages returns World plus the groups for langs that have feeds,
+// ordered by language code.
+func (c *CuratedFeeds) ForLanguages(langs []string) []CuratedGroup {
+	if c == nil {
+		return nil
+	}
+	var out []CuratedGroup
+	if c.World != nil {
+		out = append(out, CuratedGroup{Feeds: c.World})
+	}
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, l := range langs {
+		l = strings.ToLower(strings.TrimSpace(l))
+		if seen[l] || c.ByLang[l] == nil {
+			continue
+		}
+		seen[l] = true
+		keys = append(keys, l)
+	}
+	sort.Strings(keys)
+	for _, l := range keys {
+		out = append(out, CuratedGroup{Lang: l, Feeds: c.ByLang[l]})
+	}
+	return out
+}
+
+// FeedsForDomains returns the feeds, from any group, hosted on one of
+// domains (or a subdomain: "rss.nytimes.com" for "nytimes.com"), minus those
+// already in the skip groups.
+func (c *CuratedFeeds) FeedsForDomains(domains []string, skip []CuratedGroup) []string {
+	if c == nil || len(domains) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, g := range skip {
+		for _, f := range g.Feeds.Feeds {
+			seen[f] = true
+		}
+	}
+
+	groups := []*RSSFeeds{c.World}
+	keys := make([]string, 0, len(c.ByLang))
+	for l := range c.ByLang {
+		keys = append(keys, l)
+	}
+	sort.Strings(keys)
+	for _, l := range keys {
+		groups = append(groups, c.ByLang[l])
+	}
+
+	var out []string
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		for _, f := range g.Feeds {
+			if seen[f] || !hostInDomains(f, domains) {
+				continue
+			}
+			seen[f] = true
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func hostInDomains(rawURL string, domains []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
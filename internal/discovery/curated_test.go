@@ -0,0 +1,67 @@
+package discovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const frenchFeed = `<?xml version="1.0"?><rss version="2.0"><channel>
+<title>Le Quotidien</title><link>https://quotidien.example/</link>
+<item><title>Grève dans les ports français</title><link>https://quotidien.example/greve</link>
+<pubDate>Tue, 03 Mar 2026 10:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+func TestCuratedFeedsForFrenchTargets(t *testing.T) {
+	srv := feedServer(t, map[string]string{"/world": workingFeed, "/fr": frenchFeed})
+	path := filepath.Join(t.TempDir(), "curated_feeds.json")
+	cfg := `{"world": ["` + srv.URL + `/world"], "FR": ["` + srv.URL + `/fr"], "es": ["` + srv.URL + `/es"]}`
+	if err := os.WriteFile(path, []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := LoadCuratedFeeds(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targets := []LanguageProfile{{Code: "fr", GL: "FR"}, {Code: "fr", GL: "BE"}, {Code: "nl", GL: "BE"}}
+	var codes []string
+	for _, l := range c.Locales(targets) {
+		codes = append(codes, l.Code)
+	}
+	if !reflect.DeepEqual(codes, []string{"", "fr"}) {
+		t.Errorf("locales = %q, want World and fr once (no es, no nl feeds)", codes)
+	}
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := c.Discover(context.Background(), Plan{Query: "grève ports", Scope: "global"}, LanguageProfile{Code: "fr"}, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].URL != "https://quotidien.example/greve" {
+		t.Errorf("French group = %+v, want the French feed's item", got)
+	}
+}
+
+func TestLoadCuratedFeedsDefaults(t *testing.T) {
+	c, err := LoadCuratedFeeds(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(c.World.Feeds, DefaultWorldFeeds) || len(c.ByLang) != 0 {
+		t.Errorf("missing file = world %v, by language %v; want the default world feeds only", c.World.Feeds, c.ByLang)
+	}
+
+	shipped, err := LoadCuratedFeeds(filepath.Join("..", "..", DefaultCuratedFeedsPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lang := range []string{"fr", "es", "de"} {
+		if shipped.ByLang[lang] == nil || len(shipped.ByLang[lang].Feeds) == 0 {
+			t.Errorf("shipped %s has no %q feeds", DefaultCuratedFeedsPath, lang)
+		}
+	}
+}
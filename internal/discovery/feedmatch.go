@@ -0,0 +1,89 @@
+package discovery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MatchWeights score a feed item by where the query keywords appear: a
+// keyword in the title is a stronger signal than one buried in a verbose
+// description. Each keyword counts once, in the title if it is there.
+type MatchWeights struct {
+	Title       int `json:"title"`
+	Description int `json:"description"`
+}
+
+// DefaultMatchWeights is what a zero MatchWeights means.
+var DefaultMatchWeights = MatchWeights{Title: 3, Description: 1}
+
+func (w MatchWeights) orDefault() MatchWeights {
+	if w == (MatchWeights{}) {
+		return DefaultMatchWeights
+	}
+	return w
+}
+
+// Score sums the weights of keywords found in title or, failing that,
+// description. Both texts must already be lowercase. 0 = no match. Any
+// length filtering is the caller's (see curatedKeywords).
+func (w MatchWeights) Score(title, description string, keywords []string) int {
+	w = w.orDefault()
+	score := 0
+	for _, k := range keywords {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(title, k):
+			score += w.Title
+		case description != "" && strings.Contains(description, k):
+			score += w.Description
+		}
+	}
+	return score
+}
+
+// curatedKeywords drops keywords under 3 bytes, too short to match a
+// whole feed's items on their own. Counting bytes, not runes, keeps the
+// 2-character CJK bigrams text.Keywords yields for no-space scripts.
+func curatedKeywords(keywords []string) []string {
+	out := keywords[:0:0]
+	for _, k := range keywords {
+		if len(strings.TrimSpace(k)) >= 3 {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// ParseMatchWeights reads "title=3,description=1"; a part left out keeps
+// its DefaultMatchWeights value. A description weight of 0 matches titles
+// only.
+func ParseMatchWeights(s string) (MatchWeights, error) {
+	w := DefaultMatchWeights
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if !ok || err != nil || n < 0 {
+			return MatchWeights{}, fmt.Errorf("invalid match weight %q: want title=<n> or description=<n>", part)
+		}
+		switch strings.ToLower(strings.TrimSpace(k)) {
+		case "title":
+			w.Title = n
+		case "description", "desc":
+			w.Description = n
+		default:
+			return MatchWeights{}, fmt.Errorf("invalid match weight %q: want title=<n> or description=<n>", part)
+		}
+	}
+	if w.Title == 0 {
+		return MatchWeights{}, fmt.Errorf("the title match weight must be at least 1")
+	}
+	return w, nil
+}
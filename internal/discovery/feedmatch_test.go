@@ -0,0 +1,59 @@
+package discovery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchWeightsScore(t *testing.T) {
+	tests := []struct {
+		name, title, desc string
+		keywords          []string
+		w                 MatchWeights
+		want              int
+	}{
+		{"title hit", "france pension strikes", "", []string{"pension"}, MatchWeights{}, 3},
+		{"description hit", "strikes continue", "the pension reform", []string{"pension"}, MatchWeights{}, 1},
+		{"title wins over description", "pension reform", "pension reform", []string{"pension"}, MatchWeights{}, 3},
+		{"titles only", "strikes continue", "the pension reform", []string{"pension"}, MatchWeights{Title: 3}, 0},
+		{"short keyword", "eu sanctions on russia", "", []string{"eu"}, MatchWeights{}, 3},
+		{"cjk bigram", "日本代表が勝利", "", []string{"日本", "代表"}, MatchWeights{}, 6},
+		{"no match", "weather today", "", []string{"pension"}, MatchWeights{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.Score(tt.title, tt.desc, tt.keywords); got != tt.want {
+				t.Errorf("Score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCuratedKeywords(t *testing.T) {
+	got := curatedKeywords([]string{"eu", "pension", "日本", "a"})
+	want := []string{"pension", "日本"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("curatedKeywords = %v, want %v", got, want)
+	}
+}
+
+func TestParseMatchWeights(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    MatchWeights
+		wantErr bool
+	}{
+		{"", DefaultMatchWeights, false},
+		{"title=5", MatchWeights{Title: 5, Description: 1}, false},
+		{"title=2,desc=0", MatchWeights{Title: 2}, false},
+		{"title=0", MatchWeights{}, true},
+		{"body=1", MatchWeights{}, true},
+		{"title=-1", MatchWeights{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMatchWeights(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMatchWeights(%q) = %+v, %v; want %+v, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -1,15 +1,20 @@
 package discovery
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"html"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -20,14 +25,109 @@ type LanguageProfile struct {
 	CEID string // e.g. "CA:en"
 }
 
+// DefaultRateLimitCooldown is how long GoogleNews stops issuing requests
+// after a 429 when the response carries no usable Retry-After.
+const DefaultRateLimitCooldown = 30 * time.Second
+
+// MaxRateLimitCooldown caps a 429's Retry-After: the GoogleNews source is
+// shared by every search of a long-running process (serve), so an outsized
+// value must not stall them all.
+const MaxRateLimitCooldown = 2 * time.Minute
+
+// cooldownJitter spreads the default cooldown (not an explicit Retry-After).
+const cooldownJitter = 0.2
+
+// GoogleNewsCookieEnv supplies GoogleNews.ConsentCookie.
+const GoogleNewsCookieEnv = "NEWSCHECK_GOOGLE_COOKIE"
+
+// ErrInterstitial means Google News answered with an HTML page (typically
+// the cookie consent screen) instead of the RSS feed.
+var ErrInterstitial = errors.New("consent/interstitial page instead of RSS")
+
 type GoogleNews struct {
 	Client *http.Client
+
+	// RateLimitCooldown pauses every subsequent Discover call (across
+	// goroutines) after a 429. 0 uses DefaultRateLimitCooldown.
+	RateLimitCooldown time.Duration
+
+	// ConsentCookie, if set, is sent as the Cookie header (e.g. a CONSENT=...
+	// value copied from a browser) to get past the consent page some regions
+	// serve instead of the feed.
+	ConsentCookie string
+
+	// SaveRawDir, if set, keeps every fetched RSS body there, one file per
+	// query and target, for replaying with FromRawDir.
+	SaveRawDir string
+
+	// FromRawDir, if set, replays bodies saved with SaveRawDir instead of
+	// calling Google News: no HTTP request is made, and a search with no
+	// saved body fails.
+	FromRawDir string
+
+	mu          sync.Mutex
+	pausedUntil time.Time
 }
 
 func NewGoogleNews() *GoogleNews {
 	return &GoogleNews{
-		Client: &http.Client{Timeout: 20 * time.Second},
+		Client:            &http.Client{Timeout: 20 * time.Second},
+		RateLimitCooldown: DefaultRateLimitCooldown,
+		ConsentCookie:     os.Getenv(GoogleNewsCookieEnv),
+	}
+}
+
+// waitCooldown blocks until any 429 cooldown has passed. When ctx is done
+// first, or its deadline falls before the end of the cooldown, it returns at
+// once with an error giving the cooldown left, which the search reports as a
+// source error.
+func (g *GoogleNews) waitCooldown(ctx context.Context) error {
+	g.mu.Lock()
+	until := g.pausedUntil
+	g.mu.Unlock()
+
+	d := time.Until(until)
+	if d <= 0 {
+		return nil
+	}
+	paused := func(err error) error {
+		left := time.Until(until).Round(time.Second)
+		return fmt.Errorf("google news rss: rate limited, requests paused for another %s: %w", left, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(until) {
+		return paused(context.DeadlineExceeded)
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return paused(ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
+
+// startCooldown records a 429. Retry-After (seconds) wins over the
+// (jittered) default, up to MaxRateLimitCooldown; an already longer pause
+// is kept.
+func (g *GoogleNews) startCooldown(retryAfter string) time.Duration {
+	d := g.RateLimitCooldown
+	if d <= 0 {
+		d = DefaultRateLimitCooldown
+	}
+	if secs, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && secs > 0 {
+		d = time.Duration(secs) * time.Second
+	} else {
+		d = jitter(d, cooldownJitter)
+	}
+	d = min(d, MaxRateLimitCooldown)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.pausedUntil) {
+		g.pausedUntil = until
 	}
+	return d
 }
 
 // ---------- RSS structs ----------
@@ -60,7 +160,10 @@ var reHrefAny = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]+)"|'([^']+)')`)
 var reURLPattern = regexp.MustCompile(`https?://[^\s<>"']+`)
 
 func (g *GoogleNews) Discover(ctx context.Context, p Plan, lang LanguageProfile, from, to time.Time, limit int) ([]Candidate, error) {
-	q := buildScopedQuery(p.Query, p.Scope)
+	q := buildScopedQuery(p.Query, p.Scope, p.ScopeTerm)
+	if p.Publisher != "" {
+		q += " " + PublisherOperator(p.Publisher)
+	}
 
 	u := fmt.Sprintf(
 		"https://news.google.com/rss/search?q=%s&hl=%s&gl=%s&ceid=%s",
@@ -70,27 +173,7 @@ func (g *GoogleNews) Discover(ctx context.Context, p Plan, lang LanguageProfile,
 		url.QueryEscape(lang.CEID),
 	)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// More browser-like UA
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 newscheck/0.1 (+personal use)")
-	req.Header.Set("Accept", "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.1")
-
-	resp, err := g.Client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-		return nil, fmt.Errorf("google news rss http %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
-	}
-
-	raw, err := io.ReadAll(resp.Body)
+	raw, err := g.fetch(ctx, u, lang)
 	if err != nil {
 		return nil, err
 	}
@@ -102,16 +185,19 @@ func (g *GoogleNews) Discover(ctx context.Context, p Plan, lang LanguageProfile,
 
 	out := make([]Candidate, 0, limit)
 	skipped := 0
+	shortTitles := 0
 	for _, it := range feed.Channel.Items {
 		if len(out) >= limit {
 			break
 		}
 
-		pub, ok := parseGoogleRSSDate(it.PubDate)
-		if !ok {
+		if !HasUsableTitle(it.Title) {
+			shortTitles++
 			continue
 		}
-		if pub.Before(from) || pub.After(to) {
+
+		pub, _ := parseGoogleRSSDate(it.PubDate)
+		if !inWindow(pub, from, to) {
 			continue
 		}
 
@@ -135,19 +221,113 @@ func (g *GoogleNews) Discover(ctx context.Context, p Plan, lang LanguageProfile,
 			Title:       strings.TrimSpace(it.Title),
 			URL:         publisherURL,
 			Source:      "Google News RSS (" + lang.Code + ")",
+			Publisher:   strings.TrimSpace(it.Source.Text),
 			PublishedAt: pub,
+			Undated:     pub.IsZero(),
 			FoundBy:     fmt.Sprintf("%s | %s", p.Scope, p.Query),
+			TargetISO2:  lang.GL,
+			TargetLang:  lang.Code,
 		})
 	}
 
 	// Log how many were skipped
 	if skipped > 0 {
-		fmt.Printf("  (skipped %d Google News wrappers that couldn't be resolved)\n", skipped)
+		fmt.Fprintf(os.Stderr, "  (skipped %d Google News wrappers that couldn't be resolved)\n", skipped)
 	}
+	logShortTitles("Google News", shortTitles)
 
 	return out, nil
 }
 
+// fetch returns the RSS body for the search URL u: from FromRawDir when
+// set, else from Google News (saved to SaveRawDir when set, interstitials
+// included, so odd answers can be looked at too).
+func (g *GoogleNews) fetch(ctx context.Context, u string, lang LanguageProfile) ([]byte, error) {
+	if g.FromRawDir != "" {
+		raw, err := loadRawBody(g.FromRawDir, lang, u)
+		if err != nil {
+			return nil, err
+		}
+		if !looksLikeFeed(nil, raw) {
+			return nil, fmt.Errorf("google news rss: %w (saved response)", ErrInterstitial)
+		}
+		return raw, nil
+	}
+
+	if err := g.waitCooldown(ctx); err != nil {
+		return nil, err
+	}
+
+	callCtx, cancel := withCallTimeout(ctx, g.Client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// More browser-like UA
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 newscheck/0.1 (+personal use)")
+	req.Header.Set("Accept", "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.1")
+	if g.ConsentCookie != "" {
+		req.Header.Set("Cookie", g.ConsentCookie)
+	}
+
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		d := g.startCooldown(resp.Header.Get("Retry-After"))
+		return nil, fmt.Errorf("google news rss http %d: rate limited, pausing requests for %s", resp.StatusCode, d)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("google news rss http %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if g.SaveRawDir != "" {
+		if err := saveRawBody(g.SaveRawDir, lang, u, raw); err != nil {
+			return nil, fmt.Errorf("google news rss: saving raw response: %w", err)
+		}
+	}
+	if !looksLikeFeed(resp, raw) {
+		return nil, fmt.Errorf("google news rss: %w (%s from %s)", ErrInterstitial, resp.Header.Get("Content-Type"), resp.Request.URL.Host)
+	}
+	return raw, nil
+}
+
+// looksLikeFeed tells an RSS/Atom body from an HTML page served with a 200,
+// such as the consent screen (often after a redirect to consent.google.com).
+// resp may be nil for a saved body, which is then judged by its markers.
+func looksLikeFeed(resp *http.Response, raw []byte) bool {
+	if resp != nil && resp.Request != nil && strings.HasPrefix(resp.Request.URL.Host, "consent.") {
+		return false
+	}
+	head := raw
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	head = bytes.ToLower(head)
+	if bytes.Contains(head, []byte("<rss")) || bytes.Contains(head, []byte("<feed")) {
+		return true
+	}
+	if bytes.Contains(head, []byte("<html")) || bytes.Contains(head, []byte("<!doctype html")) {
+		return false
+	}
+	// No markers either way: trust an XML content type, let the parser decide
+	if resp == nil {
+		return true
+	}
+	return !strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "text/html")
+}
+
 // isGoogleNewsWrapper checks if the URL is a Google News wrapper that needs resolution
 func isGoogleNewsWrapper(u string) bool {
 	parsed, err := url.Parse(u)
@@ -312,20 +492,9 @@ func isValidPublisherURL(urlStr string) bool {
 		host = strings.Split(host, ":")[0]
 	}
 
-	// Reject Google domains
-	googleDomains := []string{
-		"google.com",
-		"www.google.com",
-		"news.google.com",
-		"google.ca",
-		"google.co.uk",
-		"google.fr",
-	}
-
-	for _, gd := range googleDomains {
-		if host == gd || strings.HasSuffix(host, "."+gd) {
-			return false
-		}
+	// Reject Google wrappers on any ccTLD and configured blocklisted hosts
+	if isBlockedHost(host) {
+		return false
 	}
 
 	// Reject obviously invalid URLs
@@ -384,16 +553,20 @@ func parseGoogleRSSDate(s string) (time.Time, bool) {
 	return time.Time{}, false
 }
 
-func buildScopedQuery(q, scope string) string {
+// buildScopedQuery appends the scope's query term (country/region name) to q.
+// term wins over the raw scope value, so "country:VE" can be searched as "Venezuela".
+func buildScopedQuery(q, scope, term string) string {
 	q = strings.TrimSpace(q)
-	if scope == "" || scope == "global" {
+	sc := ParseScope(scope)
+	if sc.Kind == "global" {
 		return q
 	}
-	if strings.HasPrefix(scope, "region:") {
-		return q + " " + strings.TrimPrefix(scope, "region:")
+	term = strings.TrimSpace(term)
+	if term == "" {
+		term = sc.Value
 	}
-	if strings.HasPrefix(scope, "country:") {
-		return q + " " + strings.TrimPrefix(scope, "country:")
+	if term == "" || strings.Contains(strings.ToLower(q), strings.ToLower(term)) {
+		return q
 	}
-	return q
+	return q + " " + term
 }
@@ -0,0 +1,172 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGoogleNewsRateLimitCooldown(t *testing.T) {
+	var calls atomic.Int32
+	g := NewGoogleNews()
+	g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": {"86400"}},
+			Body:       http.NoBody,
+			Request:    r,
+		}, nil
+	})}
+	lang := LanguageProfile{Code: "en", HL: "en-US", GL: "US", CEID: "US:en"}
+	plan := Plan{Query: "elections", Scope: "global"}
+	now := time.Now()
+
+	_, err := g.Discover(context.Background(), plan, lang, now.AddDate(0, 0, -1), now, 10)
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Fatalf("first Discover err = %v, want rate limited", err)
+	}
+	if left := time.Until(g.pausedUntil); left > MaxRateLimitCooldown || left < MaxRateLimitCooldown-time.Minute {
+		t.Errorf("cooldown = %s, want capped at %s", left, MaxRateLimitCooldown)
+	}
+
+	// The pause applies to the next call, which can't outlast its deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = g.Discover(ctx, plan, lang, now.AddDate(0, 0, -1), now, 10)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "paused for another") {
+		t.Errorf("second Discover err = %v, want the remaining cooldown", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("second Discover waited %s instead of failing fast", time.Since(start))
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("%d HTTP requests, want 1 (the second call must not reach Google News)", n)
+	}
+}
+
+const consentPage = `<!DOCTYPE html><html><head><title>Before you continue to Google</title></head>
+<body><form action="https://consent.google.com/save" method="POST"><button>Accept all</button></form></body></html>`
+
+func TestGoogleNewsInterstitial(t *testing.T) {
+	lang := LanguageProfile{Code: "de", HL: "de", GL: "DE", CEID: "DE:de"}
+	plan := Plan{Query: "wahl", Scope: "global"}
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		host        string // of the final request, after redirects
+		contentType string
+		body        string
+	}{
+		{"redirect to consent host", "consent.google.com", "application/xml", `<form action="save"></form>`},
+		{"HTML page with a 200", "news.google.com", "text/html; charset=utf-8", consentPage},
+		{"HTML page without a content type", "news.google.com", "", consentPage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGoogleNews()
+			g.ConsentCookie = "CONSENT=YES+1"
+			g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				if got := r.Header.Get("Cookie"); got != "CONSENT=YES+1" {
+					t.Errorf("Cookie = %q, want the consent cookie", got)
+				}
+				final := r.Clone(r.Context())
+				final.URL.Host = tt.host
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {tt.contentType}},
+					Body:       io.NopCloser(strings.NewReader(tt.body)),
+					Request:    final,
+				}, nil
+			})}
+			_, err := g.Discover(context.Background(), plan, lang, now.AddDate(0, 0, -1), now, 10)
+			if !errors.Is(err, ErrInterstitial) {
+				t.Errorf("err = %v, want ErrInterstitial", err)
+			}
+		})
+	}
+}
+
+func TestGoogleNewsSavedInterstitial(t *testing.T) {
+	dir := t.TempDir()
+	lang := LanguageProfile{Code: "de", HL: "de", GL: "DE", CEID: "DE:de"}
+	plan := Plan{Query: "wahl", Scope: "global"}
+	now := time.Now()
+
+	live := NewGoogleNews()
+	live.SaveRawDir = dir
+	live.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/html"}},
+			Body:       io.NopCloser(strings.NewReader(consentPage)),
+			Request:    r,
+		}, nil
+	})}
+	if _, err := live.Discover(context.Background(), plan, lang, now.AddDate(0, 0, -1), now, 10); !errors.Is(err, ErrInterstitial) {
+		t.Fatalf("live err = %v, want ErrInterstitial", err)
+	}
+
+	// The interstitial was saved, and replaying it fails the same way
+	replay := NewGoogleNews()
+	replay.FromRawDir = dir
+	if _, err := replay.Discover(context.Background(), plan, lang, now.AddDate(0, 0, -1), now, 10); !errors.Is(err, ErrInterstitial) {
+		t.Errorf("replay err = %v, want ErrInterstitial", err)
+	}
+}
+
+func TestBuildScopedQuery(t *testing.T) {
+	tests := []struct {
+		name, q, scope, term, want string
+	}{
+		{"global untouched", "port strike", "global", "Venezuela", "port strike"},
+		{"country name from the term, not the ISO2", "port strike", "country:VE", "Venezuela", "port strike Venezuela"},
+		{"no term falls back to the scope value", "port strike", "country:Venezuela", "", "port strike Venezuela"},
+		{"region", "port strike", "region:Europe", "", "port strike Europe"},
+		{"name already in the query", "Venezuela port strike", "country:VE", "venezuela", "Venezuela port strike"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildScopedQuery(tt.q, tt.scope, tt.term); got != tt.want {
+				t.Errorf("buildScopedQuery(%q, %q, %q) = %q, want %q", tt.q, tt.scope, tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleNewsRecordsTarget(t *testing.T) {
+	g := NewGoogleNews()
+	g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/rss+xml"}},
+			Body:       io.NopCloser(strings.NewReader(testFeed)),
+			Request:    r,
+		}, nil
+	})}
+	lang := LanguageProfile{Code: "fr", HL: "fr-BE", GL: "BE", CEID: "BE:fr"}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := g.Discover(context.Background(), Plan{Query: "grève", Scope: "global"}, lang, from, from.AddDate(0, 0, 7), 10)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Discover = %+v, %v; want 1 candidate", got, err)
+	}
+	if got[0].TargetISO2 != "BE" || got[0].TargetLang != "fr" {
+		t.Errorf("target = %s/%s, want BE/fr", got[0].TargetISO2, got[0].TargetLang)
+	}
+	b, err := json.Marshal(got[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"target_iso2":"BE"`) || !strings.Contains(string(b), `"target_lang":"fr"`) {
+		t.Errorf("JSON %s lacks the target", b)
+	}
+}
@@ -1,7 +1,14 @@
 package discovery
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Simple starter profiles.
 // You can tweak these anytime (HL/GL/CEID influence what Google News returns).
+// Searches pick their locales from discovery targets instead; see ParseLocale
+// for choosing the worldwide default.
 
 func DefaultLanguageProfiles() map[string]LanguageProfile {
 	return map[string]LanguageProfile{
@@ -11,3 +18,27 @@ func DefaultLanguageProfiles() map[string]LanguageProfile {
 		"pt": {Code: "pt", HL: "pt-BR", GL: "BR", CEID: "BR:pt-419"},  // Portuguese (Brazil-heavy)
 	}
 }
+
+// ParseLocale turns a locale like "en-US" (or "en_US") into the profile
+// Google News uses for it: HL "en-US", GL "US", CEID "US:en".
+func ParseLocale(s string) (LanguageProfile, error) {
+	lang, region, ok := strings.Cut(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"), "-")
+	lang, region = strings.ToLower(lang), strings.ToUpper(region)
+	if !ok || !isLetters(lang, 2) || !isLetters(region, 2) {
+		return LanguageProfile{}, fmt.Errorf("invalid locale %q: want language-REGION, e.g. en-US or fr-FR", s)
+	}
+	return LanguageProfile{Code: lang, HL: lang + "-" + region, GL: region, CEID: region + ":" + lang}, nil
+}
+
+// isLetters reports whether s is n ASCII letters.
+func isLetters(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -7,8 +7,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
+
+	"newscheck/internal/text"
 )
 
 // MultiSourceDiscovery combines multiple news sources
@@ -16,6 +19,10 @@ type MultiSourceDiscovery struct {
 	GoogleNews  *GoogleNews
 	directFeeds map[string][]string // country -> RSS feed URLs
 	client      *http.Client
+
+	// Match weighs direct-feed title hits against description hits; zero =
+	// DefaultMatchWeights.
+	Match MatchWeights
 }
 
 func NewMultiSourceDiscovery() *MultiSourceDiscovery {
@@ -32,28 +39,28 @@ func (m *MultiSourceDiscovery) Discover(ctx context.Context, p Plan, lang Langua
 	seenURLs := make(map[string]bool)
 
 	// 1. Try Google News first (filtered for real URLs only)
-	fmt.Printf("  Searching Google News RSS...\n")
+	fmt.Fprintf(os.Stderr, "  Searching Google News RSS...\n")
 	gnCandidates, err := m.GoogleNews.Discover(ctx, p, lang, from, to, limit*2)
 	if err != nil {
-		fmt.Printf("  Warning: Google News failed: %v\n", err)
+		fmt.Fprintf(os.Stderr, "  Warning: Google News failed: %v\n", err)
 	} else {
 		for _, c := range gnCandidates {
-			normalizedURL := normalizeURL(c.URL)
+			normalizedURL := CanonicalizeURL(c.URL)
 			if !seenURLs[normalizedURL] {
 				seenURLs[normalizedURL] = true
 				allCandidates = append(allCandidates, c)
 			}
 		}
-		fmt.Printf("  Found %d articles from Google News\n", len(allCandidates))
+		fmt.Fprintf(os.Stderr, "  Found %d articles from Google News\n", len(allCandidates))
 	}
 
 	// 2. If we don't have enough results, try direct feeds for this country
 	if len(allCandidates) < limit/2 {
 		countryCode := lang.GL // e.g., "CA"
 		if feeds, ok := m.directFeeds[countryCode]; ok {
-			fmt.Printf("  Searching direct publisher feeds for %s...\n", countryCode)
+			fmt.Fprintf(os.Stderr, "  Searching direct publisher feeds for %s...\n", countryCode)
 
-			keywords := extractSearchKeywords(p.Query)
+			keywords := text.Keywords(p.Query, "", 0)
 			for _, feedURL := range feeds {
 				if len(allCandidates) >= limit {
 					break
@@ -65,14 +72,14 @@ func (m *MultiSourceDiscovery) Discover(ctx context.Context, p Plan, lang Langua
 				}
 
 				for _, c := range candidates {
-					normalizedURL := normalizeURL(c.URL)
+					normalizedURL := CanonicalizeURL(c.URL)
 					if !seenURLs[normalizedURL] {
 						seenURLs[normalizedURL] = true
 						allCandidates = append(allCandidates, c)
 					}
 				}
 			}
-			fmt.Printf("  Total articles after direct feeds: %d\n", len(allCandidates))
+			fmt.Fprintf(os.Stderr, "  Total articles after direct feeds: %d\n", len(allCandidates))
 		}
 	}
 
@@ -86,7 +93,10 @@ func (m *MultiSourceDiscovery) Discover(ctx context.Context, p Plan, lang Langua
 
 // fetchDirectFeed fetches and filters articles from a direct RSS feed
 func (m *MultiSourceDiscovery) fetchDirectFeed(ctx context.Context, feedURL string, keywords []string, from, to time.Time, limit int) ([]Candidate, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	callCtx, cancel := withCallTimeout(ctx, m.client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(callCtx, http.MethodGet, feedURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -122,36 +132,34 @@ func (m *MultiSourceDiscovery) fetchDirectFeed(ctx context.Context, feedURL stri
 	}
 
 	var candidates []Candidate
+	shortTitles := 0
 	for _, item := range feed.Channel.Items {
-		// Parse date
-		pub, ok := parseGoogleRSSDate(item.PubDate)
-		if !ok {
+		if !HasUsableTitle(item.Title) {
+			shortTitles++
 			continue
 		}
 
-		// Filter by date range
-		if pub.Before(from) || pub.After(to) {
+		// Parse date
+		pub, _ := parseGoogleRSSDate(item.PubDate)
+
+		// Filter by date range (undated items are kept)
+		if !inWindow(pub, from, to) {
 			continue
 		}
 
-		// Filter by keywords in title and description
+		// Filter by keywords, title hits weighing more than description ones
 		titleLower := strings.ToLower(item.Title)
 		descLower := strings.ToLower(item.Description)
-		matchCount := 0
-		for _, kw := range keywords {
-			if strings.Contains(titleLower, kw) || strings.Contains(descLower, kw) {
-				matchCount++
-			}
-		}
+		match := m.Match.Score(titleLower, descLower, keywords)
 
 		// Require at least 1 keyword match for relevance
-		if len(keywords) > 0 && matchCount == 0 {
+		if len(keywords) > 0 && match == 0 {
 			continue
 		}
 
-		// Get the actual article URL
-		articleURL := strings.TrimSpace(item.Link)
-		if articleURL == "" {
+		// Get the actual article URL (some feeds use relative or //host links)
+		articleURL := resolveItemLink(item.Link, feedURL)
+		if !isValidPublisherURL(articleURL) {
 			continue
 		}
 
@@ -165,7 +173,9 @@ func (m *MultiSourceDiscovery) fetchDirectFeed(ctx context.Context, feedURL stri
 			URL:         articleURL,
 			Source:      publisherName,
 			PublishedAt: pub,
+			Undated:     pub.IsZero(),
 			FoundBy:     fmt.Sprintf("Direct RSS: %s", publisherName),
+			FeedMatch:   match,
 		})
 
 		if len(candidates) >= limit {
@@ -173,6 +183,7 @@ func (m *MultiSourceDiscovery) fetchDirectFeed(ctx context.Context, feedURL stri
 		}
 	}
 
+	logShortTitles(publisherName, shortTitles)
 	return candidates, nil
 }
 
@@ -208,36 +219,3 @@ func getDirectFeedsByCountry() map[string][]string {
 		// Add more countries as needed
 	}
 }
-
-func extractSearchKeywords(query string) []string {
-	query = strings.ToLower(query)
-	words := strings.Fields(query)
-
-	stopWords := map[string]bool{
-		"the": true, "a": true, "an": true, "and": true, "or": true,
-		"but": true, "in": true, "on": true, "at": true, "to": true,
-		"for": true, "of": true, "with": true, "by": true, "from": true,
-		"is": true, "are": true, "was": true, "were": true, "be": true,
-	}
-
-	var keywords []string
-	for _, word := range words {
-		if !stopWords[word] && len(word) > 2 {
-			keywords = append(keywords, word)
-		}
-	}
-
-	return keywords
-}
-
-func normalizeURL(urlStr string) string {
-	// Remove query parameters and fragments for deduplication
-	urlStr = strings.TrimSpace(urlStr)
-	if i := strings.Index(urlStr, "?"); i > 0 {
-		urlStr = urlStr[:i]
-	}
-	if i := strings.Index(urlStr, "#"); i > 0 {
-		urlStr = urlStr[:i]
-	}
-	return strings.ToLower(urlStr)
-}
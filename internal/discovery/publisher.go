@@ -0,0 +1,60 @@
+package discovery
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// maxPublisherRunes caps a publisher name for the source: operator.
+const maxPublisherRunes = 60
+
+// SanitizePublisher validates a publisher name for the source: operator
+// ("Reuters", "Le Monde"): surrounding quotes are dropped and whitespace
+// collapsed; letters, digits, spaces and . & ' - are allowed. Anything else
+// (another operator, parentheses, a URL) is an error, so the name can't
+// change the meaning of the query it is added to.
+func SanitizePublisher(s string) (string, error) {
+	s = strings.Join(strings.Fields(strings.Trim(strings.TrimSpace(s), `"“”'`)), " ")
+	if s == "" {
+		return "", fmt.Errorf("empty publisher name")
+	}
+	if len([]rune(s)) > maxPublisherRunes {
+		return "", fmt.Errorf("publisher name longer than %d characters", maxPublisherRunes)
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) && !strings.ContainsRune(" .&'’-", r) {
+			return "", fmt.Errorf("publisher name %q: %q not allowed (use the outlet's name, not a domain or operator)", s, r)
+		}
+	}
+	return s, nil
+}
+
+// PublisherOperator is Google News' restrict-to-publisher operator for a
+// sanitized name: source:"Reuters".
+func PublisherOperator(name string) string {
+	return `source:"` + name + `"`
+}
+
+// MatchesPublisher reports whether c plausibly comes from the publisher
+// name: by its source label or publisher, its " - Publisher" title suffix,
+// or its host ("The Guardian" matches theguardian.com). Google News wrapper
+// links are trusted, since the operator already restricted them server-side.
+func MatchesPublisher(c Candidate, name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || isGoogleNewsWrapper(c.URL) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(c.Source), name) || strings.Contains(strings.ToLower(c.Publisher), name) {
+		return true
+	}
+	if i := strings.LastIndex(c.Title, " - "); i >= 0 && strings.Contains(strings.ToLower(c.Title[i+3:]), name) {
+		return true
+	}
+	squashed := strings.NewReplacer(" ", "", "'", "", "’", "", "&", "", ".", "").Replace(name)
+	if u, err := url.Parse(c.URL); err == nil && squashed != "" {
+		return strings.Contains(strings.ToLower(u.Hostname()), squashed)
+	}
+	return false
+}
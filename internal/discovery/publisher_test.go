@@ -0,0 +1,57 @@
+package discovery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizePublisher(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: "Reuters", want: "Reuters"},
+		{in: `  "The   Guardian" `, want: "The Guardian"},
+		{in: "“Le Monde”", want: "Le Monde"},
+		{in: "AT&T News", want: "AT&T News"},
+		{in: "Ouest-France", want: "Ouest-France"},
+		{in: "  ", wantErr: true},
+		{in: "reuters.com/world", wantErr: true},
+		{in: `Reuters" OR site:evil.example`, wantErr: true},
+		{in: strings.Repeat("a", 200), wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := SanitizePublisher(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("SanitizePublisher(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestGoogleNewsPublisherOperator(t *testing.T) {
+	var query string
+	g := NewGoogleNews()
+	g.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query().Get("q")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/rss+xml"}},
+			Body:       io.NopCloser(strings.NewReader(testFeed)),
+			Request:    r,
+		}, nil
+	})}
+	lang := LanguageProfile{Code: "fr", HL: "fr", GL: "FR", CEID: "FR:fr"}
+	plan := Plan{Query: "grève SNCF", Scope: "country:FR", ScopeTerm: "France", Publisher: "Le Monde"}
+	now := time.Now()
+
+	if _, err := g.Discover(context.Background(), plan, lang, now.AddDate(0, 0, -1), now, 10); err != nil {
+		t.Fatal(err)
+	}
+	if want := `grève SNCF France source:"Le Monde"`; query != want {
+		t.Errorf("q = %q, want %q", query, want)
+	}
+}
@@ -0,0 +1,37 @@
+package discovery
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Randomized behavior (backoff jitter for now) draws from one package-level
+// generator so tests and reproducible runs can pin it with SetRandSeed.
+var (
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// SetRandSeed makes the package's randomized behavior deterministic.
+func SetRandSeed(seed int64) {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	rng = rand.New(rand.NewSource(seed))
+}
+
+// randFloat64 returns a value in [0, 1) from the package generator.
+func randFloat64() float64 {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return rng.Float64()
+}
+
+// jitter spreads d by up to ±frac (0.2 = ±20%) so concurrent clients don't
+// retry in lockstep.
+func jitter(d time.Duration, frac float64) time.Duration {
+	if d <= 0 || frac <= 0 {
+		return d
+	}
+	return d + time.Duration((randFloat64()*2-1)*frac*float64(d))
+}
@@ -0,0 +1,41 @@
+package discovery
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetRandSeedRepeatsJitter(t *testing.T) {
+	const d = 10 * time.Second
+	draw := func() []time.Duration {
+		SetRandSeed(42)
+		out := make([]time.Duration, 5)
+		for i := range out {
+			out[i] = jitter(d, 0.2)
+		}
+		return out
+	}
+
+	first, second := draw(), draw()
+	distinct := map[time.Duration]bool{}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("same seed, different sequences: %v vs %v", first, second)
+		}
+		if first[i] < 8*time.Second || first[i] > 12*time.Second {
+			t.Errorf("jitter(%s, 0.2) = %s, want within ±20%%", d, first[i])
+		}
+		distinct[first[i]] = true
+	}
+	if len(distinct) < 2 {
+		t.Errorf("jitter never varies: %v", first)
+	}
+
+	SetRandSeed(7)
+	if other := jitter(d, 0.2); other == first[0] {
+		t.Errorf("seeds 42 and 7 gave the same first draw %s", other)
+	}
+	if got := jitter(d, 0); got != d {
+		t.Errorf("jitter with frac 0 = %s, want %s", got, d)
+	}
+}
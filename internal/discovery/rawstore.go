@@ -0,0 +1,38 @@
+package discovery
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// rawBodyPath is where the Google News response for the search URL u (query
+// and target: q, hl, gl, ceid) is kept under dir: "<gl>-<hl>-<hash>.xml",
+// readable enough to find a target's files by eye.
+func rawBodyPath(dir string, lang LanguageProfile, u string) string {
+	sum := sha256.Sum256([]byte(u))
+	name := strings.ToLower(lang.GL + "-" + lang.HL + "-" + hex.EncodeToString(sum[:8]) + ".xml")
+	return filepath.Join(dir, name)
+}
+
+// saveRawBody writes raw for u under dir, creating dir.
+func saveRawBody(dir string, lang LanguageProfile, u string, raw []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(rawBodyPath(dir, lang, u), raw, 0o644)
+}
+
+// loadRawBody reads the body saved for u under dir.
+func loadRawBody(dir string, lang LanguageProfile, u string) ([]byte, error) {
+	path := rawBodyPath(dir, lang, u)
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("google news rss: no saved response for %s (%s)", u, path)
+	}
+	return raw, err
+}
@@ -0,0 +1,65 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+const testFeed = `<?xml version="1.0"?><rss version="2.0"><channel>
+<item><title>Strikes paralyse French rail network - Le Monde</title>
+<link>https://news.google.com/rss/articles/abc</link>
+<description>&lt;a href="https://www.lemonde.fr/a"&gt;Strikes&lt;/a&gt;</description>
+<pubDate>Tue, 03 Mar 2026 10:00:00 GMT</pubDate>
+<source url="https://www.lemonde.fr">Le Monde</source></item>
+</channel></rss>`
+
+func TestGoogleNewsSaveAndReplayRaw(t *testing.T) {
+	dir := t.TempDir()
+	lang := LanguageProfile{Code: "fr", HL: "fr", GL: "FR", CEID: "FR:fr"}
+	plan := Plan{Query: "grève", Scope: "global"}
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	live := NewGoogleNews()
+	live.SaveRawDir = dir
+	live.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/rss+xml"}},
+			Body:       io.NopCloser(strings.NewReader(testFeed)),
+			Request:    r,
+		}, nil
+	})}
+	want, err := live.Discover(context.Background(), plan, lang, from, to, 10)
+	if err != nil || len(want) != 1 {
+		t.Fatalf("live Discover = %v, %v; want 1 candidate", want, err)
+	}
+
+	replay := NewGoogleNews()
+	replay.FromRawDir = dir
+	replay.Client = &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("replay must not make HTTP requests")
+	})}
+	got, err := replay.Discover(context.Background(), plan, lang, from, to, 10)
+	if err != nil {
+		t.Fatalf("replay Discover: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replay = %+v, want %+v", got, want)
+	}
+
+	// A query never saved fails instead of going online
+	if _, err := replay.Discover(context.Background(), Plan{Query: "other", Scope: "global"}, lang, from, to, 10); err == nil {
+		t.Error("replay of an unsaved query succeeded")
+	}
+}
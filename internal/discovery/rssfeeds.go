@@ -1,63 +1,221 @@
 package discovery
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"html"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mmcdole/gofeed"
+	"newscheck/internal/text"
 )
 
 type RSSFeeds struct {
 	Client *http.Client
 	Feeds  []string
+
+	// BrowserHeaders sends a full browser-like header set (Accept-Language,
+	// Sec-Fetch-*, client hints) instead of just a User-Agent, which gets
+	// past some basic bot checks. Real JS challenges still fail, with
+	// ErrChallenge.
+	BrowserHeaders bool
+
+	// Match weighs keyword hits in item titles against hits in
+	// descriptions; zero = DefaultMatchWeights.
+	Match MatchWeights
+
+	mu       sync.Mutex
+	outcomes map[string]FeedOutcome // latest fetch per feed URL
+}
+
+// FeedOutcome is the result of the latest fetch of one feed.
+type FeedOutcome struct {
+	URL   string    `json:"url"`
+	Title string    `json:"title,omitempty"` // feed title, once it has been read
+	OK    bool      `json:"ok"`
+	Err   string    `json:"error,omitempty"`
+	Items int       `json:"items"` // items in the feed, before keyword/window filtering
+	At    time.Time `json:"at"`
+}
+
+// Name is the feed title, else the feed's host.
+func (o FeedOutcome) Name() string {
+	if o.Title != "" {
+		return o.Title
+	}
+	if u, err := url.Parse(o.URL); err == nil && u.Host != "" {
+		return strings.TrimPrefix(u.Host, "www.")
+	}
+	return o.URL
 }
 
+// NewRSSFeeds trims feeds and drops blanks and repeats (ignoring a trailing
+// slash), keeping the first occurrence's order.
 func NewRSSFeeds(feeds []string) *RSSFeeds {
 	return &RSSFeeds{
 		Client: &http.Client{Timeout: 15 * time.Second},
-		Feeds:  feeds,
+		Feeds:  dedupeFeeds(feeds),
+	}
+}
+
+// WithFeeds returns a new RSSFeeds over feeds with r's settings (client,
+// headers, match weights). A nil r gives NewRSSFeeds(feeds).
+func (r *RSSFeeds) WithFeeds(feeds []string) *RSSFeeds {
+	out := NewRSSFeeds(feeds)
+	if r != nil {
+		out.Client, out.BrowserHeaders, out.Match = r.Client, r.BrowserHeaders, r.Match
+	}
+	return out
+}
+
+func dedupeFeeds(feeds []string) []string {
+	seen := make(map[string]struct{}, len(feeds))
+	out := make([]string, 0, len(feeds))
+	for _, f := range feeds {
+		f = strings.TrimSpace(f)
+		key := strings.TrimSuffix(f, "/")
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, f)
+	}
+	return out
+}
+
+// record stores o as the latest outcome for its feed. A failure keeps the
+// title from an earlier success so warnings can name the feed.
+func (r *RSSFeeds) record(o FeedOutcome) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.outcomes == nil {
+		r.outcomes = map[string]FeedOutcome{}
+	}
+	if o.Title == "" {
+		o.Title = r.outcomes[o.URL].Title
+	}
+	r.outcomes[o.URL] = o
+}
+
+// Outcomes returns the latest outcome of every feed fetched at or after
+// since, in feed order.
+func (r *RSSFeeds) Outcomes(since time.Time) []FeedOutcome {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []FeedOutcome
+	for _, f := range r.Feeds {
+		if o, ok := r.outcomes[f]; ok && !o.At.Before(since) {
+			out = append(out, o)
+		}
 	}
+	return out
 }
 
+// fetch downloads and parses one feed, recording the outcome.
+func (r *RSSFeeds) fetch(ctx context.Context, parser *gofeed.Parser, feedURL string) (*gofeed.Feed, error) {
+	feed, err := r.fetchFeed(ctx, parser, feedURL)
+	o := FeedOutcome{URL: feedURL, OK: err == nil, At: time.Now()}
+	if err != nil {
+		o.Err = err.Error()
+	} else {
+		o.Title = strings.TrimSpace(feed.Title)
+		o.Items = len(feed.Items)
+	}
+	r.record(o)
+	return feed, err
+}
+
+func (r *RSSFeeds) fetchFeed(ctx context.Context, parser *gofeed.Parser, feedURL string) (*gofeed.Feed, error) {
+	callCtx, cancel := withCallTimeout(ctx, r.Client.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(callCtx, "GET", feedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.BrowserHeaders {
+		for k, v := range browserHeaders {
+			req.Header.Set(k, v)
+		}
+	} else {
+		req.Header.Set("User-Agent", "Mozilla/5.0 newscheck/0.1")
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes))
+	if err != nil {
+		return nil, err
+	}
+	// Challenges come as 403/503, but some are served with a 200
+	if isChallenge(resp, raw) {
+		return nil, fmt.Errorf("feed http %d: %w", resp.StatusCode, ErrChallenge)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("feed http %d", resp.StatusCode)
+	}
+	return parser.Parse(bytes.NewReader(raw))
+}
+
+// maxFeedBytes caps how much of a feed is read.
+const maxFeedBytes = 16 << 20
+
 func (r *RSSFeeds) Discover(ctx context.Context, p Plan, from, to time.Time, limit int) ([]Candidate, error) {
-	// RSS feeds are not queryable like search, so we pull and filter locally by keywords.
-	// For now: basic contains-any-keyword match on title.
-	keywords := strings.Fields(strings.ToLower(p.Query))
+	// RSS feeds are not queryable like search, so we pull and filter locally
+	// by keywords, in the title or the description (see MatchWeights).
+	keywords := curatedKeywords(text.Keywords(p.Query, "", 0))
 	if len(keywords) == 0 {
 		return nil, nil
 	}
 
 	parser := gofeed.NewParser()
 	out := make([]Candidate, 0, limit)
+	shortTitles := 0
 
 	for _, feedURL := range r.Feeds {
 		if len(out) >= limit {
 			break
 		}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
-		if err != nil {
-			continue
-		}
-		resp, err := r.Client.Do(req)
+		// A failed feed doesn't fail the call; it shows up in Outcomes
+		feed, err := r.fetch(ctx, parser, feedURL)
 		if err != nil {
 			continue
 		}
-		feed, err := parser.Parse(resp.Body)
-		resp.Body.Close()
-		if err != nil {
-			continue
+
+		// Relative item links resolve against the site link, else the feed URL
+		base := feedURL
+		if resolved := resolveItemLink(feed.Link, feedURL); resolved != "" {
+			base = resolved
 		}
 
 		for _, it := range feed.Items {
 			if len(out) >= limit {
 				break
 			}
+			if !HasUsableTitle(it.Title) {
+				shortTitles++
+				continue
+			}
 			title := strings.ToLower(strings.TrimSpace(it.Title))
+			desc := plainDescription(it.Description)
 
-			if !matchesAnyKeyword(title, keywords) {
+			match := r.Match.Score(title, strings.ToLower(desc), keywords)
+			if match == 0 {
 				continue
 			}
 
@@ -66,36 +224,46 @@ func (r *RSSFeeds) Discover(ctx context.Context, p Plan, from, to time.Time, lim
 				pub = *it.PublishedParsed
 			} else if it.UpdatedParsed != nil {
 				pub = *it.UpdatedParsed
-			} else {
+			}
+
+			if !inWindow(pub, from, to) {
 				continue
 			}
 
-			if pub.Before(from) || pub.After(to) {
+			link := resolveItemLink(it.Link, base)
+			if !isValidPublisherURL(link) {
 				continue
 			}
 
 			out = append(out, Candidate{
 				Title:       strings.TrimSpace(it.Title),
-				URL:         strings.TrimSpace(it.Link),
+				URL:         link,
 				Source:      strings.TrimSpace(feed.Title),
+				Description: desc,
 				PublishedAt: pub,
+				Undated:     pub.IsZero(),
 				FoundBy:     p.Scope + " | " + p.Query,
+				FeedMatch:   match,
 			})
 		}
 	}
 
+	logShortTitles("Curated RSS", shortTitles)
 	return out, nil
 }
 
-func matchesAnyKeyword(text string, keywords []string) bool {
-	for _, k := range keywords {
-		k = strings.TrimSpace(k)
-		if len(k) < 3 {
-			continue
-		}
-		if strings.Contains(text, k) {
-			return true
-		}
+var reHTMLTag = regexp.MustCompile(`<[^>]*>`)
+
+// maxDescriptionRunes caps a feed item's description kept on the candidate.
+const maxDescriptionRunes = 500
+
+// plainDescription turns an item's (often HTML) description into short
+// plain text.
+func plainDescription(s string) string {
+	s = html.UnescapeString(reHTMLTag.ReplaceAllString(s, " "))
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxDescriptionRunes {
+		s = strings.TrimSpace(string(r[:maxDescriptionRunes])) + "..."
 	}
-	return false
+	return s
 }
@@ -0,0 +1,159 @@
+package discovery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const workingFeed = `<?xml version="1.0"?><rss version="2.0"><channel>
+<title>Harbour Times</title><link>https://harbour.example/</link>
+<item><title>Port strike spreads to Antwerp</title><link>https://harbour.example/news/strike</link>
+<pubDate>Tue, 03 Mar 2026 10:00:00 GMT</pubDate></item>
+<item><title>Weather turns cold over the weekend</title><link>https://harbour.example/news/weather</link>
+<pubDate>Tue, 03 Mar 2026 11:00:00 GMT</pubDate></item>
+</channel></rss>`
+
+// feedServer serves path -> body; "/forbidden" answers 403.
+func feedServer(t *testing.T, feeds map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := feeds[r.URL.Path]
+		if !ok {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRSSFeedsOneFailingOneWorking(t *testing.T) {
+	srv := feedServer(t, map[string]string{"/ok": workingFeed})
+	r := NewRSSFeeds([]string{srv.URL + "/forbidden", srv.URL + "/ok", " " + srv.URL + "/ok/ ", ""})
+	if len(r.Feeds) != 2 {
+		t.Fatalf("Feeds = %v, want the blank and the repeat dropped", r.Feeds)
+	}
+
+	start := time.Now()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := r.Discover(context.Background(), Plan{Query: "port strike", Scope: "global"}, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatalf("a failing feed failed the call: %v", err)
+	}
+	if len(got) != 1 || got[0].URL != "https://harbour.example/news/strike" || got[0].Source != "Harbour Times" {
+		t.Errorf("candidates = %+v, want the strike item from the working feed", got)
+	}
+	if len(got) == 1 && (got[0].TargetISO2 != "" || got[0].TargetLang != "") {
+		t.Errorf("curated feed candidate has target %s/%s, want none", got[0].TargetISO2, got[0].TargetLang)
+	}
+
+	outcomes := r.Outcomes(start)
+	if len(outcomes) != 2 {
+		t.Fatalf("outcomes = %+v, want one per feed", outcomes)
+	}
+	dead, ok := outcomes[0], outcomes[1]
+	if dead.OK || !strings.Contains(dead.Err, "403") || dead.Name() != strings.TrimPrefix(srv.URL, "http://") {
+		t.Errorf("failing feed outcome = %+v (name %q)", dead, dead.Name())
+	}
+	if !ok.OK || ok.Items != 2 || ok.Name() != "Harbour Times" {
+		t.Errorf("working feed outcome = %+v, want OK with 2 items", ok)
+	}
+	if later := r.Outcomes(time.Now().Add(time.Minute)); len(later) != 0 {
+		t.Errorf("Outcomes after the run = %+v, want none", later)
+	}
+}
+
+func TestRSSFeedsDropBlankAndShortTitles(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Harbour Times</title>
+<item><title></title><link>https://harbour.example/news/1</link></item>
+<item><title>   </title><link>https://harbour.example/news/2</link></item>
+<item><title>Strike!</title><link>https://harbour.example/news/3</link></item>
+<item><title>Port strike spreads to Antwerp</title><link>https://harbour.example/news/4</link></item>
+</channel></rss>`
+	srv := feedServer(t, map[string]string{"/feed": feed})
+	r := NewRSSFeeds([]string{srv.URL + "/feed"})
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := r.Discover(context.Background(), Plan{Query: "strike", Scope: "global"}, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].URL != "https://harbour.example/news/4" {
+		t.Errorf("candidates = %+v, want only the item with a full title", got)
+	}
+}
+
+func TestHasUsableTitle(t *testing.T) {
+	tests := map[string]bool{
+		"":                    false,
+		"   ":                 false,
+		"Strike!":             false,
+		"  Strike!  ":         false,
+		"Grève !!":            true, // 8 runes, more bytes
+		"Port strike spreads": true,
+	}
+	for title, want := range tests {
+		if got := HasUsableTitle(title); got != want {
+			t.Errorf("HasUsableTitle(%q) = %v, want %v", title, got, want)
+		}
+	}
+}
+
+func TestRSSFeedsRelativeLinks(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel>
+<title>Harbour Times</title><link>https://harbour.example/section/</link>
+<item><title>Port strike spreads to Antwerp</title><link>/news/antwerp</link></item>
+<item><title>Port strike reaches Rotterdam</title><link>news/rotterdam</link></item>
+<item><title>Port strike hits Hamburg</title><link>//cdn.harbour.example/news/hamburg</link></item>
+</channel></rss>`
+	srv := feedServer(t, map[string]string{"/feed": feed})
+	r := NewRSSFeeds([]string{srv.URL + "/feed"})
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := r.Discover(context.Background(), Plan{Query: "port strike", Scope: "global"}, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{
+		"https://harbour.example/news/antwerp":           true,
+		"https://harbour.example/section/news/rotterdam": true,
+		"https://cdn.harbour.example/news/hamburg":       true,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates, want %d: %+v", len(got), len(want), got)
+	}
+	for _, c := range got {
+		if !want[c.URL] {
+			t.Errorf("unexpected link %q", c.URL)
+		}
+	}
+}
+
+func TestRSSFeedsKeepUndatedItems(t *testing.T) {
+	feed := `<?xml version="1.0"?><rss version="2.0"><channel><title>Harbour Times</title>
+<item><title>Port strike spreads to Antwerp</title><link>https://harbour.example/news/undated</link></item>
+<item><title>Port strike talks collapse</title><link>https://harbour.example/news/garbled</link><pubDate>sometime soon</pubDate></item>
+</channel></rss>`
+	srv := feedServer(t, map[string]string{"/feed": feed})
+	r := NewRSSFeeds([]string{srv.URL + "/feed"})
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	got, err := r.Discover(context.Background(), Plan{Query: "port strike", Scope: "global"}, from, from.AddDate(0, 0, 7), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d candidates, want both undated items kept", len(got))
+	}
+	for _, c := range got {
+		if !c.Undated || !c.PublishedAt.IsZero() {
+			t.Errorf("%s: Undated %v, PublishedAt %v; want undated with a zero time", c.URL, c.Undated, c.PublishedAt)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package discovery
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Source is a discovery backend: given one plan in one locale, it returns up
+// to limit candidates published in [from, to]. The orchestrator calls it once
+// per discovery target and plan unless it also implements LocaleSource.
+type Source interface {
+	// Name labels the source in errors and run stats ("Google News").
+	Name() string
+	Discover(ctx context.Context, p Plan, lang LanguageProfile, from, to time.Time, limit int) ([]Candidate, error)
+}
+
+// LocaleSource is a Source that doesn't map one-to-one onto discovery
+// targets (e.g. feed lists grouped by language). Locales turns the run's
+// target profiles into the locales it should be queried in.
+type LocaleSource interface {
+	Source
+	Locales(targets []LanguageProfile) []LanguageProfile
+}
+
+var (
+	_ Source       = (*GoogleNews)(nil)
+	_ Source       = (*BingNews)(nil)
+	_ Source       = (*MultiSourceDiscovery)(nil)
+	_ LocaleSource = (*CuratedFeeds)(nil)
+)
+
+func (g *GoogleNews) Name() string           { return "Google News" }
+func (b *BingNews) Name() string             { return "Bing News" }
+func (m *MultiSourceDiscovery) Name() string { return "Multi-source" }
+func (c *CuratedFeeds) Name() string         { return "Curated RSS" }
+
+// Locales returns one locale per curated group: World (Code "") plus each
+// target language that has feeds.
+func (c *CuratedFeeds) Locales(targets []LanguageProfile) []LanguageProfile {
+	langs := make([]string, 0, len(targets))
+	for _, t := range targets {
+		langs = append(langs, t.Code)
+	}
+	groups := c.ForLanguages(langs)
+	out := make([]LanguageProfile, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, LanguageProfile{Code: g.Lang})
+	}
+	return out
+}
+
+// Discover runs the group for lang.Code ("" = World).
+func (c *CuratedFeeds) Discover(ctx context.Context, p Plan, lang LanguageProfile, from, to time.Time, limit int) ([]Candidate, error) {
+	if c == nil {
+		return nil, nil
+	}
+	feeds := c.World
+	if lang.Code != "" {
+		feeds = c.ByLang[lang.Code]
+	}
+	if feeds == nil {
+		return nil, nil
+	}
+	return feeds.Discover(ctx, p, from, to, limit)
+}
+
+// SetBrowserHeaders turns RSSFeeds.BrowserHeaders on or off for every group.
+func (c *CuratedFeeds) SetBrowserHeaders(on bool) {
+	if c == nil {
+		return
+	}
+	if c.World != nil {
+		c.World.BrowserHeaders = on
+	}
+	for _, g := range c.ByLang {
+		g.BrowserHeaders = on
+	}
+}
+
+// SetMatchWeights sets RSSFeeds.Match for every group.
+func (c *CuratedFeeds) SetMatchWeights(w MatchWeights) {
+	if c == nil {
+		return
+	}
+	if c.World != nil {
+		c.World.Match = w
+	}
+	for _, g := range c.ByLang {
+		g.Match = w
+	}
+}
+
+// FeedOutcomes returns the latest outcome of every curated feed fetched at
+// or after since: World first, then the language groups by code.
+func (c *CuratedFeeds) FeedOutcomes(since time.Time) []FeedOutcome {
+	if c == nil {
+		return nil
+	}
+	out := c.World.Outcomes(since)
+	langs := make([]string, 0, len(c.ByLang))
+	for l := range c.ByLang {
+		langs = append(langs, l)
+	}
+	sort.Strings(langs)
+	for _, l := range langs {
+		out = append(out, c.ByLang[l].Outcomes(since)...)
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package discovery
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// knownPublishers names outlets by registrable domain, for labels that stay
+// the same whichever backend or feed found the article.
+var knownPublishers = map[string]string{
+	"abc.net.au":          "ABC News (Australia)",
+	"aljazeera.com":       "Al Jazeera",
+	"apnews.com":          "Associated Press",
+	"afp.com":             "AFP",
+	"bbc.co.uk":           "BBC",
+	"bbc.com":             "BBC",
+	"bloomberg.com":       "Bloomberg",
+	"cbc.ca":              "CBC",
+	"clarin.com":          "Clarín",
+	"cnn.com":             "CNN",
+	"dw.com":              "Deutsche Welle",
+	"economist.com":       "The Economist",
+	"elpais.com":          "El País",
+	"euronews.com":        "Euronews",
+	"faz.net":             "Frankfurter Allgemeine",
+	"folha.uol.com.br":    "Folha de S.Paulo",
+	"france24.com":        "France 24",
+	"ft.com":              "Financial Times",
+	"globo.com":           "Globo",
+	"lefigaro.fr":         "Le Figaro",
+	"lemonde.fr":          "Le Monde",
+	"liberation.fr":       "Libération",
+	"nhk.or.jp":           "NHK",
+	"npr.org":             "NPR",
+	"nytimes.com":         "The New York Times",
+	"politico.com":        "Politico",
+	"politico.eu":         "Politico Europe",
+	"reuters.com":         "Reuters",
+	"rfi.fr":              "RFI",
+	"scmp.com":            "South China Morning Post",
+	"spiegel.de":          "Der Spiegel",
+	"theguardian.com":     "The Guardian",
+	"washingtonpost.com":  "The Washington Post",
+	"wsj.com":             "The Wall Street Journal",
+	"zeit.de":             "Die Zeit",
+	"japantimes.co.jp":    "The Japan Times",
+	"timesofindia.com":    "The Times of India",
+	"hindustantimes.com":  "Hindustan Times",
+	"jamaica-gleaner.com": "The Gleaner",
+}
+
+// knownPublisherDomains maps a lowercased knownPublishers name back to its
+// domain; for an outlet with several (BBC) the alphabetically first.
+var knownPublisherDomains = func() map[string]string {
+	m := make(map[string]string, len(knownPublishers))
+	for domain, name := range knownPublishers {
+		key := strings.ToLower(name)
+		if d, ok := m[key]; !ok || domain < d {
+			m[key] = domain
+		}
+	}
+	return m
+}()
+
+// KnownPublisherDomain returns the domain of a known outlet by display name
+// ("Reuters" gives "reuters.com"), so a candidate behind a Google News
+// wrapper URL can be matched with the outlet's own links.
+func KnownPublisherDomain(name string) (string, bool) {
+	d, ok := knownPublisherDomains[strings.ToLower(strings.Join(strings.Fields(name), " "))]
+	return d, ok
+}
+
+// reBackendLabel matches the labels sources fall back to when they don't
+// know the publisher: "Google News RSS (en)", "Bing News (fr)".
+var reBackendLabel = regexp.MustCompile(`^(?:Google News(?: RSS)?|Bing News)(?: \([\w-]*\))?$`)
+
+// feedTitleSeparators split a feed title's outlet from its section
+// ("BBC News - World", "Reuters | Business").
+var feedTitleSeparators = []string{" - ", " | ", " – ", " — ", ": "}
+
+// NormalizeSourceName returns a clean display name for c's publisher, the
+// same whichever backend found it: the known-publishers name for its host,
+// else the publisher the source reported (Google News' <source>, a feed's
+// title without its section), else the " - Publisher" suffix of a Google
+// News title, else the bare host.
+func NormalizeSourceName(c Candidate) string {
+	host := ""
+	if u, err := url.Parse(strings.TrimSpace(c.URL)); err == nil {
+		host = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	}
+	if name, ok := knownPublisherFor(host); ok {
+		return name
+	}
+	for _, raw := range []string{c.Publisher, c.Source} {
+		if name := cleanSourceLabel(raw); name != "" {
+			return name
+		}
+	}
+	if i := strings.LastIndex(c.Title, " - "); i > 0 {
+		if name := strings.TrimSpace(c.Title[i+3:]); name != "" && len(strings.Fields(name)) <= 5 {
+			return name
+		}
+	}
+	if host != "" && !isGoogleNewsWrapper(c.URL) {
+		return host
+	}
+	return strings.TrimSpace(c.Source)
+}
+
+// knownPublisherFor looks host and its parent domains up in knownPublishers,
+// so "edition.cnn.com" is CNN.
+func knownPublisherFor(host string) (string, bool) {
+	for host != "" {
+		if name, ok := knownPublishers[host]; ok {
+			return name, true
+		}
+		i := strings.IndexByte(host, '.')
+		if i < 0 {
+			break
+		}
+		host = host[i+1:]
+	}
+	return "", false
+}
+
+// cleanSourceLabel turns a source label into a publisher name: backend
+// labels give "", feed titles lose their section and an "RSS" suffix.
+func cleanSourceLabel(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" || reBackendLabel.MatchString(s) {
+		return ""
+	}
+	for _, sep := range feedTitleSeparators {
+		if i := strings.Index(s, sep); i > 0 {
+			s = s[:i]
+		}
+	}
+	for _, suffix := range []string{" RSS", " Feed", " News Feed"} {
+		if len(s) > len(suffix) && strings.EqualFold(s[len(s)-len(suffix):], suffix) {
+			s = s[:len(s)-len(suffix)]
+		}
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,44 @@
+package discovery
+
+import "testing"
+
+func TestNormalizeSourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Candidate
+		want string
+	}{
+		{"known host", Candidate{URL: "https://www.reuters.com/world/x", Source: "Reuters: World News"}, "Reuters"},
+		{"known parent domain", Candidate{URL: "https://edition.cnn.com/x", Source: "Bing News (en)"}, "CNN"},
+		{"known outlet on a second domain", Candidate{URL: "https://www.bbc.co.uk/news/x"}, "BBC"},
+		{"Google News source element", Candidate{URL: "https://news.google.com/rss/articles/a", Source: "Google News RSS (fr)", Publisher: "Ouest-France"}, "Ouest-France"},
+		{"feed title loses its section", Candidate{URL: "https://harbour.example/x", Source: "Harbour Times - World"}, "Harbour Times"},
+		{"feed title loses RSS", Candidate{URL: "https://harbour.example/x", Source: "Harbour Times RSS"}, "Harbour Times"},
+		{"Bing provider name", Candidate{URL: "https://harbour.example/x", Source: "Harbour Times"}, "Harbour Times"},
+		{"title suffix behind a wrapper", Candidate{URL: "https://news.google.com/rss/articles/b", Source: "Google News RSS (en)", Title: "Port strike spreads - The Harbour Times"}, "The Harbour Times"},
+		{"backend label falls back to the host", Candidate{URL: "https://www.harbour.example/x", Source: "Bing News (en)", Title: "Port strike spreads"}, "harbour.example"},
+		{"wrapper with nothing else keeps the label", Candidate{URL: "https://news.google.com/rss/articles/c", Source: "Google News RSS (en)", Title: "Port strike spreads"}, "Google News RSS (en)"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeSourceName(tt.c); got != tt.want {
+			t.Errorf("%s: NormalizeSourceName = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKnownPublisherDomain(t *testing.T) {
+	tests := []struct {
+		name, want string
+		ok         bool
+	}{
+		{"Reuters", "reuters.com", true},
+		{"  le   MONDE ", "lemonde.fr", true},
+		{"BBC", "bbc.co.uk", true}, // alphabetically first of bbc.co.uk, bbc.com
+		{"Harbour Times", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := KnownPublisherDomain(tt.name); got != tt.want || ok != tt.ok {
+			t.Errorf("KnownPublisherDomain(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
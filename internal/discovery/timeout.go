@@ -0,0 +1,49 @@
+package discovery
+
+import (
+	"context"
+	"time"
+)
+
+// A single HTTP call may use at most 1/deadlineShare of the time left before
+// the context deadline, so one slow feed can't eat the whole budget...
+const deadlineShare = 4
+
+// ...but never less than minCallTimeout (unless less than that remains).
+const minCallTimeout = 2 * time.Second
+
+// deadlineAwareTimeout returns the timeout for one HTTP call: max when ctx
+// has no deadline, otherwise a share of the remaining time, shrinking as the
+// deadline approaches. Returns 0 once the deadline has passed.
+func deadlineAwareTimeout(ctx context.Context, max time.Duration) time.Duration {
+	dl, ok := ctx.Deadline()
+	if !ok {
+		return max
+	}
+	remaining := time.Until(dl)
+	if remaining <= 0 {
+		return 0
+	}
+
+	t := remaining / deadlineShare
+	if t < minCallTimeout {
+		t = minCallTimeout
+	}
+	if t > remaining {
+		t = remaining
+	}
+	if max > 0 && t > max {
+		t = max
+	}
+	return t
+}
+
+// withCallTimeout bounds one HTTP call by deadlineAwareTimeout.
+func withCallTimeout(ctx context.Context, max time.Duration) (context.Context, context.CancelFunc) {
+	t := deadlineAwareTimeout(ctx, max)
+	if t <= 0 {
+		// Already past the deadline: ctx is (or is about to be) done anyway
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, t)
+}
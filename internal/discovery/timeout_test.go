@@ -0,0 +1,63 @@
+package discovery
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeadlineAwareTimeout(t *testing.T) {
+	const max = 20 * time.Second
+	tests := []struct {
+		name     string
+		left     time.Duration // 0 = no deadline
+		min, top time.Duration // accepted range, for the time elapsed in the call
+	}{
+		{"no deadline", 0, max, max},
+		{"far deadline capped at max", 10 * time.Minute, max, max},
+		{"a quarter of the time left", 40 * time.Second, 9 * time.Second, 10 * time.Second},
+		{"floor near the deadline", 4 * time.Second, minCallTimeout, minCallTimeout},
+		{"never past the deadline", time.Second, 900 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.left > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.left)
+				defer cancel()
+			}
+			if got := deadlineAwareTimeout(ctx, max); got < tt.min || got > tt.top {
+				t.Errorf("timeout = %s, want %s-%s", got, tt.min, tt.top)
+			}
+		})
+	}
+}
+
+func TestWithCallTimeoutShrinks(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Second)
+	defer cancel()
+
+	// As the deadline nears, each call gets less: 40s left -> ~10s, 12s -> ~3s
+	prev := time.Duration(1<<63 - 1)
+	for _, left := range []time.Duration{40 * time.Second, 12 * time.Second, 3 * time.Second} {
+		inner, cancel := context.WithDeadline(ctx, time.Now().Add(left))
+		callCtx, callCancel := withCallTimeout(inner, time.Minute)
+		dl, _ := callCtx.Deadline()
+		got := time.Until(dl)
+		callCancel()
+		cancel()
+		if got >= prev {
+			t.Errorf("%s left: call timeout %s, want less than the previous %s", left, got, prev)
+		}
+		prev = got
+	}
+
+	past, cancelPast := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelPast()
+	callCtx, callCancel := withCallTimeout(past, time.Minute)
+	defer callCancel()
+	if callCtx.Err() == nil {
+		t.Error("call context past the deadline is not done")
+	}
+}
@@ -1,18 +1,110 @@
 package discovery
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type Candidate struct {
 	Title          string    `json:"title"`
 	URL            string    `json:"url"`
 	Source         string    `json:"source"`
+	Description    string    `json:"description,omitempty"`
 	PublishedAt    time.Time `json:"published_at"`
 	FoundBy        string    `json:"found_by"`
 	RelevanceScore int       `json:"relevance_score"`
 	ConsensusScore int       `json:"consensus_score"`
+	// Distinct publisher domains covering the same story (this one included).
+	ConsensusDomains int `json:"consensus_domains,omitempty"`
+
+	// Publisher is the outlet's display name ("The Guardian"). Sources set
+	// it when the backend names the outlet; the app then normalizes it with
+	// NormalizeSourceName so every candidate has one.
+	Publisher string `json:"publisher,omitempty"`
+
+	// Discovery target (country edition and language) that returned this
+	// candidate, set by the source from its LanguageProfile (GL, Code).
+	// Empty for sources not tied to a target, like curated RSS.
+	TargetISO2 string `json:"target_iso2,omitempty"`
+	TargetLang string `json:"target_lang,omitempty"`
+
+	// Undated is set when the feed gave no usable publish date. PublishedAt
+	// is then zero until extraction supplies one.
+	Undated bool `json:"undated,omitempty"`
+
+	// FeedMatch is the keyword match score RSS sources give the item (see
+	// MatchWeights); 0 for sources that search server-side.
+	FeedMatch int `json:"feed_match,omitempty"`
+
+	// Provenance lists every distinct FoundBy ("scope | query") that returned
+	// this URL, filled in when duplicates are merged.
+	Provenance []string `json:"provenance,omitempty"`
+}
+
+// AddProvenance records found (a FoundBy value) once. Returns false if it was
+// already present.
+func (c *Candidate) AddProvenance(found string) bool {
+	if found == "" {
+		return false
+	}
+	for _, p := range c.Provenance {
+		if p == found {
+			return false
+		}
+	}
+	c.Provenance = append(c.Provenance, found)
+	return true
 }
 
 type Plan struct {
 	Query string
-	Scope string
+	Scope string // "global" | "region:<name>" | "country:<ISO2|name>"
+	// ScopeTerm is appended to the query instead of the raw scope value
+	// (e.g. "Venezuela" for scope "country:VE"). Optional.
+	ScopeTerm string
+	// Publisher restricts sources that support it to one outlet (see
+	// PublisherOperator). Optional.
+	Publisher string
+}
+
+// Scope is a parsed Plan.Scope.
+type Scope struct {
+	Kind  string // "global" | "region" | "country"
+	Value string // text after the prefix (ISO2 for forced countries)
+}
+
+func ParseScope(s string) Scope {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, ":"); i > 0 {
+		kind := s[:i]
+		if kind == "region" || kind == "country" {
+			return Scope{Kind: kind, Value: strings.TrimSpace(s[i+1:])}
+		}
+	}
+	return Scope{Kind: "global"}
+}
+
+// MinTitleLength is the shortest title (in runes, after trimming) a candidate
+// may have. Blank or stub titles score zero and add noise to consensus.
+const MinTitleLength = 8
+
+// HasUsableTitle reports whether title is long enough to keep.
+func HasUsableTitle(title string) bool {
+	return utf8.RuneCountInString(strings.TrimSpace(title)) >= MinTitleLength
+}
+
+func logShortTitles(source string, n int) {
+	if n > 0 {
+		fmt.Fprintf(os.Stderr, "  (%s: skipped %d items with blank/short titles)\n", source, n)
+	}
+}
+
+// inWindow reports whether pub falls within [from, to]. A zero pub (the feed
+// had no usable date) passes: it can't be placed, and dropping it would lose
+// articles that may well be recent.
+func inWindow(pub, from, to time.Time) bool {
+	return pub.IsZero() || (!pub.Before(from) && !pub.After(to))
 }
@@ -0,0 +1,141 @@
+package extract
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WorkerCommandEnv sets Worker.Command from a space-separated template, e.g.
+// "poetry run python {script} {args}" or
+// "docker run --rm newscheck-worker {args}".
+const WorkerCommandEnv = "NEWSCHECK_WORKER_CMD"
+
+// Placeholders understood in a worker command template. {args} expands to the
+// standard worker flags for the call (--mode, --url, --target-lang,
+// --keep-original, --metadata-only) and is the easiest to use; the others substitute single
+// values for CLIs with their own flag names. A token that ends up empty is
+// dropped.
+const (
+	PlaceholderArgs       = "{args}"
+	PlaceholderScript     = "{script}"
+	PlaceholderMode       = "{mode}"
+	PlaceholderURL        = "{url}"
+	PlaceholderTargetLang = "{target_lang}"
+)
+
+// ParseCommandTemplate splits a template into argv and checks it can pass
+// the worker its inputs: it needs {args}, or both {mode} and {url}.
+func ParseCommandTemplate(s string) ([]string, error) {
+	argv := strings.Fields(s)
+	if len(argv) == 0 {
+		return nil, errors.New("worker command template is empty")
+	}
+	has := func(p string) bool {
+		for _, a := range argv {
+			if strings.Contains(a, p) {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(PlaceholderArgs) && !(has(PlaceholderMode) && has(PlaceholderURL)) {
+		return nil, fmt.Errorf("worker command template %q needs %s, or %s and %s", s, PlaceholderArgs, PlaceholderMode, PlaceholderURL)
+	}
+	return argv, nil
+}
+
+// workerCall is one invocation of the worker.
+type workerCall struct {
+	mode         string // "extract" or "summarize"
+	url          string
+	targetLang   string
+	keepOriginal bool
+	metadataOnly bool
+}
+
+// flags are the standard worker.py arguments for c.
+func (c workerCall) flags() []string {
+	args := []string{"--mode", c.mode}
+	if c.url != "" {
+		args = append(args, "--url", c.url)
+	}
+	if c.targetLang != "" {
+		args = append(args, "--target-lang", c.targetLang)
+		if c.keepOriginal {
+			args = append(args, "--keep-original")
+		}
+	}
+	if c.metadataOnly {
+		args = append(args, "--metadata-only")
+	}
+	return args
+}
+
+// argv returns the program and arguments for c: PythonExe Script <flags>
+// by default, or Command with its placeholders filled in.
+func (w *Worker) argv(c workerCall) (string, []string, error) {
+	var script string
+	if usesScript(w.Command) {
+		s, err := w.script()
+		if err != nil {
+			return "", nil, err
+		}
+		script = s
+	}
+	if len(w.Command) == 0 {
+		if w.PythonExe == "" {
+			return "", nil, errors.New("worker not configured")
+		}
+		return w.PythonExe, append([]string{script}, c.flags()...), nil
+	}
+
+	r := strings.NewReplacer(
+		PlaceholderScript, script,
+		PlaceholderMode, c.mode,
+		PlaceholderURL, c.url,
+		PlaceholderTargetLang, c.targetLang,
+	)
+	var out []string
+	for _, tok := range w.Command {
+		if tok == PlaceholderArgs {
+			out = append(out, c.flags()...)
+			continue
+		}
+		if tok = r.Replace(tok); tok != "" {
+			out = append(out, tok)
+		}
+	}
+	if len(out) == 0 {
+		return "", nil, errors.New("worker command template expands to nothing")
+	}
+	return out[0], out[1:], nil
+}
+
+// script returns Script, locating worker.py the first time. A failed
+// lookup isn't remembered, so installing the worker mid-session works.
+func (w *Worker) script() (string, error) {
+	w.scriptMu.Lock()
+	defer w.scriptMu.Unlock()
+	if w.Script == "" {
+		s, err := FindWorkerScript()
+		if err != nil {
+			return "", err
+		}
+		w.Script = s
+	}
+	return w.Script, nil
+}
+
+// usesScript reports whether the command needs the bundled worker.py.
+func usesScript(command []string) bool {
+	if len(command) == 0 {
+		return true
+	}
+	for _, tok := range command {
+		if strings.Contains(tok, PlaceholderScript) {
+			return true
+		}
+	}
+	return false
+}
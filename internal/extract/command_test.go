@@ -0,0 +1,77 @@
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommandTemplate(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"poetry run python {script} {args}", []string{"poetry", "run", "python", "{script}", "{args}"}, false},
+		{"  scraper  --url={url}   --op {mode} ", []string{"scraper", "--url={url}", "--op", "{mode}"}, false},
+		{"", nil, true},
+		{"scraper --url {url}", nil, true},
+		{"python worker.py", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommandTemplate(tt.in)
+		if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCommandTemplate(%q) = %q, %v; want %q, err %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWorkerArgvFromTemplate(t *testing.T) {
+	call := workerCall{mode: "extract", url: "https://example.com/a", targetLang: "en", keepOriginal: true}
+	tests := []struct {
+		name     string
+		command  []string
+		call     workerCall
+		wantProg string
+		wantArgs []string
+	}{
+		{
+			name:     "default python and script",
+			call:     call,
+			wantProg: "python3",
+			wantArgs: []string{"/opt/worker.py", "--mode", "extract", "--url", "https://example.com/a", "--target-lang", "en", "--keep-original"},
+		},
+		{
+			name:     "script and args placeholders",
+			command:  []string{"poetry", "run", "python", "{script}", "{args}"},
+			call:     workerCall{mode: "summarize", metadataOnly: true},
+			wantProg: "poetry",
+			wantArgs: []string{"run", "python", "/opt/worker.py", "--mode", "summarize", "--metadata-only"},
+		},
+		{
+			name:     "docker image, no script",
+			command:  []string{"docker", "run", "--rm", "newscheck-worker", "{args}"},
+			call:     workerCall{mode: "extract", url: "https://example.com/a"},
+			wantProg: "docker",
+			wantArgs: []string{"run", "--rm", "newscheck-worker", "--mode", "extract", "--url", "https://example.com/a"},
+		},
+		{
+			name:     "single-value placeholders, empty tokens dropped",
+			command:  []string{"scraper", "--op={mode}", "{url}", "{target_lang}"},
+			call:     workerCall{mode: "extract", url: "https://example.com/a"},
+			wantProg: "scraper",
+			wantArgs: []string{"--op=extract", "https://example.com/a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Worker{PythonExe: "python3", Script: "/opt/worker.py", Command: tt.command}
+			prog, args, err := w.argv(tt.call)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prog != tt.wantProg || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("argv = %s %q, want %s %q", prog, args, tt.wantProg, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -8,7 +8,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
 	"time"
+
+	"newscheck/internal/langdetect"
 )
 
 type Article struct {
@@ -21,6 +25,20 @@ type Article struct {
 	Lang        *string `json:"lang"`
 	Text        string  `json:"text"`
 	FetchedAt   string  `json:"fetched_at"`
+
+	// Untranslated title and text, when translated with Worker.KeepOriginal.
+	OriginalTitle string `json:"original_title,omitempty"`
+	OriginalText  string `json:"original_text,omitempty"`
+
+	// MetadataOnly: extracted with Worker.MetadataOnly, so Text is empty
+	// by design rather than because the page had none.
+	MetadataOnly bool `json:"metadata_only,omitempty"`
+
+	// Set by the app's post-extraction quality check (never by the worker).
+	LowQuality    bool   `json:"low_quality,omitempty"`
+	QualityReason string `json:"quality_reason,omitempty"`
+	// URL of the kept article when this one's text is a near-duplicate.
+	DuplicateOf string `json:"duplicate_of,omitempty"`
 }
 
 type workerResponse struct {
@@ -32,19 +50,122 @@ type workerResponse struct {
 
 type Worker struct {
 	PythonExe string // "python"
-	Script    string // "python_worker/worker.py"
+	// Script is worker.py; "" = located with FindWorkerScript on the first
+	// call that needs it.
+	Script string
+
+	// Command, if set, replaces "PythonExe Script <flags>" with an argv
+	// template (see ParseCommandTemplate and the Placeholder constants).
+	Command []string
+
+	// TimeoutEscalation: when an extraction times out, it is retried once
+	// with the timeout multiplied by this. <= 1 disables the retry.
+	TimeoutEscalation float64
+
+	// KeepOriginal asks the worker to also return the untranslated title and
+	// text (Article.OriginalTitle/OriginalText) when it translates.
+	KeepOriginal bool
+
+	// MetadataOnly asks the worker for the page's title, site, date, author
+	// and language only: the main text isn't extracted and nothing is
+	// translated. Much faster when building a link list from many URLs.
+	MetadataOnly bool
+
+	scriptMu sync.Mutex // guards the lazy lookup of Script
 }
 
-func NewWorker() *Worker {
+// Extraction modes, as named on the command line.
+const (
+	ModeFull         = "full"
+	ModeMetadataOnly = "metadata-only" // Worker.MetadataOnly
+)
+
+// DefaultTimeoutEscalation gives a timed-out page one retry at twice the time.
+const DefaultTimeoutEscalation = 2.0
+
+// Per-attempt extraction timeouts, without and with translation; vars so
+// tests can shorten them.
+var (
+	extractTimeout   = 25 * time.Second
+	translateTimeout = 45 * time.Second
+)
+
+// ErrTimeout marks an extraction that ran out of time, as opposed to one the
+// worker rejected (404, parse failure, ...), which is never retried.
+var ErrTimeout = errors.New("python worker timeout")
+
+// WorkerScriptEnv overrides the worker script location when set.
+const WorkerScriptEnv = "NEWSCHECK_WORKER"
+
+const workerScriptRel = "python_worker/worker.py"
+
+func NewWorker() (*Worker, error) {
+	var command []string
+	if tmpl := os.Getenv(WorkerCommandEnv); tmpl != "" {
+		c, err := ParseCommandTemplate(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", WorkerCommandEnv, err)
+		}
+		command = c
+	}
+
+	// worker.py is looked up on first use (see Worker.Script), so
+	// discovery-only commands work without it
 	return &Worker{
-		PythonExe: "python",
-		Script:    "python_worker/worker.py",
+		PythonExe:         "python",
+		Command:           command,
+		TimeoutEscalation: DefaultTimeoutEscalation,
+	}, nil
+}
+
+// FindWorkerScript locates worker.py. Resolution order:
+// 1) $NEWSCHECK_WORKER (must exist if set)
+// 2) next to the executable, then up to two parent dirs (build/bin layouts)
+// 3) the current working directory (go run / repo root)
+func FindWorkerScript() (string, error) {
+	if env := os.Getenv(WorkerScriptEnv); env != "" {
+		if isFile(env) {
+			return filepath.Clean(env), nil
+		}
+		return "", fmt.Errorf("%s=%q: worker script not found", WorkerScriptEnv, env)
 	}
+
+	candidates := workerScriptCandidates()
+	for _, c := range candidates {
+		if isFile(c) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("python worker script not found (set %s); looked in: %v", WorkerScriptEnv, candidates)
+}
+
+func workerScriptCandidates() []string {
+	var out []string
+	if exe, err := os.Executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		dir := filepath.Dir(exe)
+		for i := 0; i < 3; i++ {
+			out = append(out, filepath.Join(dir, workerScriptRel))
+			dir = filepath.Dir(dir)
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		out = append(out, filepath.Join(wd, workerScriptRel))
+	}
+	return out
+}
+
+func isFile(p string) bool {
+	st, err := os.Stat(p)
+	return err == nil && !st.IsDir()
 }
 
 func (w *Worker) Summarize(ctx context.Context, text string, apiKey string) (string, error) {
-	if w.PythonExe == "" || w.Script == "" {
-		return "", errors.New("worker not configured")
+	exe, args, err := w.argv(workerCall{mode: "summarize"})
+	if err != nil {
+		return "", err
 	}
 	if text == "" {
 		return "", nil
@@ -53,8 +174,7 @@ func (w *Worker) Summarize(ctx context.Context, text string, apiKey string) (str
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
-	args := []string{w.Script, "--mode", "summarize"}
-	cmd := exec.CommandContext(ctx, w.PythonExe, args...)
+	cmd := exec.CommandContext(ctx, exe, args...)
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -69,7 +189,7 @@ func (w *Worker) Summarize(ctx context.Context, text string, apiKey string) (str
 
 	cmd.Env = append(os.Environ(), "GEMINI_API_KEY="+keyToUse)
 
-	err := cmd.Run()
+	err = cmd.Run()
 	if ctx.Err() != nil {
 		return "", fmt.Errorf("summarize timeout: %w", ctx.Err())
 	}
@@ -95,32 +215,44 @@ func (w *Worker) Summarize(ctx context.Context, text string, apiKey string) (str
 }
 
 func (w *Worker) Extract(ctx context.Context, url string, targetLang string) (Article, error) {
-	if w.PythonExe == "" || w.Script == "" {
-		return Article{}, errors.New("worker not configured")
+	if w.MetadataOnly {
+		targetLang = "" // nothing to translate
 	}
 
 	// Increase timeout for translation
-	timeout := 25 * time.Second
+	timeout := extractTimeout
 	if targetLang != "" {
-		timeout = 45 * time.Second
+		timeout = translateTimeout
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	art, err := w.extractOnce(ctx, url, targetLang, timeout)
+	if errors.Is(err, ErrTimeout) && w.TimeoutEscalation > 1 && ctx.Err() == nil {
+		// Most pages are fast; a slow one gets a single, longer second chance
+		art, err = w.extractOnce(ctx, url, targetLang, time.Duration(float64(timeout)*w.TimeoutEscalation))
+	}
+	return art, err
+}
+
+func (w *Worker) extractOnce(parent context.Context, url, targetLang string, timeout time.Duration) (Article, error) {
+	ctx, cancel := context.WithTimeout(parent, timeout)
 	defer cancel()
 
-	args := []string{w.Script, "--url", url}
-	if targetLang != "" {
-		args = append(args, "--target-lang", targetLang)
+	exe, args, err := w.argv(workerCall{mode: "extract", url: url, targetLang: targetLang, keepOriginal: w.KeepOriginal, metadataOnly: w.MetadataOnly})
+	if err != nil {
+		return Article{}, err
 	}
 
-	cmd := exec.CommandContext(ctx, w.PythonExe, args...)
+	cmd := exec.CommandContext(ctx, exe, args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	err = cmd.Run()
 	if ctx.Err() != nil {
-		return Article{}, fmt.Errorf("python worker timeout: %w", ctx.Err())
+		if parent.Err() == nil {
+			return Article{}, fmt.Errorf("%w after %s: %w", ErrTimeout, timeout, ctx.Err())
+		}
+		return Article{}, fmt.Errorf("python worker: %w", parent.Err())
 	}
 	if err != nil {
 		return Article{}, fmt.Errorf("python worker failed: %v (stderr=%s)", err, stderr.String())
@@ -137,5 +269,25 @@ func (w *Worker) Extract(ctx context.Context, url string, targetLang string) (Ar
 		return Article{}, fmt.Errorf("worker error: %s", resp.Error)
 	}
 
-	return resp.Data, nil
+	art := resp.Data
+	if w.MetadataOnly {
+		art.Text, art.MetadataOnly = "", true
+	}
+	fillMissingLang(&art)
+	return art, nil
+}
+
+// fillMissingLang guesses Article.Lang from the text when the worker
+// couldn't read it from the page (no <html lang> / og:locale).
+func fillMissingLang(art *Article) {
+	if art.Lang != nil && *art.Lang != "" {
+		return
+	}
+	sample := art.Text
+	if sample == "" {
+		sample = art.Title
+	}
+	if code, ok := langdetect.Detect(sample); ok {
+		art.Lang = &code
+	}
 }
@@ -0,0 +1,136 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowOnceScript sleeps through its first call, then answers; URLs
+// containing "fail" get a worker error. Every call appends a line to $2.
+const slowOnceScript = `echo call >> "$2"
+case "$3" in
+*fail*) echo '{"ok": false, "error": "HTTP 404"}'; exit 0 ;;
+esac
+if [ ! -f "$1" ]; then
+  touch "$1"
+  exec sleep 5
+fi
+printf '{"ok": true, "data": {"url": "%s", "title": "Port strike spreads", "text": "Dockers walked out."}}' "$3"
+`
+
+func slowOnceWorker(t *testing.T, escalation float64) (*Worker, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "worker.sh")
+	if err := os.WriteFile(script, []byte(slowOnceScript), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	calls := filepath.Join(dir, "calls")
+	w := &Worker{
+		Command:           []string{"sh", script, filepath.Join(dir, "slept"), calls, "{url}"},
+		TimeoutEscalation: escalation,
+	}
+	return w, calls
+}
+
+func callCount(t *testing.T, path string) int {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(b), "call")
+}
+
+func shortenTimeouts(t *testing.T, d time.Duration) {
+	t.Helper()
+	prevExtract, prevTranslate := extractTimeout, translateTimeout
+	extractTimeout, translateTimeout = d, d
+	t.Cleanup(func() { extractTimeout, translateTimeout = prevExtract, prevTranslate })
+}
+
+func TestExtractEscalatesAfterTimeout(t *testing.T) {
+	shortenTimeouts(t, 300*time.Millisecond)
+	w, calls := slowOnceWorker(t, 10)
+
+	art, err := w.Extract(context.Background(), "https://a.example/1", "")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if art.Title != "Port strike spreads" {
+		t.Errorf("article = %+v", art)
+	}
+	if n := callCount(t, calls); n != 2 {
+		t.Errorf("worker ran %d times, want a timed-out attempt and one retry", n)
+	}
+}
+
+func TestExtractTimeoutWithoutEscalation(t *testing.T) {
+	shortenTimeouts(t, 300*time.Millisecond)
+	w, calls := slowOnceWorker(t, 1)
+
+	_, err := w.Extract(context.Background(), "https://a.example/1", "")
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("err = %v, want ErrTimeout", err)
+	}
+	if n := callCount(t, calls); n != 1 {
+		t.Errorf("worker ran %d times, want no retry", n)
+	}
+}
+
+func TestExtractDoesNotRetryWorkerErrors(t *testing.T) {
+	shortenTimeouts(t, 300*time.Millisecond)
+	w, calls := slowOnceWorker(t, 10)
+
+	_, err := w.Extract(context.Background(), "https://fail.example/1", "")
+	if err == nil || errors.Is(err, ErrTimeout) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("err = %v, want the worker's 404", err)
+	}
+	if n := callCount(t, calls); n != 1 {
+		t.Errorf("worker ran %d times, want no retry on a 404", n)
+	}
+}
+
+func TestExtractMetadataOnly(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake worker needs sh")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "worker.sh")
+	argsFile := filepath.Join(dir, "args")
+	// The worker answers with a body either way; metadata-only drops it
+	body := `echo "$@" > "$1"
+echo '{"ok": true, "data": {"url": "https://a.example/1", "title": "Port strike spreads", "site": "Harbour Times", "text": "Dockers walked out."}}'
+`
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	w := &Worker{Command: []string{"sh", script, argsFile, "{args}"}, MetadataOnly: true}
+
+	art, err := w.Extract(context.Background(), "https://a.example/1", "en")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if art.Text != "" || !art.MetadataOnly {
+		t.Errorf("article = %+v, want no text and MetadataOnly", art)
+	}
+	if art.Title != "Port strike spreads" || art.Site != "Harbour Times" {
+		t.Errorf("metadata lost: %+v", art)
+	}
+	b, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := string(b); !strings.Contains(args, "--metadata-only") || strings.Contains(args, "--target-lang") {
+		t.Errorf("worker args = %q, want --metadata-only and no translation", args)
+	}
+}
@@ -0,0 +1,74 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeScript(t *testing.T, dir string) string {
+	t.Helper()
+	p := filepath.Join(dir, workerScriptRel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte("# worker"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestFindWorkerScript(t *testing.T) {
+	wd := t.TempDir()
+	inWD := writeScript(t, wd)
+	env := writeScript(t, t.TempDir())
+	t.Chdir(wd)
+
+	tests := []struct {
+		name    string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{"env wins", env, env, false},
+		{"env must exist", filepath.Join(wd, "missing.py"), "", true},
+		{"working directory", "", inWD, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(WorkerScriptEnv, tt.env)
+			got, err := FindWorkerScript()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FindWorkerScript() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWorkerFindsScriptLazily(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv(WorkerCommandEnv, "")
+	t.Setenv(WorkerScriptEnv, filepath.Join(t.TempDir(), "worker.py"))
+
+	w, err := NewWorker()
+	if err != nil {
+		t.Fatalf("NewWorker without worker.py: %v", err)
+	}
+	if _, _, err := w.argv(workerCall{mode: "extract", url: "https://example.com"}); err == nil {
+		t.Fatal("argv succeeded without worker.py")
+	}
+
+	// Installed later: the next call finds it
+	script := writeScript(t, t.TempDir())
+	t.Setenv(WorkerScriptEnv, script)
+	exe, args, err := w.argv(workerCall{mode: "extract", url: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exe != "python" || len(args) == 0 || args[0] != script {
+		t.Errorf("argv = %s %v, want python %s ...", exe, args, script)
+	}
+}
@@ -0,0 +1,92 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheChange is one entry whose resolved data differs after a rebuild.
+// Old is zero for entries that weren't cached before.
+type CacheChange struct {
+	Name string
+	Old  DatasetEntry
+	New  DatasetEntry
+}
+
+// RebuildReport summarizes RebuildAutoCache.
+type RebuildReport struct {
+	Kept    int           // re-resolved to the same data
+	Changed []CacheChange // re-resolved to different (or new) data
+	Dropped []Problem     // no longer found by the API; removed
+	Invalid []Problem     // resolved to data failing validation; cached entry kept
+}
+
+// RebuildAutoCache re-resolves names (default: every name in store) through
+// api, waiting delay between calls, validates each result and writes the
+// valid ones over the store's entries; other cached names are untouched.
+// Names the API no longer finds are removed, so a full rebuild also prunes
+// stale names. Any other lookup failure (network, rate limit, server error)
+// aborts the rebuild before anything is written, so an outage can't empty
+// the cache.
+func RebuildAutoCache(ctx context.Context, store *AutoCacheStore, api Resolver, names []string, delay time.Duration) (RebuildReport, error) {
+	var rep RebuildReport
+	old := store.Entries()
+	if len(names) == 0 {
+		for n := range old {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	merged := make(map[string]DatasetEntry, len(old))
+	for n, e := range old {
+		merged[n] = e
+	}
+	for i, name := range names {
+		if i > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return rep, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		info, err := api.ResolveCountry(ctx, name)
+		if errors.Is(err, ErrCountryNotFound) {
+			delete(merged, name)
+			rep.Dropped = append(rep.Dropped, Problem{Entry: name, Message: err.Error()})
+			continue
+		}
+		if err != nil {
+			return rep, fmt.Errorf("%s: %w (cache left unchanged)", name, err)
+		}
+		entry := DatasetEntry{
+			ISO2:          info.ISO2,
+			Languages:     info.Languages,
+			Aliases:       old[name].Aliases,
+			LocalNames:    info.LocalNames,
+			LanguageNames: info.LanguageNames,
+		}
+		if entry.Aliases == nil {
+			entry.Aliases = []string{}
+		}
+		if msgs := entryProblems(name, entry); len(msgs) > 0 {
+			rep.Invalid = append(rep.Invalid, Problem{Entry: name, Message: strings.Join(msgs, "; ")})
+			continue
+		}
+
+		merged[name] = entry
+		if prev, ok := old[name]; ok && reflect.DeepEqual(prev, entry) {
+			rep.Kept++
+		} else {
+			rep.Changed = append(rep.Changed, CacheChange{Name: name, Old: old[name], New: entry})
+		}
+	}
+
+	return rep, store.ReplaceAll(merged)
+}
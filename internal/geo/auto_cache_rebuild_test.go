@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+// fakeResolver answers from a fixed table; names mapped to an error fail
+// with it, unknown names are not found.
+type fakeResolver map[string]any
+
+func (f fakeResolver) ResolveCountry(_ context.Context, name string) (CountryInfo, error) {
+	switch v := f[name].(type) {
+	case CountryInfo:
+		return v, nil
+	case error:
+		return CountryInfo{}, v
+	}
+	return CountryInfo{}, fmt.Errorf("%w in fake", ErrCountryNotFound)
+}
+
+func newTestStore(t *testing.T, entries map[string]DatasetEntry) *AutoCacheStore {
+	t.Helper()
+	store, err := NewAutoCacheStore(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReplaceAll(entries); err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestRebuildAutoCache(t *testing.T) {
+	cached := map[string]DatasetEntry{
+		"france":   {ISO2: "FR", Languages: []string{"fr"}, Aliases: []string{}},
+		"atlantis": {ISO2: "AT", Languages: []string{"de"}, Aliases: []string{}},
+		"germany":  {ISO2: "DE", Languages: []string{"de"}, Aliases: []string{}},
+	}
+	api := fakeResolver{
+		"france":  CountryInfo{ISO2: "FR", Languages: []string{"fr"}},
+		"germany": CountryInfo{ISO2: "DE", Languages: []string{"de", "en"}},
+		"spain":   CountryInfo{ISO2: "ES", Languages: []string{"es"}},
+		"outage":  errors.New("api error: status 503"),
+	}
+
+	tests := []struct {
+		name      string
+		names     []string
+		wantErr   bool
+		wantNames []string
+		kept      int
+		changed   int
+		dropped   int
+	}{
+		{"full rebuild prunes not found", nil, false, []string{"france", "germany"}, 1, 1, 1},
+		{"listed names merge into the cache", []string{"spain"}, false, []string{"atlantis", "france", "germany", "spain"}, 0, 1, 0},
+		{"outage aborts without writing", []string{"france", "outage"}, true, []string{"atlantis", "france", "germany"}, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestStore(t, cached)
+			rep, err := RebuildAutoCache(context.Background(), store, api, tt.names, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if rep.Kept != tt.kept || len(rep.Changed) != tt.changed || len(rep.Dropped) != tt.dropped {
+				t.Errorf("report = %d kept, %d changed, %d dropped; want %d, %d, %d",
+					rep.Kept, len(rep.Changed), len(rep.Dropped), tt.kept, tt.changed, tt.dropped)
+			}
+			got := store.Entries()
+			if len(got) != len(tt.wantNames) {
+				t.Errorf("cache has %d entries, want %v", len(got), tt.wantNames)
+			}
+			for _, n := range tt.wantNames {
+				if _, ok := got[n]; !ok {
+					t.Errorf("cache lost %q", n)
+				}
+			}
+		})
+	}
+}
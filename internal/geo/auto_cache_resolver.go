@@ -15,9 +15,11 @@ func (r *AutoCacheResolver) ResolveCountry(ctx context.Context, name string) (Co
 	// Check auto-cache by the exact name key we stored
 	if e, ok := r.store.Get(name); ok && e.ISO2 != "" && len(e.Languages) > 0 {
 		return CountryInfo{
-			Name:      name,
-			ISO2:      e.ISO2,
-			Languages: normalizeLangs(e.Languages),
+			Name:          name,
+			ISO2:          e.ISO2,
+			Languages:     normalizeLangs(e.Languages),
+			LanguageNames: e.LanguageNames,
+			LocalNames:    e.LocalNames,
 		}, nil
 	}
 
@@ -29,9 +31,11 @@ func (r *AutoCacheResolver) ResolveCountry(ctx context.Context, name string) (Co
 
 	// Write-through cache
 	_ = r.store.Upsert(info.Name, DatasetEntry{
-		ISO2:      info.ISO2,
-		Languages: info.Languages,
-		Aliases:   []string{},
+		ISO2:          info.ISO2,
+		Languages:     info.Languages,
+		Aliases:       []string{},
+		LocalNames:    info.LocalNames,
+		LanguageNames: info.LanguageNames,
 	})
 
 	return info, nil
@@ -32,6 +32,7 @@ func NewAutoCacheStore(path string) (*AutoCacheStore, error) {
 	if err := json.Unmarshal(b, &s.data); err != nil {
 		return nil, err
 	}
+	dropInvalidEntries(s.path, s.data)
 	return s, nil
 }
 
@@ -51,7 +52,33 @@ func (s *AutoCacheStore) Upsert(name string, entry DatasetEntry) error {
 	}
 
 	s.data[name] = entry
+	return s.saveLocked()
+}
+
+// Entries returns a copy of all cached entries.
+func (s *AutoCacheStore) Entries() map[string]DatasetEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]DatasetEntry, len(s.data))
+	for k, v := range s.data {
+		out[k] = v
+	}
+	return out
+}
+
+// ReplaceAll swaps the whole cache for entries and writes it to disk.
+func (s *AutoCacheStore) ReplaceAll(entries map[string]DatasetEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = make(map[string]DatasetEntry, len(entries))
+	for k, v := range entries {
+		s.data[k] = v
+	}
+	return s.saveLocked()
+}
 
+// saveLocked writes the cache atomically; s.mu must be held.
+func (s *AutoCacheStore) saveLocked() error {
 	tmp := s.path + ".tmp"
 	b, err := json.MarshalIndent(s.data, "", "  ")
 	if err != nil {
@@ -5,8 +5,19 @@ import "context"
 // CountryResolver is the common interface implemented by:
 // - DatasetResolver
 // - RestCountriesResolver
+// - LocalRestCountriesResolver
 // - HybridResolver
 // - AutoCacheResolver
 type CountryResolver interface {
 	ResolveCountry(ctx context.Context, name string) (CountryInfo, error)
 }
+
+// MultiCountryResolver also lists every match for an ambiguous name
+// ("Congo", "Guinea"), best first, for callers that can disambiguate.
+// Implemented by RestCountriesResolver.
+type MultiCountryResolver interface {
+	CountryResolver
+	ResolveCountries(ctx context.Context, name string) ([]CountryInfo, error)
+}
+
+var _ MultiCountryResolver = (*RestCountriesResolver)(nil)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,11 @@ type DatasetEntry struct {
 	ISO2      string   `json:"iso2"`
 	Languages []string `json:"languages"`
 	Aliases   []string `json:"aliases"`
+	// Optional country name per language code, used in localized queries.
+	LocalNames map[string]string `json:"local_names,omitempty"`
+	// Optional English language names keyed by the codes in Languages,
+	// used in report labels.
+	LanguageNames map[string]string `json:"language_names,omitempty"`
 }
 
 type DatasetResolver struct {
@@ -29,13 +35,16 @@ func NewDatasetResolver(datasetPath string) (*DatasetResolver, error) {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return nil, err
 	}
+	dropInvalidEntries(datasetPath, raw)
 
 	byKey := map[string]CountryInfo{}
 	for name, e := range raw {
 		info := CountryInfo{
-			Name:      strings.TrimSpace(name),
-			ISO2:      strings.ToUpper(strings.TrimSpace(e.ISO2)),
-			Languages: normalizeLangs(e.Languages),
+			Name:          strings.TrimSpace(name),
+			ISO2:          strings.ToUpper(strings.TrimSpace(e.ISO2)),
+			Languages:     normalizeLangs(e.Languages),
+			LanguageNames: e.LanguageNames,
+			LocalNames:    e.LocalNames,
 		}
 		// main name
 		byKey[normalizeKey(name)] = info
@@ -60,7 +69,7 @@ func (d *DatasetResolver) ResolveCountry(ctx context.Context, name string) (Coun
 	if v, ok := d.byKey[key]; ok {
 		return v, nil
 	}
-	return CountryInfo{}, errors.New("not found in dataset")
+	return CountryInfo{}, fmt.Errorf("%w in dataset", ErrCountryNotFound)
 }
 
 func normalizeLangs(in []string) []string {
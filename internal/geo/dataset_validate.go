@@ -0,0 +1,99 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Problem is one invalid entry in a country dataset file.
+type Problem struct {
+	Entry   string `json:"entry"` // country name key, empty for file-level problems
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Entry == "" {
+		return p.Message
+	}
+	return fmt.Sprintf("%s: %s", p.Entry, p.Message)
+}
+
+// ValidateDataset checks a country_languages.json-style file (also used by
+// the auto cache) and reports every entry that would resolve badly. A missing
+// or unparsable file is reported as a single file-level Problem.
+func ValidateDataset(path string) []Problem {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return []Problem{{Message: err.Error()}}
+	}
+	raw := map[string]DatasetEntry{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []Problem{{Message: "invalid JSON: " + err.Error()}}
+	}
+	return validateEntries(raw)
+}
+
+// validateEntries returns problems sorted by entry name.
+func validateEntries(raw map[string]DatasetEntry) []Problem {
+	names := make([]string, 0, len(raw))
+	for name := range raw {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var out []Problem
+	for _, name := range names {
+		for _, msg := range entryProblems(name, raw[name]) {
+			out = append(out, Problem{Entry: name, Message: msg})
+		}
+	}
+	return out
+}
+
+func entryProblems(name string, e DatasetEntry) []string {
+	var msgs []string
+	if strings.TrimSpace(name) == "" {
+		msgs = append(msgs, "empty country name")
+	}
+	iso2 := strings.TrimSpace(e.ISO2)
+	switch {
+	case iso2 == "":
+		msgs = append(msgs, "missing iso2")
+	case len(iso2) != 2 || !isASCIILetters(iso2):
+		msgs = append(msgs, fmt.Sprintf("iso2 %q is not a 2-letter code", e.ISO2))
+	}
+	if len(normalizeLangs(e.Languages)) == 0 {
+		msgs = append(msgs, "no usable languages")
+	}
+	for _, l := range e.Languages {
+		if l = strings.TrimSpace(l); l != "" && !isASCIILetters(l) {
+			msgs = append(msgs, fmt.Sprintf("language %q is not a language code", l))
+		}
+	}
+	return msgs
+}
+
+func isASCIILetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// dropInvalidEntries removes entries that can't resolve (bad ISO2 or no
+// languages) and logs each one, so one typo doesn't poison resolution.
+func dropInvalidEntries(path string, raw map[string]DatasetEntry) {
+	for _, p := range validateEntries(raw) {
+		if _, ok := raw[p.Entry]; !ok {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "  (%s: skipping %s)\n", filepath.Base(path), p)
+		delete(raw, p.Entry)
+	}
+}
@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const brokenDataset = `{
+  "France":  {"iso2": "FR", "languages": ["fr"]},
+  "Nowhere": {"iso2": "", "languages": ["en"]},
+  "Typoland": {"iso2": "TYP", "languages": ["en"]},
+  "Silentia": {"iso2": "SI", "languages": []},
+  "Codeland": {"iso2": "CL", "languages": ["es", "e5"]}
+}`
+
+func writeDataset(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "countries.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateDataset(t *testing.T) {
+	got := ValidateDataset(writeDataset(t, brokenDataset))
+	want := []Problem{
+		{Entry: "Codeland", Message: `language "e5" is not a language code`},
+		{Entry: "Nowhere", Message: "missing iso2"},
+		{Entry: "Silentia", Message: "no usable languages"},
+		{Entry: "Typoland", Message: `iso2 "TYP" is not a 2-letter code`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ValidateDataset =\n%v\nwant\n%v", got, want)
+	}
+
+	for name, content := range map[string]string{"invalid JSON": `{"France": [`, "missing file": ""} {
+		path := filepath.Join(t.TempDir(), "missing.json")
+		if content != "" {
+			path = writeDataset(t, content)
+		}
+		if got := ValidateDataset(path); len(got) != 1 || got[0].Entry != "" {
+			t.Errorf("%s: ValidateDataset = %v, want one file-level problem", name, got)
+		}
+	}
+}
+
+func TestDatasetResolverSkipsBrokenEntries(t *testing.T) {
+	r, err := NewDatasetResolver(writeDataset(t, brokenDataset))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := r.ResolveCountry(context.Background(), "France"); err != nil || info.ISO2 != "FR" {
+		t.Errorf("France = %+v, %v", info, err)
+	}
+	for _, name := range []string{"Nowhere", "Typoland", "Silentia", "Codeland"} {
+		if info, err := r.ResolveCountry(context.Background(), name); err == nil {
+			t.Errorf("%s resolved to %+v, want it skipped", name, info)
+		}
+	}
+}
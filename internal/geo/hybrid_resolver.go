@@ -8,13 +8,15 @@ import (
 type HybridResolver struct {
 	Cache   *Cache
 	Dataset Resolver // optional
+	Local   Resolver // optional (offline RestCountries dump)
 	API     Resolver // optional
 }
 
-func NewHybridResolver(cache *Cache, dataset Resolver, api Resolver) *HybridResolver {
+func NewHybridResolver(cache *Cache, dataset Resolver, local Resolver, api Resolver) *HybridResolver {
 	return &HybridResolver{
 		Cache:   cache,
 		Dataset: dataset,
+		Local:   local,
 		API:     api,
 	}
 }
@@ -36,6 +38,7 @@ func (h *HybridResolver) ResolveCountry(ctx context.Context, name string) (Count
 	// 1) dataset
 	if h.Dataset != nil {
 		if v, err := h.Dataset.ResolveCountry(ctx, name); err == nil {
+			v = h.backfillLanguages(ctx, v)
 			if h.Cache != nil {
 				_ = h.Cache.Put(key, v)
 			}
@@ -43,10 +46,22 @@ func (h *HybridResolver) ResolveCountry(ctx context.Context, name string) (Count
 		}
 	}
 
-	// 2) api fallback
+	// 2) local restcountries dump
+	if h.Local != nil {
+		if v, err := h.Local.ResolveCountry(ctx, name); err == nil && v.ISO2 != "" {
+			v = h.backfillLanguages(ctx, v)
+			if h.Cache != nil {
+				_ = h.Cache.Put(key, v)
+			}
+			return v, nil
+		}
+	}
+
+	// 3) api fallback
 	if h.API != nil {
 		v, err := h.API.ResolveCountry(ctx, name)
 		if err == nil {
+			v = h.backfillLanguages(ctx, v)
 			if h.Cache != nil {
 				_ = h.Cache.Put(key, v)
 			}
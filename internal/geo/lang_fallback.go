@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fallbackLanguages lists the main news languages for countries whose API
+// records have been seen without a "languages" field. Only consulted when
+// every other source came back empty.
+var fallbackLanguages = map[string][]string{
+	"AE": {"ar"}, "AR": {"es"}, "AT": {"de"}, "AU": {"en"}, "BE": {"nl", "fr"},
+	"BG": {"bg"}, "BR": {"pt"}, "CA": {"en", "fr"}, "CH": {"de", "fr", "it"},
+	"CL": {"es"}, "CN": {"zh"}, "CO": {"es"}, "CZ": {"cs"}, "DE": {"de"},
+	"DK": {"da"}, "EG": {"ar"}, "ES": {"es"}, "FI": {"fi"}, "FR": {"fr"},
+	"GB": {"en"}, "GR": {"el"}, "HK": {"zh", "en"}, "HU": {"hu"}, "ID": {"id"},
+	"IE": {"en"}, "IL": {"he"}, "IN": {"hi", "en"}, "IR": {"fa"}, "IT": {"it"},
+	"JP": {"ja"}, "KE": {"sw", "en"}, "KR": {"ko"}, "MX": {"es"}, "MY": {"ms"},
+	"NG": {"en"}, "NL": {"nl"}, "NO": {"no"}, "NZ": {"en"}, "PE": {"es"},
+	"PH": {"tl", "en"}, "PK": {"ur", "en"}, "PL": {"pl"}, "PT": {"pt"},
+	"RO": {"ro"}, "RS": {"sr"}, "RU": {"ru"}, "SA": {"ar"}, "SE": {"sv"},
+	"SG": {"en", "zh", "ms"}, "TH": {"th"}, "TR": {"tr"}, "TW": {"zh"},
+	"UA": {"uk"}, "US": {"en"}, "VE": {"es"}, "VN": {"vi"}, "ZA": {"en"},
+}
+
+// backfillLanguages fills in Languages for a resolved country that came back
+// without any: first from the other resolvers (looked up by ISO2), then from
+// fallbackLanguages. It logs which fallback was used.
+func (h *HybridResolver) backfillLanguages(ctx context.Context, info CountryInfo) CountryInfo {
+	if info.ISO2 == "" || len(info.Languages) > 0 {
+		return info
+	}
+
+	for _, r := range []struct {
+		name string
+		res  Resolver
+	}{{"dataset", h.Dataset}, {"local dump", h.Local}} {
+		if r.res == nil {
+			continue
+		}
+		v, err := r.res.ResolveCountry(ctx, info.ISO2)
+		if err == nil && strings.EqualFold(v.ISO2, info.ISO2) && len(v.Languages) > 0 {
+			fmt.Fprintf(os.Stderr, "  (%s: no languages from resolver; using %s: %s)\n", info.Name, r.name, strings.Join(v.Languages, ","))
+			info.Languages = v.Languages
+			info.LanguageNames = v.LanguageNames
+			return info
+		}
+	}
+
+	if langs, ok := fallbackLanguages[strings.ToUpper(info.ISO2)]; ok {
+		fmt.Fprintf(os.Stderr, "  (%s: no languages from resolver; using built-in table: %s)\n", info.Name, strings.Join(langs, ","))
+		info.Languages = append([]string(nil), langs...)
+		return info
+	}
+
+	fmt.Fprintf(os.Stderr, "  (%s: no languages known; English only)\n", info.Name)
+	return info
+}
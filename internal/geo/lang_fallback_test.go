@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// rcNoLanguages is a RestCountries answer without the "languages" field.
+const rcNoLanguages = `[{"name": {"common": "Venezuela", "official": "Bolivarian Republic of Venezuela"}, "cca2": "VE"}]`
+
+func TestBackfillLanguagesWhenAPIOmitsThem(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(rcNoLanguages))
+	}))
+	defer srv.Close()
+	api := NewRestCountriesResolver(WithBaseURL(srv.URL+"/v3.1/"), WithHTTPClient(srv.Client()))
+
+	tests := []struct {
+		name  string
+		local Resolver
+		want  []string
+	}{
+		{name: "built-in table", want: []string{"es"}},
+		{
+			name:  "local dump by ISO2 first",
+			local: fakeResolver{"VE": CountryInfo{Name: "Venezuela", ISO2: "VE", Languages: []string{"es", "en"}}},
+			want:  []string{"es", "en"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHybridResolver(nil, nil, tt.local, api)
+			info, err := h.ResolveCountry(context.Background(), "Venezuela")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.ISO2 != "VE" || !reflect.DeepEqual(info.Languages, tt.want) {
+				t.Errorf("info = %+v, want VE with languages %v", info, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackfillLanguagesUnknownCountry(t *testing.T) {
+	h := NewHybridResolver(nil, fakeResolver{"Atlantis": CountryInfo{Name: "Atlantis", ISO2: "XA"}}, nil, nil)
+	info, err := h.ResolveCountry(context.Background(), "Atlantis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Languages) != 0 {
+		t.Errorf("Languages = %v, want none (English only downstream)", info.Languages)
+	}
+}
@@ -1,13 +1,14 @@
 package geo
 
-import (
-	"sort"
-	"strings"
-)
+import "strings"
 
 type DiscoveryTarget struct {
 	ISO2 string // "HU"
 	Lang string // Google News language code, usually ISO-639-1 like "hu"
+
+	// Global marks an anchor locale used for worldwide searches (no country
+	// resolved). Global plans run once per anchor instead of once overall.
+	Global bool
 }
 
 // toGoogleNewsLang normalizes language codes for Google News.
@@ -28,7 +29,7 @@ func toGoogleNewsLang(code string) string {
 		return "ro"
 	case "ces", "cze":
 		return "cs"
-	case "deu", "ger":
+	case "deu", "ger", "gsw": // Swiss German outlets publish in standard German
 		return "de"
 	case "fra", "fre":
 		return "fr"
@@ -62,6 +63,86 @@ func toGoogleNewsLang(code string) string {
 		return "el"
 	case "tur":
 		return "tr"
+	case "eng":
+		return "en"
+	case "ara":
+		return "ar"
+	case "rus":
+		return "ru"
+	case "ita":
+		return "it"
+	case "hin":
+		return "hi"
+	case "ben":
+		return "bn"
+	case "urd":
+		return "ur"
+	case "ind":
+		return "id"
+	case "msa", "may", "zsm":
+		return "ms"
+	case "fas", "per":
+		return "fa"
+	case "heb":
+		return "he"
+	case "tha":
+		return "th"
+	case "vie":
+		return "vi"
+	case "swa":
+		return "sw"
+	case "swe":
+		return "sv"
+	case "nor", "nob", "nno":
+		return "no"
+	case "dan":
+		return "da"
+	case "fin":
+		return "fi"
+	case "cat":
+		return "ca"
+	case "fil", "tgl":
+		return "tl"
+	case "mkd", "mac":
+		return "mk"
+	case "sqi", "alb":
+		return "sq"
+	case "isl", "ice":
+		return "is"
+	case "kat", "geo":
+		return "ka"
+	case "ltz":
+		return "lb"
+	case "gle":
+		return "ga"
+	case "mlt":
+		return "mt"
+	case "roh":
+		return "rm"
+	case "tam":
+		return "ta"
+	case "sin":
+		return "si"
+	case "pus":
+		return "ps"
+	case "kur":
+		return "ku"
+	case "kin":
+		return "rw"
+	case "mri":
+		return "mi"
+	case "grn":
+		return "gn"
+	case "que":
+		return "qu"
+	case "aym":
+		return "ay"
+	case "zul":
+		return "zu"
+	case "xho":
+		return "xh"
+	case "afr":
+		return "af"
 	}
 	return code
 }
@@ -87,6 +168,8 @@ func BuildDiscoveryTargets(country CountryInfo, includeEnglish bool) []Discovery
 		langs = append(langs, l)
 	}
 
+	// country.Languages is ranked (most spoken in the country first); keep
+	// that order so the main language's target comes first
 	for _, l := range country.Languages {
 		add(l)
 	}
@@ -94,8 +177,6 @@ func BuildDiscoveryTargets(country CountryInfo, includeEnglish bool) []Discovery
 		add("en")
 	}
 
-	sort.Strings(langs)
-
 	out := make([]DiscoveryTarget, 0, len(langs))
 	for _, l := range langs {
 		out = append(out, DiscoveryTarget{ISO2: iso2, Lang: l})
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// swissJSON is a trimmed RestCountries /name/switzerland answer.
+const swissJSON = `[{"name":{"common":"Switzerland","official":"Swiss Confederation","nativeName":{"deu":{"common":"Schweiz"},"fra":{"common":"Suisse"}}},
+"cca2":"CH","altSpellings":["CH","Schweiz","Suisse"],
+"languages":{"fra":"French","gsw":"Swiss German","ita":"Italian","roh":"Romansh"},
+"translations":{"fra":{"common":"Suisse"},"deu":{"common":"Schweiz"}}}]`
+
+func TestRestCountriesMultilingualCountry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(swissJSON))
+	}))
+	defer srv.Close()
+
+	info, err := NewRestCountriesResolver(WithBaseURL(srv.URL)).ResolveCountry(context.Background(), "Switzerland")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Swiss German is published as standard German; ranked by in-country share
+	wantLangs := []string{"de", "fr", "it", "rm"}
+	if !reflect.DeepEqual(info.Languages, wantLangs) {
+		t.Errorf("Languages = %v, want %v", info.Languages, wantLangs)
+	}
+	if info.LanguageNames["rm"] != "Romansh" || info.LanguageNames["de"] != "Swiss German" {
+		t.Errorf("LanguageNames = %v", info.LanguageNames)
+	}
+}
+
+func TestExtractLangCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		iso2 string
+		in   map[string]string
+		want []string
+	}{
+		{"in-country share beats global rank", "CH", map[string]string{"fra": "French", "deu": "German", "ita": "Italian", "roh": "Romansh"}, []string{"de", "fr", "it", "rm"}},
+		{"belgium", "BE", map[string]string{"fra": "French", "nld": "Dutch", "deu": "German"}, []string{"nl", "fr", "de"}},
+		{"unlisted country by global rank", "XK", map[string]string{"srp": "Serbian", "sqi": "Albanian"}, []string{"sq", "sr"}},
+		{"newly mapped codes", "MK", map[string]string{"mkd": "Macedonian"}, []string{"mk"}},
+		{"unknown code kept", "GE", map[string]string{"kat": "Georgian", "xyz": "Other"}, []string{"ka", "xyz"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := extractLangCodes(tt.in, tt.iso2)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractLangCodes = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildDiscoveryTargetsKeepsRank(t *testing.T) {
+	got := BuildDiscoveryTargets(CountryInfo{ISO2: "ch", Languages: []string{"de", "fr", "it"}}, true)
+	want := []DiscoveryTarget{{ISO2: "CH", Lang: "de"}, {ISO2: "CH", Lang: "fr"}, {ISO2: "CH", Lang: "it"}, {ISO2: "CH", Lang: "en"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildDiscoveryTargets = %v, want %v", got, want)
+	}
+}
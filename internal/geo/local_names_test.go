@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLocalCountryNamesDeterministic(t *testing.T) {
+	var c rcCountry
+	c.Name.Common = "China"
+	c.Translations = map[string]rcName{"zho": {Common: "中国"}, "chi": {Common: "中華"}, "fra": {Common: "Chine"}}
+	c.Name.NativeName = map[string]rcName{"zho": {Common: "中國"}}
+
+	want := map[string]string{"en": "China", "fr": "Chine", "zh": "中華"} // "chi" sorts first
+	for i := 0; i < 20; i++ {
+		if got := localCountryNames(c); !reflect.DeepEqual(got, want) {
+			t.Fatalf("localCountryNames = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestAutoCacheKeepsLocalNames(t *testing.T) {
+	store, err := NewAutoCacheStore(filepath.Join(t.TempDir(), "cache.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := map[string]string{"en": "Germany", "fr": "Allemagne"}
+	api := fakeResolver{"Germany": CountryInfo{Name: "Germany", ISO2: "DE", Languages: []string{"de"}, LocalNames: local}}
+	r := NewAutoCacheResolver(store, api)
+
+	if _, err := r.ResolveCountry(context.Background(), "Germany"); err != nil {
+		t.Fatal(err)
+	}
+	// Reload from disk and resolve without the API
+	reloaded, err := NewAutoCacheStore(store.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewAutoCacheResolver(reloaded, fakeResolver{}).ResolveCountry(context.Background(), "Germany")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.LocalNames, local) {
+		t.Errorf("cached LocalNames = %v, want %v", got.LocalNames, local)
+	}
+}
+
+func TestDatasetLocalNames(t *testing.T) {
+	ds, err := NewDatasetResolver(filepath.Join("..", "..", "data", "country_languages.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := ds.ResolveCountry(context.Background(), "Mexico")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.LocalNames["fr"] != "Mexique" {
+		t.Errorf("Mexico in French = %q, want Mexique", info.LocalNames["fr"])
+	}
+}
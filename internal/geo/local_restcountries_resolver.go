@@ -0,0 +1,140 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultRestCountriesDumpPath is where the download command saves the full dump.
+const DefaultRestCountriesDumpPath = "data/restcountries_all.json"
+
+// restCountriesDumpFields are requested from /all (the API caps this list at 10).
+const restCountriesDumpFields = "name,cca2,cca3,altSpellings,languages,translations"
+
+// LocalRestCountriesResolver resolves countries from a downloaded RestCountries
+// dump (all countries), giving offline full coverage that the curated
+// country_languages.json subset can't.
+type LocalRestCountriesResolver struct {
+	byKey map[string]CountryInfo // normalized name/official/alias/ISO2/ISO3 -> info
+}
+
+func NewLocalRestCountriesResolver(dumpPath string) (*LocalRestCountriesResolver, error) {
+	data, err := os.ReadFile(filepath.Clean(dumpPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var all []rcCountry
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("parsing restcountries dump %s: %w", dumpPath, err)
+	}
+
+	byKey := map[string]CountryInfo{}
+	add := func(k string, info CountryInfo) {
+		k = normalizeKey(k)
+		if k == "" {
+			return
+		}
+		// First writer wins: common names are indexed before aliases
+		if _, ok := byKey[k]; !ok {
+			byKey[k] = info
+		}
+	}
+
+	for _, c := range all {
+		langs, names := extractLangCodes(c.Languages, c.CCA2)
+		info := CountryInfo{
+			Name:          strings.TrimSpace(c.Name.Common),
+			ISO2:          strings.ToUpper(strings.TrimSpace(c.CCA2)),
+			Languages:     langs,
+			LanguageNames: names,
+			LocalNames:    localCountryNames(c),
+		}
+		if info.ISO2 == "" || info.Name == "" {
+			continue
+		}
+		add(c.Name.Common, info)
+	}
+	for _, c := range all {
+		info, ok := byKey[normalizeKey(c.Name.Common)]
+		if !ok {
+			continue
+		}
+		add(c.Name.Official, info)
+		add(c.CCA2, info)
+		add(c.CCA3, info)
+		for _, a := range c.AltSpellings {
+			add(a, info)
+		}
+	}
+
+	return &LocalRestCountriesResolver{byKey: byKey}, nil
+}
+
+func (l *LocalRestCountriesResolver) ResolveCountry(ctx context.Context, name string) (CountryInfo, error) {
+	_ = ctx
+	key := normalizeKey(name)
+	if key == "" {
+		return CountryInfo{}, errors.New("empty country name")
+	}
+	if v, ok := l.byKey[key]; ok {
+		return v, nil
+	}
+	return CountryInfo{}, fmt.Errorf("%w in local restcountries dump", ErrCountryNotFound)
+}
+
+// DownloadRestCountriesDump fetches every country from the API at baseURL
+// (empty = public instance) and writes the raw JSON to dumpPath.
+// It returns the number of countries saved.
+func DownloadRestCountriesDump(ctx context.Context, baseURL, dumpPath string) (int, error) {
+	r := NewRestCountriesResolver(WithBaseURL(baseURL), WithHTTPClient(&http.Client{Timeout: 60 * time.Second}))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint("/all?fields="+restCountriesDumpFields), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	// Validate before overwriting a good dump with garbage
+	var all []rcCountry
+	if err := json.Unmarshal(b, &all); err != nil {
+		return 0, fmt.Errorf("bad restcountries json: %w", err)
+	}
+	if len(all) == 0 {
+		return 0, errors.New("api returned no countries")
+	}
+
+	dumpPath = filepath.Clean(dumpPath)
+	if err := os.MkdirAll(filepath.Dir(dumpPath), 0o755); err != nil {
+		return 0, err
+	}
+	tmp := dumpPath + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp, dumpPath); err != nil {
+		return 0, err
+	}
+	return len(all), nil
+}
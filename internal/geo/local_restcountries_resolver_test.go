@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const rcDump = `[
+  {"name": {"common": "France", "official": "French Republic"}, "cca2": "FR", "cca3": "FRA",
+   "altSpellings": ["FR", "République française"], "languages": {"fra": "French"}},
+  {"name": {"common": "Congo", "official": "Republic of the Congo"}, "cca2": "CG", "cca3": "COG",
+   "altSpellings": ["CG", "Congo-Brazzaville"], "languages": {"fra": "French", "lin": "Lingala"}},
+  {"name": {"common": "DR Congo", "official": "Democratic Republic of the Congo"}, "cca2": "CD", "cca3": "COD",
+   "altSpellings": ["CD", "Congo", "Congo-Kinshasa"], "languages": {"fra": "French", "lin": "Lingala"}}
+]`
+
+func TestLocalRestCountriesResolver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "restcountries_all.json")
+	if err := os.WriteFile(path, []byte(rcDump), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewLocalRestCountriesResolver(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ name, want string }{
+		{"France", "FR"},
+		{"french republic", "FR"},
+		{"République Française", "FR"},
+		{"fra", "FR"},
+		{"fr", "FR"},
+		{"Congo-Kinshasa", "CD"},
+		{"COD", "CD"},
+		{"Congo", "CG"}, // a common name beats DR Congo's alias
+	}
+	for _, tt := range tests {
+		info, err := r.ResolveCountry(context.Background(), tt.name)
+		if err != nil || info.ISO2 != tt.want {
+			t.Errorf("ResolveCountry(%q) = %s, %v; want %s", tt.name, info.ISO2, err, tt.want)
+		}
+	}
+	if _, err := r.ResolveCountry(context.Background(), "Atlantis"); !errors.Is(err, ErrCountryNotFound) {
+		t.Errorf("unknown country err = %v, want ErrCountryNotFound", err)
+	}
+}
+
+func TestDownloadRestCountriesDump(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/all" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(rcDump))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "data", "restcountries_all.json")
+	n, err := DownloadRestCountriesDump(context.Background(), srv.URL, path)
+	if err != nil || n != 3 {
+		t.Fatalf("DownloadRestCountriesDump = %d, %v; want 3 countries", n, err)
+	}
+	r, err := NewLocalRestCountriesResolver(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := r.ResolveCountry(context.Background(), "DR Congo"); err != nil || info.ISO2 != "CD" {
+		t.Errorf("DR Congo = %+v, %v", info, err)
+	}
+}
@@ -30,6 +30,7 @@ func NewCountryMatcher(datasetPath string) (*CountryMatcher, error) {
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return nil, err
 	}
+	dropInvalidEntries(datasetPath, raw)
 
 	toCanon := map[string]string{}
 	phrases := make([]string, 0, len(raw)*2)
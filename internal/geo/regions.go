@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultRegionsPath maps region names (as the intent lexicon labels them) to
+// member countries, most newsworthy first.
+const DefaultRegionsPath = "data/regions.json"
+
+// DefaultRegions is used for any region the regions file doesn't list (or
+// when the file doesn't exist).
+var DefaultRegions = map[string][]string{
+	"South America": {"Brazil", "Argentina", "Colombia", "Chile", "Peru", "Venezuela", "Ecuador", "Bolivia", "Paraguay", "Uruguay", "Guyana", "Suriname"},
+	"Caribbean":     {"Cuba", "Dominican Republic", "Haiti", "Jamaica", "Trinidad and Tobago", "Bahamas", "Barbados"},
+	"North America": {"United States", "Canada", "Mexico"},
+	"Europe":        {"Germany", "France", "United Kingdom", "Italy", "Spain", "Poland", "Netherlands"},
+	"Africa":        {"Nigeria", "South Africa", "Kenya", "Egypt", "Ethiopia", "Morocco", "Ghana"},
+	"Middle East":   {"Israel", "Iran", "Saudi Arabia", "Turkey", "United Arab Emirates", "Iraq"},
+	"Asia":          {"China", "India", "Japan", "Indonesia", "South Korea", "Pakistan"},
+}
+
+// Regions maps a region name to its countries, in priority order.
+type Regions map[string][]string
+
+// LoadRegions reads a {"Caribbean": ["Cuba", ...], ...} file on top of
+// DefaultRegions. A missing file yields just the defaults.
+func LoadRegions(path string) (Regions, error) {
+	r := Regions{}
+	for k, v := range DefaultRegions {
+		r[k] = v
+	}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string][]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for k, v := range raw {
+		if k = strings.TrimSpace(k); k != "" && len(v) > 0 {
+			r[k] = v
+		}
+	}
+	return r, nil
+}
+
+// Countries returns the countries of the given regions, at most perRegion
+// from each (perRegion <= 0: all), in priority order without repeats. Region
+// names match case-insensitively; unknown regions are skipped.
+func (r Regions) Countries(regions []string, perRegion int) []string {
+	var out []string
+	seen := map[string]struct{}{}
+	for _, name := range regions {
+		var members []string
+		for k, v := range r {
+			if strings.EqualFold(k, name) {
+				members = v
+				break
+			}
+		}
+		taken := 0
+		for _, c := range members {
+			if perRegion > 0 && taken >= perRegion {
+				break
+			}
+			key := strings.ToLower(c)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = append(out, c)
+			taken++
+		}
+	}
+	return out
+}
@@ -12,108 +12,301 @@ import (
 	"time"
 )
 
+// DefaultRestCountriesBaseURL is the public RestCountries v3.1 API.
+const DefaultRestCountriesBaseURL = "https://restcountries.com/v3.1"
+
+// RestCountriesBaseURLEnv lets users point at a self-hosted mirror or caching proxy.
+const RestCountriesBaseURLEnv = "NEWSCHECK_RESTCOUNTRIES_URL"
+
 type RestCountriesResolver struct {
-	Client *http.Client
+	Client  *http.Client
+	BaseURL string // e.g. "https://restcountries.com/v3.1" (no trailing slash needed)
+}
+
+// RestCountriesOption configures a RestCountriesResolver.
+type RestCountriesOption func(*RestCountriesResolver)
+
+// WithBaseURL overrides the API base URL. Empty values are ignored.
+func WithBaseURL(u string) RestCountriesOption {
+	return func(r *RestCountriesResolver) {
+		if u = strings.TrimSpace(u); u != "" {
+			r.BaseURL = u
+		}
+	}
+}
+
+// WithHTTPClient overrides the HTTP client.
+func WithHTTPClient(c *http.Client) RestCountriesOption {
+	return func(r *RestCountriesResolver) {
+		if c != nil {
+			r.Client = c
+		}
+	}
+}
+
+func NewRestCountriesResolver(opts ...RestCountriesOption) *RestCountriesResolver {
+	r := &RestCountriesResolver{
+		Client:  &http.Client{Timeout: 12 * time.Second},
+		BaseURL: DefaultRestCountriesBaseURL,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
 }
 
-func NewRestCountriesResolver() *RestCountriesResolver {
-	return &RestCountriesResolver{
-		Client: &http.Client{Timeout: 12 * time.Second},
+func (r *RestCountriesResolver) endpoint(path string) string {
+	base := strings.TrimRight(strings.TrimSpace(r.BaseURL), "/")
+	if base == "" {
+		base = DefaultRestCountriesBaseURL
 	}
+	return base + path
+}
+
+type rcName struct {
+	Common   string `json:"common"`
+	Official string `json:"official"`
 }
 
 type rcCountry struct {
 	Name struct {
-		Common string `json:"common"`
+		Common     string            `json:"common"`
+		Official   string            `json:"official"`
+		NativeName map[string]rcName `json:"nativeName"`
 	} `json:"name"`
-	CCA2      string            `json:"cca2"`
-	Languages map[string]string `json:"languages"`
+	Translations map[string]rcName `json:"translations"`
+	CCA2         string            `json:"cca2"`
+	CCA3         string            `json:"cca3"`
+	AltSpellings []string          `json:"altSpellings"`
+	Languages    map[string]string `json:"languages"`
 }
 
+// ResolveCountry returns the best match for name; see ResolveCountries.
 func (r *RestCountriesResolver) ResolveCountry(ctx context.Context, name string) (CountryInfo, error) {
+	all, err := r.ResolveCountries(ctx, name)
+	if err != nil {
+		return CountryInfo{}, err
+	}
+	return all[0], nil
+}
+
+// ResolveCountries returns every country the API matches for name, best
+// match first: exact common name, then exact official name, then an exact
+// alternative spelling or translation, then a common name starting with
+// name. Equal scores keep the API's order. A partial name like "Congo" or
+// "Guinea" yields several entries so the caller can disambiguate.
+func (r *RestCountriesResolver) ResolveCountries(ctx context.Context, name string) ([]CountryInfo, error) {
 	q := strings.TrimSpace(name)
 	if q == "" {
-		return CountryInfo{}, errors.New("empty country name")
+		return nil, errors.New("empty country name")
 	}
 
 	// Minimal fields for speed
-	endpoint := fmt.Sprintf(
-		"https://restcountries.com/v3.1/name/%s?fields=name,cca2,languages",
+	endpoint := r.endpoint(fmt.Sprintf(
+		"/name/%s?fields=name,cca2,altSpellings,languages,translations",
 		url.PathEscape(q),
-	)
+	))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return CountryInfo{}, err
+		return nil, err
 	}
 
 	resp, err := r.Client.Do(req)
 	if err != nil {
-		return CountryInfo{}, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return CountryInfo{}, errors.New("not found in api")
+		return nil, fmt.Errorf("%w in api", ErrCountryNotFound)
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return CountryInfo{}, fmt.Errorf("api error: status %d", resp.StatusCode)
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
 	}
 
 	var results []rcCountry
 	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
-		return CountryInfo{}, err
-	}
-	if len(results) == 0 {
-		return CountryInfo{}, errors.New("not found in api")
+		return nil, err
 	}
 
-	// Pick best match:
-	// 1) exact common name match (case-insensitive)
-	// 2) otherwise first entry
-	target := results[0]
+	sort.SliceStable(results, func(i, j int) bool {
+		return rcMatchScore(results[i], q) > rcMatchScore(results[j], q)
+	})
+
+	var out []CountryInfo
 	for _, c := range results {
-		if strings.EqualFold(strings.TrimSpace(c.Name.Common), q) {
-			target = c
-			break
+		langs, names := extractLangCodes(c.Languages, c.CCA2)
+		if len(langs) == 0 {
+			// Sometimes the API might omit languages. Keep empty list, Hybrid will still add English baseline.
+			langs = []string{}
+		}
+		info := CountryInfo{
+			Name:          strings.TrimSpace(c.Name.Common),
+			ISO2:          strings.ToUpper(strings.TrimSpace(c.CCA2)),
+			Languages:     langs,
+			LanguageNames: names,
+			LocalNames:    localCountryNames(c),
+		}
+		if info.ISO2 != "" {
+			out = append(out, info)
 		}
 	}
-
-	langs := extractLangCodes(target.Languages)
-	if len(langs) == 0 {
-		// Sometimes the API might omit languages. Keep empty list, Hybrid will still add English baseline.
-		langs = []string{}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w in api", ErrCountryNotFound)
 	}
+	return out, nil
+}
 
-	info := CountryInfo{
-		Name:      strings.TrimSpace(target.Name.Common),
-		ISO2:      strings.ToUpper(strings.TrimSpace(target.CCA2)),
-		Languages: langs,
+// rcMatchScore rates how well c matches the queried name q.
+func rcMatchScore(c rcCountry, q string) int {
+	eq := func(s string) bool { return strings.EqualFold(strings.TrimSpace(s), q) }
+	switch {
+	case eq(c.Name.Common):
+		return 4
+	case eq(c.Name.Official):
+		return 3
 	}
-
-	if info.ISO2 == "" {
-		return CountryInfo{}, errors.New("api returned empty iso2")
+	for _, a := range c.AltSpellings {
+		if eq(a) {
+			return 2
+		}
 	}
-
-	return info, nil
+	for _, t := range c.Translations {
+		if eq(t.Common) || eq(t.Official) {
+			return 2
+		}
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(c.Name.Common)), strings.ToLower(q)) {
+		return 1
+	}
+	return 0
 }
 
-func extractLangCodes(m map[string]string) []string {
+// extractLangCodes converts a RestCountries "languages" map (ISO-639-3 code ->
+// English name) for the country iso2 into Google News codes, most spoken in
+// that country first (see languageRank), plus a code -> name map. Codes
+// toGoogleNewsLang doesn't know are kept as given.
+func extractLangCodes(m map[string]string, iso2 string) ([]string, map[string]string) {
 	if len(m) == 0 {
-		return nil
+		return nil, nil
 	}
 	out := make([]string, 0, len(m))
-	for code := range m {
-		code = strings.ToLower(strings.TrimSpace(code))
+	names := make(map[string]string, len(m))
+	for raw, name := range m {
+		code := toGoogleNewsLang(raw)
 		if code == "" {
 			continue
 		}
-		// prefer ISO 639-1 style codes like "de", "pl", "hu"
-		if len(code) < 2 || len(code) > 8 {
+		if _, dup := names[code]; dup {
 			continue
 		}
+		names[code] = strings.TrimSpace(name)
 		out = append(out, code)
 	}
-	sort.Strings(out)
+	sort.Slice(out, func(i, j int) bool {
+		ri, rj := languageRank(iso2, out[i]), languageRank(iso2, out[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return out[i] < out[j]
+	})
+	if len(names) == 0 {
+		names = nil
+	}
+	return out, names
+}
+
+// localCountryNames maps Google News language codes to the country's name in
+// that language: translations first, native names for gaps, English common
+// name for "en". Codes are visited in sorted order, so when two map to the
+// same language the pick doesn't depend on map iteration.
+func localCountryNames(c rcCountry) map[string]string {
+	out := map[string]string{}
+	add := func(code, name string) {
+		code = toGoogleNewsLang(code)
+		name = strings.TrimSpace(name)
+		if len(code) != 2 || name == "" {
+			return
+		}
+		if _, ok := out[code]; !ok {
+			out[code] = name
+		}
+	}
+	add("en", c.Name.Common)
+	for _, code := range sortedKeys(c.Translations) {
+		add(code, c.Translations[code].Common)
+	}
+	for _, code := range sortedKeys(c.Name.NativeName) {
+		add(code, c.Name.NativeName[code].Common)
+	}
+	if len(out) == 0 {
+		return nil
+	}
 	return out
 }
+
+func sortedKeys(m map[string]rcName) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countryLanguages orders the official languages of multilingual countries
+// by share of the country's own population, largest first: Switzerland is
+// mostly German-speaking even though French has more speakers worldwide.
+var countryLanguages = map[string][]string{
+	"AF": {"fa", "ps"},
+	"BE": {"nl", "fr", "de"},
+	"BO": {"es", "qu", "ay"},
+	"CA": {"en", "fr"},
+	"CH": {"de", "fr", "it", "rm"},
+	"CM": {"fr", "en"},
+	"CY": {"el", "tr"},
+	"FI": {"fi", "sv"},
+	"IE": {"en", "ga"},
+	"IL": {"he", "ar"},
+	"IN": {"hi", "en", "ta"},
+	"IQ": {"ar", "ku"},
+	"KE": {"sw", "en"},
+	"LK": {"si", "ta"},
+	"LU": {"lb", "fr", "de"},
+	"MT": {"mt", "en"},
+	"NZ": {"en", "mi"},
+	"PE": {"es", "qu", "ay"},
+	"PH": {"tl", "en"},
+	"PK": {"ur", "en"},
+	"PY": {"gn", "es"},
+	"RW": {"rw", "en", "fr"},
+	"SG": {"en", "zh", "ms", "ta"},
+	"TZ": {"sw", "en"},
+	"ZA": {"zu", "xh", "af", "en"},
+}
+
+// widelySpoken orders languages by approximate global speaker count, for
+// countries countryLanguages doesn't list.
+var widelySpoken = []string{
+	"en", "zh", "hi", "es", "fr", "ar", "bn", "pt", "ru", "ur", "id", "de",
+	"ja", "sw", "tr", "ko", "vi", "it", "fa", "ms", "th", "pl", "uk", "nl",
+}
+
+// languageRank places code among the languages of the country iso2: by
+// in-country share when countryLanguages knows the country, then by
+// global speaker count.
+func languageRank(iso2, code string) int {
+	own := countryLanguages[strings.ToUpper(strings.TrimSpace(iso2))]
+	for i, c := range own {
+		if c == code {
+			return i
+		}
+	}
+	for i, c := range widelySpoken {
+		if c == code {
+			return len(own) + i
+		}
+	}
+	return len(own) + len(widelySpoken)
+}
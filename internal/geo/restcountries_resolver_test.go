@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const rcFrance = `[{"name": {"common": "France", "official": "French Republic"},
+  "cca2": "FR", "altSpellings": ["FR", "French Republic"],
+  "languages": {"fra": "French"},
+  "translations": {"deu": {"common": "Frankreich", "official": "Französische Republik"}}}]`
+
+func TestRestCountriesMirror(t *testing.T) {
+	var gotPath, gotFields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotFields = r.URL.Path, r.URL.Query().Get("fields")
+		if r.URL.Path != "/mirror/v3.1/name/France" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(rcFrance))
+	}))
+	defer srv.Close()
+
+	r := NewRestCountriesResolver(WithBaseURL(srv.URL+"/mirror/v3.1/"), WithHTTPClient(srv.Client()))
+	info, err := r.ResolveCountry(context.Background(), "France")
+	if err != nil {
+		t.Fatalf("ResolveCountry: %v (path %s)", err, gotPath)
+	}
+	if gotFields == "" {
+		t.Error("request asked for every field, want a fields= list")
+	}
+	if info.Name != "France" || info.ISO2 != "FR" || len(info.Languages) != 1 || info.Languages[0] != "fr" {
+		t.Errorf("info = %+v", info)
+	}
+	if info.LocalNames["de"] != "Frankreich" {
+		t.Errorf("LocalNames = %v, want de: Frankreich", info.LocalNames)
+	}
+
+	if _, err := r.ResolveCountry(context.Background(), "Atlantis"); !errors.Is(err, ErrCountryNotFound) {
+		t.Errorf("unknown country err = %v, want ErrCountryNotFound", err)
+	}
+}
+
+func TestRestCountriesAmbiguousName(t *testing.T) {
+	// The API answers a partial name with every country containing it, in
+	// its own order
+	const guinea = `[
+  {"name": {"common": "Papua New Guinea", "official": "Independent State of Papua New Guinea"}, "cca2": "PG", "languages": {"eng": "English"}},
+  {"name": {"common": "Guinea-Bissau", "official": "Republic of Guinea-Bissau"}, "cca2": "GW", "languages": {"por": "Portuguese"}},
+  {"name": {"common": "Equatorial Guinea", "official": "Republic of Equatorial Guinea"}, "cca2": "GQ", "languages": {"spa": "Spanish"}},
+  {"name": {"common": "Guinea", "official": "Republic of Guinea"}, "cca2": "GN", "languages": {"fra": "French"}}
+]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(guinea))
+	}))
+	defer srv.Close()
+	r := NewRestCountriesResolver(WithBaseURL(srv.URL))
+
+	all, err := r.ResolveCountries(context.Background(), "guinea")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Exact common name, then the prefix match, then the API's order
+	want := []string{"GN", "GW", "PG", "GQ"}
+	if len(all) != len(want) {
+		t.Fatalf("got %d countries %+v, want %v", len(all), all, want)
+	}
+	for i, w := range want {
+		if all[i].ISO2 != w {
+			t.Errorf("match %d = %s, want %s", i, all[i].ISO2, w)
+		}
+	}
+
+	best, err := r.ResolveCountry(context.Background(), "Guinea")
+	if err != nil || best.ISO2 != "GN" {
+		t.Errorf("ResolveCountry(Guinea) = %+v, %v; want GN, not the API's first result", best, err)
+	}
+	if best, _ := r.ResolveCountry(context.Background(), "Republic of Equatorial Guinea"); best.ISO2 != "GQ" {
+		t.Errorf("official name resolved to %s, want GQ", best.ISO2)
+	}
+}
@@ -1,11 +1,23 @@
 package geo
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrCountryNotFound is wrapped by resolvers when a name matches no country,
+// as opposed to the lookup itself failing.
+var ErrCountryNotFound = errors.New("country not found")
 
 type CountryInfo struct {
 	Name      string   `json:"name"`
 	ISO2      string   `json:"iso2"`
 	Languages []string `json:"languages"`
+	// English language names keyed by the codes in Languages, when the source provides them.
+	LanguageNames map[string]string `json:"language_names,omitempty"`
+	// The country's own name in other languages, keyed by Google News
+	// language code ("fr" -> "Allemagne"), when the source provides them.
+	LocalNames map[string]string `json:"local_names,omitempty"`
 }
 
 type Resolver interface {
@@ -0,0 +1,138 @@
+// Package langdetect provides a small, dependency-free language guesser.
+//
+// It is intentionally lightweight: non-Latin scripts are identified by
+// Unicode script, Latin-script languages by stopword frequency. Good enough
+// to label an article or a query, not meant for short ambiguous snippets.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSample caps how much text is inspected (articles can be long).
+const maxSample = 4000
+
+// ukMarkerShare: Cyrillic text is Ukrainian when at least one letter in
+// ukMarkerShare is і, ї, є or ґ (around 1 in 15 in running Ukrainian).
+const ukMarkerShare = 40
+
+// minHits is the minimum number of stopword hits to trust a Latin-script guess.
+const minHits = 2
+
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "that", "for", "it", "with", "was", "on", "as", "are", "by", "this", "be", "from", "have", "has", "at", "which", "but", "not", "they", "their", "were", "been", "will", "would"},
+	"fr": {"le", "la", "les", "des", "et", "est", "une", "du", "dans", "que", "qui", "pour", "pas", "sur", "au", "avec", "par", "sont", "ce", "cette", "mais", "aux", "ont", "été", "leur", "plus", "selon", "entre"},
+	"es": {"el", "los", "las", "del", "y", "que", "en", "un", "una", "por", "con", "para", "es", "se", "su", "al", "lo", "como", "más", "pero", "sus", "fue", "ha", "este", "entre", "sobre", "también"},
+	"pt": {"os", "as", "do", "da", "dos", "das", "e", "que", "em", "um", "uma", "para", "com", "não", "por", "se", "mais", "foi", "ao", "pelo", "pela", "seu", "sua", "também", "são", "entre", "sobre"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "von", "auf", "für", "im", "dem", "auch", "wird", "werden", "sind", "nach", "bei", "aus", "wie", "über"},
+	"it": {"il", "gli", "della", "delle", "che", "di", "è", "non", "per", "con", "una", "sono", "nel", "alla", "anche", "più", "dei", "degli", "come", "ma", "questo", "stato", "tra", "sulla"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "te", "zijn", "met", "voor", "ook", "aan", "om", "door", "wordt", "bij", "naar", "werd", "heeft", "maar", "over"},
+}
+
+var stopwordIndex = func() map[string][]string {
+	idx := map[string][]string{}
+	for lang, words := range stopwords {
+		for _, w := range words {
+			idx[w] = append(idx[w], lang)
+		}
+	}
+	return idx
+}()
+
+// Detect returns a best-effort ISO-639-1 code for text, or ("", false)
+// when there is not enough signal.
+func Detect(text string) (string, bool) {
+	if len(text) > maxSample {
+		text = text[:maxSample]
+	}
+	text = strings.ToValidUTF8(text, "")
+
+	if code, ok := detectScript(text); ok {
+		return code, true
+	}
+	return detectLatin(text)
+}
+
+// detectScript handles languages identifiable by writing system alone.
+func detectScript(text string) (string, bool) {
+	counts := map[string]int{}
+	letters, ukMarkers := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			counts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			counts["zh"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["ar"]++
+		case unicode.Is(unicode.Hebrew, r):
+			counts["he"]++
+		case unicode.Is(unicode.Greek, r):
+			counts["el"]++
+		case unicode.Is(unicode.Devanagari, r):
+			counts["hi"]++
+		case unicode.Is(unicode.Thai, r):
+			counts["th"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["ru"]++
+			if strings.ContainsRune("іїєґ", unicode.ToLower(r)) {
+				ukMarkers++
+			}
+		}
+	}
+	if letters == 0 {
+		return "", false
+	}
+
+	// Japanese text mixes kana with Han; any meaningful kana means Japanese.
+	if counts["ja"] > 0 && counts["ja"]*10 >= letters {
+		return "ja", true
+	}
+	// Cyrillic is Ukrainian when its own letters are a meaningful share of
+	// it; a stray "Київ" in Russian text stays Russian.
+	if ukMarkers > 0 && ukMarkers*ukMarkerShare >= counts["ru"] {
+		counts["uk"], counts["ru"] = counts["ru"], 0
+	}
+
+	best, bestN := "", 0
+	for code, n := range counts {
+		if n > bestN || (n == bestN && code < best) {
+			best, bestN = code, n
+		}
+	}
+	if best == "" || bestN*2 < letters {
+		return "", false
+	}
+	return best, true
+}
+
+func detectLatin(text string) (string, bool) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	scores := map[string]int{}
+	for _, w := range words {
+		for _, lang := range stopwordIndex[w] {
+			scores[lang]++
+		}
+	}
+
+	best, bestN := "", 0
+	for code, n := range scores {
+		if n > bestN || (n == bestN && code < best) {
+			best, bestN = code, n
+		}
+	}
+	if bestN < minHits {
+		return "", false
+	}
+	return best, true
+}
@@ -0,0 +1,26 @@
+package langdetect
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+		ok               bool
+	}{
+		{"english", "The government said on Monday that it will not change the budget, which has been criticised by the opposition.", "en", true},
+		{"french", "Le gouvernement a annoncé lundi que la réforme des retraites sera présentée au conseil des ministres dans les prochaines semaines.", "fr", true},
+		{"ukrainian", "Президент України заявив, що Київ готовий до переговорів із партнерами щодо безпеки", "uk", true},
+		{"russian with a Ukrainian name", "Президент России заявил, что переговоры с Киевом возможны, но Київ пока не ответил на предложение о встрече в Москве", "ru", true},
+		{"japanese", "サッカー日本代表がワールドカップ予選で勝利した", "ja", true},
+		{"too short", "Paris", "", false},
+		{"empty", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Detect(tt.text)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Detect(%q) = %q, %v; want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package text
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+)
+
+// shingleSize is the number of consecutive tokens hashed together; word
+// triples are robust to the small edits syndicated copies pick up.
+const shingleSize = 3
+
+// SimHash returns a 64-bit fingerprint of s built from token shingles.
+// Near-identical texts get fingerprints a few bits apart; compare them with
+// HammingDistance. Empty text hashes to 0.
+func SimHash(s string) uint64 {
+	toks := Tokenize(s)
+	if len(toks) == 0 {
+		return 0
+	}
+	n := shingleSize
+	if len(toks) < n {
+		n = len(toks)
+	}
+
+	var weights [64]int
+	h := fnv.New64a()
+	for i := 0; i+n <= len(toks); i++ {
+		h.Reset()
+		h.Write([]byte(strings.Join(toks[i:i+n], " ")))
+		v := h.Sum64()
+		for b := 0; b < 64; b++ {
+			if v&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var out uint64
+	for b, w := range weights {
+		if w > 0 {
+			out |= 1 << uint(b)
+		}
+	}
+	return out
+}
+
+// HammingDistance is the number of differing bits between two fingerprints.
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
@@ -0,0 +1,84 @@
+package text
+
+import "strings"
+
+// stemSuffixes are stripped by Stem, longest first. A replacement keeps
+// "studies"/"studied" -> "study".
+var stemSuffixes = []struct{ suffix, repl string }{
+	{"ations", "ate"}, {"ation", "ate"}, {"ings", ""}, {"ing", ""}, {"edly", ""},
+	{"ies", "y"}, {"ied", "y"}, {"ers", ""}, {"er", ""}, {"ed", ""}, {"es", ""},
+	{"ly", ""}, {"s", ""},
+}
+
+// minStemRunes keeps Stem from reducing short words to noise.
+const minStemRunes = 3
+
+// stemExceptions end like an inflection but aren't one ("news" is not the
+// plural of "new").
+var stemExceptions = map[string]bool{
+	"news": true, "series": true, "species": true, "means": true, "always": true,
+	"perhaps": true, "politics": true, "economics": true, "physics": true, "lens": true,
+}
+
+// measuredSuffixes are only stripped from a stem with more than one
+// vowel-consonant sequence (Porter's m > 1), so "water", "paper", "border"
+// and "family" keep their ending while "minister" and "recently" lose it.
+var measuredSuffixes = map[string]bool{"ers": true, "er": true, "ly": true}
+
+// Stem is a light English suffix stripper (not Porter): it maps inflections
+// like "vote"/"voting"/"voted"/"votes" to one form so keyword matching can
+// ignore them. Input should already be lowercase; non-Latin words pass through.
+func Stem(w string) string {
+	if len([]rune(w)) <= minStemRunes || stemExceptions[w] {
+		return w
+	}
+	for _, s := range stemSuffixes {
+		if !strings.HasSuffix(w, s.suffix) {
+			continue
+		}
+		// "crisis", "status", "business" aren't plurals
+		if s.suffix == "s" && strings.ContainsRune("siu", rune(w[len(w)-2])) {
+			break
+		}
+		base := w[:len(w)-len(s.suffix)] + s.repl
+		if len([]rune(base)) < minStemRunes || measuredSuffixes[s.suffix] && measure(base) < 2 {
+			continue
+		}
+		w = base
+		break
+	}
+
+	// "stopp(ing)" -> "stop"
+	if n := len(w); n > minStemRunes && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouls", rune(w[n-1])) {
+		w = w[:n-1]
+	}
+	// "vote" and "vot(ing)" meet at "vot"
+	if n := len(w); n > minStemRunes && w[n-1] == 'e' {
+		w = w[:n-1]
+	}
+	return w
+}
+
+// measure counts the vowel-consonant sequences of w, Porter's m: "wat" and
+// "fami" have 1, "minist" has 2. "y" after a consonant counts as a vowel.
+func measure(w string) int {
+	m := 0
+	prevVowel := false
+	for i := 0; i < len(w); i++ {
+		vowel := strings.IndexByte("aeiou", w[i]) >= 0 || w[i] == 'y' && i > 0 && !prevVowel
+		if prevVowel && !vowel {
+			m++
+		}
+		prevVowel = vowel
+	}
+	return m
+}
+
+// StemAll returns the stems of tokens.
+func StemAll(tokens []string) []string {
+	out := make([]string, len(tokens))
+	for i, t := range tokens {
+		out[i] = Stem(t)
+	}
+	return out
+}
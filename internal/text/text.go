@@ -0,0 +1,195 @@
+// Package text holds the tokenization, stopword and keyword helpers shared by
+// planning, discovery and scoring, so they all see the same tokens.
+package text
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"newscheck/internal/langdetect"
+)
+
+// MinKeywordRunes is the shortest token Keywords keeps.
+const MinKeywordRunes = 3
+
+var stopwords = map[string][]string{
+	"en": {
+		"the", "a", "an", "and", "or", "but", "to", "of", "in", "on", "at", "for", "with", "by", "from",
+		"is", "are", "was", "were", "be", "been", "being", "this", "that", "these", "those",
+		"what", "who", "where", "when", "why", "how", "it", "its", "as", "has", "have", "will", "would",
+		"not", "they", "their", "which",
+		// Query filler: common in prompts, useless for matching
+		"latest", "major", "developments", "development",
+	},
+	"fr": {"le", "la", "les", "des", "et", "est", "une", "du", "dans", "que", "qui", "pour", "pas", "sur", "au", "avec", "par", "sont", "ce", "cette", "mais", "aux", "ont", "été", "leur", "plus", "selon", "entre"},
+	"es": {"el", "los", "las", "del", "y", "que", "en", "un", "una", "por", "con", "para", "es", "se", "su", "al", "lo", "como", "más", "pero", "sus", "fue", "ha", "este", "entre", "sobre", "también"},
+	"pt": {"os", "as", "do", "da", "dos", "das", "e", "que", "em", "um", "uma", "para", "com", "não", "por", "se", "mais", "foi", "ao", "pelo", "pela", "seu", "sua", "também", "são", "entre", "sobre"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "von", "auf", "für", "im", "dem", "auch", "wird", "werden", "sind", "nach", "bei", "aus", "wie", "über"},
+	"it": {"il", "gli", "della", "delle", "che", "di", "è", "non", "per", "con", "una", "sono", "nel", "alla", "anche", "più", "dei", "degli", "come", "ma", "questo", "stato", "tra", "sulla"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "te", "zijn", "met", "voor", "ook", "aan", "om", "door", "wordt", "bij", "naar", "werd", "heeft", "maar", "over"},
+}
+
+var stopwordSets = func() map[string]map[string]struct{} {
+	sets := make(map[string]map[string]struct{}, len(stopwords))
+	for lang, words := range stopwords {
+		set := make(map[string]struct{}, len(words))
+		for _, w := range words {
+			set[w] = struct{}{}
+		}
+		sets[lang] = set
+	}
+	return sets
+}()
+
+// StopwordSet returns the stopwords for an ISO-639-1 code. English is always
+// included, since mixed-language queries and headlines are common. Unknown
+// or empty codes get English only. The result must not be modified.
+func StopwordSet(lang string) map[string]struct{} {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == "en" || stopwordSets[lang] == nil {
+		return stopwordSets["en"]
+	}
+	merged := make(map[string]struct{}, len(stopwordSets["en"])+len(stopwordSets[lang]))
+	for w := range stopwordSets["en"] {
+		merged[w] = struct{}{}
+	}
+	for w := range stopwordSets[lang] {
+		merged[w] = struct{}{}
+	}
+	return merged
+}
+
+// Tokenize lowercases s and splits it on anything that isn't a letter,
+// digit or combining mark, in any script. Runs of scripts written without
+// spaces (Chinese, Japanese, Thai, ...) become overlapping two-character
+// tokens ("東京都" -> "東京", "京都"), so titles in those languages still
+// share tokens.
+func Tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsMark(r)
+	})
+	out := fields[:0:0]
+	for _, f := range fields {
+		out = appendSegments(out, f)
+	}
+	return out
+}
+
+// noSpaceScripts are written without spaces between words.
+var noSpaceScripts = []*unicode.RangeTable{
+	unicode.Han, unicode.Hiragana, unicode.Katakana,
+	unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar,
+}
+
+// noSpaceCommon are Common-script characters used inside no-space words:
+// the katakana long vowel mark ("サッカー") and the kanji repetition mark
+// ("人々").
+const noSpaceCommon = "ー々"
+
+func isNoSpace(r rune) bool {
+	return unicode.In(r, noSpaceScripts...) || strings.ContainsRune(noSpaceCommon, r)
+}
+
+// appendSegments appends field to out, splitting it where it switches
+// between spaced and no-space scripts and turning no-space runs into
+// character bigrams. A combining mark stays with the character before it.
+func appendSegments(out []string, field string) []string {
+	var clusters []string // current no-space run, one entry per character
+	var word strings.Builder
+	flushWord := func() {
+		if word.Len() > 0 {
+			out = append(out, word.String())
+			word.Reset()
+		}
+	}
+	flushRun := func() {
+		if len(clusters) == 1 {
+			out = append(out, clusters[0])
+		}
+		for i := 0; i+1 < len(clusters); i++ {
+			out = append(out, clusters[i]+clusters[i+1])
+		}
+		clusters = clusters[:0]
+	}
+
+	for _, r := range field {
+		switch {
+		case unicode.IsMark(r) && len(clusters) > 0 && word.Len() == 0:
+			clusters[len(clusters)-1] += string(r)
+		case isNoSpace(r):
+			flushWord()
+			clusters = append(clusters, string(r))
+		default:
+			flushRun()
+			word.WriteRune(r)
+		}
+	}
+	flushWord()
+	flushRun()
+	return out
+}
+
+// isNoSpaceToken reports whether tok came from a no-space script run.
+func isNoSpaceToken(tok string) bool {
+	for _, r := range tok {
+		return isNoSpace(r)
+	}
+	return false
+}
+
+// isKanaOnly reports whether tok is all hiragana: in Japanese that is
+// mostly particles and verb endings, not content.
+func isKanaOnly(tok string) bool {
+	for _, r := range tok {
+		if !unicode.In(r, unicode.Hiragana) && !unicode.IsMark(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keywords returns the significant tokens of s, most frequent first (ties
+// alphabetical, a total order over distinct tokens, so the result never
+// depends on map iteration), dropping stopwords and tokens shorter than MinKeywordRunes.
+// No-space script bigrams are kept instead, except hiragana-only ones and
+// lone characters. An empty lang is detected from s. max <= 0 returns all
+// keywords.
+func Keywords(s, lang string, max int) []string {
+	if lang == "" {
+		lang, _ = langdetect.Detect(s)
+	}
+	stop := StopwordSet(lang)
+
+	counts := map[string]int{}
+	for _, tok := range Tokenize(s) {
+		if isNoSpaceToken(tok) {
+			if utf8.RuneCountInString(tok) < 2 || isKanaOnly(tok) {
+				continue
+			}
+		} else if len([]rune(tok)) < MinKeywordRunes {
+			continue
+		}
+		if _, ok := stop[tok]; ok {
+			continue
+		}
+		counts[tok]++
+	}
+
+	out := make([]string, 0, len(counts))
+	for k := range counts {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if counts[out[i]] == counts[out[j]] {
+			return out[i] < out[j]
+		}
+		return counts[out[i]] > counts[out[j]]
+	})
+
+	if max > 0 && len(out) > max {
+		out = out[:max]
+	}
+	return out
+}
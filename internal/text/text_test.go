@@ -0,0 +1,154 @@
+package text
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name, in string
+		want     []string
+	}{
+		{"ascii", "Hello, World! 2024", []string{"hello", "world", "2024"}},
+		{"accents kept", "Élection présidentielle: résultats", []string{"élection", "présidentielle", "résultats"}},
+		{"combining mark", "Café au lait", []string{"café", "au", "lait"}},
+		{"cyrillic", "Президент України", []string{"президент", "україни"}},
+		{"arabic", "الانتخابات في مصر", []string{"الانتخابات", "في", "مصر"}},
+		{"han bigrams", "東京都", []string{"東京", "京都"}},
+		{"single han", "中", []string{"中"}},
+		{"mixed scripts", "G7峰会", []string{"g7", "峰会"}},
+		{"katakana long vowel", "サッカー日本代表", []string{"サッ", "ッカ", "カー", "ー日", "日本", "本代", "代表"}},
+		{"long vowel mid-word", "ワールドカップ", []string{"ワー", "ール", "ルド", "ドカ", "カッ", "ップ"}},
+		{"repetition mark", "人々", []string{"人々"}},
+		{"thai", "ข่าวไทย", []string{"ข่า", "าว", "วไ", "ไท", "ทย"}},
+		{"empty", "", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	tests := []struct {
+		name, in, lang string
+		max            int
+		want           []string
+	}{
+		{"english stopwords and short tokens", "The latest on the EU and the pension strikes", "en", 0, []string{"pension", "strikes"}},
+		// ties sort by bytes, so "é" comes after ASCII letters
+		{"frequency then alphabetical", "vote recount vote delay", "en", 0, []string{"vote", "delay", "recount"}},
+		{"max", "alpha beta gamma delta", "en", 2, []string{"alpha", "beta"}},
+		{"french stopwords", "La grève contre la réforme des retraites", "fr", 0, []string{"contre", "grève", "retraites", "réforme"}},
+		{"detected language", "Les manifestations contre la réforme des retraites dans les villes", "", 0, []string{"contre", "manifestations", "retraites", "réforme", "villes"}},
+		{"chinese bigrams", "中国经济", "zh", 0, []string{"中国", "国经", "经济"}},
+		{"hiragana-only bigrams dropped", "東京でした", "ja", 0, []string{"京で", "東京"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Keywords(tt.in, tt.lang, tt.max); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Keywords(%q, %q, %d) = %q, want %q", tt.in, tt.lang, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStopwordSet(t *testing.T) {
+	tests := []struct {
+		lang, word string
+		want       bool
+	}{
+		{"en", "the", true},
+		{"fr", "the", true}, // English is always included
+		{"fr", "les", true},
+		{"en", "les", false},
+		{"xx", "the", true},
+		{" DE ", "und", true},
+		{"de", "pension", false},
+	}
+	for _, tt := range tests {
+		if _, got := StopwordSet(tt.lang)[tt.word]; got != tt.want {
+			t.Errorf("StopwordSet(%q)[%q] = %v, want %v", tt.lang, tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+		publishers     []string
+	}{
+		{"known outlet", "Strikes paralyse France - Reuters", "Strikes paralyse France", nil},
+		{"domain suffix", "Strikes paralyse France | example.com", "Strikes paralyse France", nil},
+		{"stacked suffixes", "Strikes paralyse France - BBC News - Yahoo News", "Strikes paralyse France", nil},
+		{"leading tag", "BREAKING: Strikes paralyse France", "Strikes paralyse France", nil},
+		{"non-tag prefix kept", "US - China talks resume", "US - China talks resume", nil},
+		{"inner dash kept", "Macron - the reform that divides France", "Macron - the reform that divides France", nil},
+		{"unicode", "Grève générale en France – Le Monde", "Grève générale en France", nil},
+		{"only a tag", "BREAKING: ", "BREAKING:", nil},
+		{"own publisher", "Grève dans les ports - Ouest-France", "Grève dans les ports", []string{"Ouest-France"}},
+		{"own publisher, other case and article", "Streik in Häfen | the local", "Streik in Häfen", []string{"The Local"}},
+		{"long publisher name", "Port strike - Radio Televizija Republike Srpske Online", "Port strike", []string{"Radio Televizija Republike Srpske Online"}},
+		{"other publisher kept", "Grève dans les ports - Ouest-France", "Grève dans les ports - Ouest-France", []string{"Le Figaro"}},
+		{"publisher inside the title kept", "Ouest-France - les ports en grève", "Ouest-France - les ports en grève", []string{"Ouest-France"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTitle(tt.in, tt.publishers...); got != tt.want {
+				t.Errorf("NormalizeTitle(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"vote", "vot"},
+		{"voting", "vot"},
+		{"voted", "vot"},
+		{"votes", "vot"},
+		{"studies", "study"},
+		{"stopping", "stop"},
+		{"crisis", "crisis"},
+		{"status", "status"},
+		{"war", "war"},
+		{"news", "news"},
+		{"series", "series"},
+		{"water", "water"},
+		{"paper", "paper"},
+		{"power", "power"},
+		{"border", "border"},
+		{"borders", "border"},
+		{"leaders", "leader"},
+		{"minister", "minist"},
+		{"ministers", "minist"},
+		{"family", "family"},
+		{"families", "family"},
+		{"recently", "recent"},
+		{"東京", "東京"},
+	}
+	for _, tt := range tests {
+		if got := Stem(tt.in); got != tt.want {
+			t.Errorf("Stem(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSimHash(t *testing.T) {
+	a := SimHash("France hit by nationwide strikes over the pension reform plan on Tuesday")
+	b := SimHash("France hit by nationwide strikes over the pension reform plan on Tuesday morning")
+	c := SimHash("Heavy rain floods parts of southern Brazil as rivers burst their banks")
+	if d := HammingDistance(a, b); d > 10 {
+		t.Errorf("near-duplicates are %d bits apart, want <= 10", d)
+	}
+	if d := HammingDistance(a, c); d < 10 {
+		t.Errorf("unrelated texts are %d bits apart, want >= 10", d)
+	}
+	if SimHash("") != 0 {
+		t.Error("SimHash(\"\") != 0")
+	}
+}
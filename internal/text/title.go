@@ -0,0 +1,97 @@
+package text
+
+import (
+	"regexp"
+	"strings"
+)
+
+// knownSources are outlet names commonly appended to headlines by Google News
+// and publisher feeds ("... - Reuters", "... | BBC News"), for titles whose
+// own publisher isn't known or that carry a syndicating outlet's name too.
+// Compared lowercase.
+var knownSources = map[string]bool{
+	"reuters": true, "ap": true, "ap news": true, "associated press": true, "afp": true,
+	"bbc": true, "bbc news": true, "cnn": true, "cnbc": true, "nbc news": true,
+	"abc news": true, "cbs news": true, "fox news": true, "npr": true,
+	"the guardian": true, "guardian": true, "the new york times": true, "nytimes": true,
+	"the washington post": true, "washington post": true, "bloomberg": true,
+	"financial times": true, "ft": true, "the economist": true, "wall street journal": true,
+	"the wall street journal": true, "wsj": true, "al jazeera": true, "al jazeera english": true,
+	"politico": true, "axios": true, "the hill": true, "dw": true, "deutsche welle": true,
+	"france 24": true, "euronews": true, "le monde": true, "el país": true, "el pais": true,
+	"der spiegel": true, "spiegel": true, "yahoo news": true, "msn": true,
+	"the independent": true, "the telegraph": true, "sky news": true, "times of india": true,
+}
+
+// reLeadingTag matches "BREAKING:", "LIVE -", "UPDATE |" style prefixes.
+// Only the tags in headlineTags are stripped, so "US - China talks" survives.
+var reLeadingTag = regexp.MustCompile(`^\s*([A-Z][A-Z ]{1,20}?)\s*[:|\-–—]\s+`)
+
+var headlineTags = map[string]bool{
+	"BREAKING": true, "BREAKING NEWS": true, "LIVE": true, "LIVE UPDATES": true,
+	"UPDATE": true, "UPDATED": true, "EXCLUSIVE": true, "WATCH": true, "VIDEO": true,
+	"OPINION": true, "ANALYSIS": true, "EXPLAINER": true, "FACT CHECK": true, "JUST IN": true,
+}
+
+// reDomainLike matches suffixes that are just a site name like "example.com".
+var reDomainLike = regexp.MustCompile(`(?i)^[a-z0-9-]+(\.[a-z0-9-]+)*\.[a-z]{2,}$`)
+
+// titleSeparators delimit a trailing source name; checked in this order.
+var titleSeparators = []string{" - ", " | ", " – ", " — "}
+
+// NormalizeTitle strips the publisher suffix and leading ALLCAPS tag from a
+// headline so the same story from different outlets yields the same tokens.
+// A suffix is a publisher when it names one of publishers (the candidate's
+// own outlet and source, compared case-insensitively and without a leading
+// "The"), a knownSources outlet, or a domain. The result keeps the original
+// casing.
+func NormalizeTitle(title string, publishers ...string) string {
+	t := strings.TrimSpace(title)
+
+	for {
+		stripped := false
+		for _, sep := range titleSeparators {
+			i := strings.LastIndex(t, sep)
+			if i <= 0 {
+				continue
+			}
+			if isSourceSuffix(t[i+len(sep):], publishers) {
+				t = strings.TrimSpace(t[:i])
+				stripped = true
+				break
+			}
+		}
+		if !stripped {
+			break
+		}
+	}
+
+	if m := reLeadingTag.FindStringSubmatch(t); m != nil && headlineTags[strings.TrimSpace(m[1])] && len(m[0]) < len(t) {
+		t = strings.TrimSpace(t[len(m[0]):])
+	}
+	return t
+}
+
+func isSourceSuffix(s string, publishers []string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	name := outletKey(s)
+	for _, p := range publishers {
+		if k := outletKey(p); k != "" && k == name {
+			return true
+		}
+	}
+	if len(strings.Fields(s)) > 4 {
+		return false
+	}
+	return knownSources[strings.ToLower(s)] || reDomainLike.MatchString(s)
+}
+
+// outletKey is an outlet name for comparison: lowercased, single-spaced,
+// without a leading "the".
+func outletKey(s string) string {
+	k := strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	return strings.TrimPrefix(k, "the ")
+}
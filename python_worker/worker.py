@@ -46,6 +46,9 @@ class Extracted:
     lang: Optional[str]
     text: str
     fetched_at: str
+    # Untranslated title/text, only with --keep-original when translating
+    original_title: Optional[str] = None
+    original_text: Optional[str] = None
 
 
 def iso_now() -> str:
@@ -442,6 +445,8 @@ def main() -> int:
     ap.add_argument("--max-bytes", type=int, default=3_000_000)
     ap.add_argument("--debug", action="store_true", help="Print debug info to stderr")
     ap.add_argument("--target-lang", help="Target language code to translate to (e.g. 'en', 'fr')")
+    ap.add_argument("--keep-original", action="store_true", help="Also return the untranslated title and text")
+    ap.add_argument("--metadata-only", action="store_true", help="Return title, site, date, author and language only: no main text, no translation")
     args = ap.parse_args()
 
     started = time.time()
@@ -547,10 +552,17 @@ def main() -> int:
         published = pick_meta(soup, "article:published_time", "og:updated_time", "date", "pubdate")
 
         lang = clean_lang(detect_lang(soup) or pick_meta(soup, "og:locale"))
-        text = extract_main_text(soup, html_text)
+        # Metadata-only skips the costly parts: main-text extraction and translation
+        text = "" if args.metadata_only else extract_main_text(soup, html_text)
+
+        original_title = None
+        original_text = None
 
         # Translation logic
-        if args.target_lang and args.target_lang != lang:
+        if args.target_lang and args.target_lang != lang and not args.metadata_only:
+            if args.keep_original:
+                original_title, original_text = title, text
+
             if args.debug:
                 print(f"[DEBUG] Translating content to {args.target_lang}...", file=sys.stderr, flush=True)
 
@@ -590,6 +602,8 @@ def main() -> int:
             lang=lang,
             text=text,
             fetched_at=iso_now(),
+            original_title=original_title,
+            original_text=original_text,
         )
 
         elapsed = int((time.time() - started) * 1000)